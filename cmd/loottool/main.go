@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func main() {
+	lootDir := flag.String("loot", filepath.Join("assets", "loot"), "directory of loot table assets")
+	objDir := flag.String("objects", filepath.Join("assets", "objects"), "directory of object assets")
+	level := flag.Int("level", 1, "level of the source rolling the table")
+	rolls := flag.Int("n", 10000, "how many times to roll the table")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: loottool [-loot dir] [-objects dir] [-level n] [-n rolls] <table-id>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *rolls < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	table, err := loadTable(*lootDir, *objDir, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s at level %d, %d rolls:\n\n", flag.Arg(0), *level, *rolls)
+	printPreview(os.Stdout, preview(table, *level, *rolls, rand.IntN), *rolls)
+}
+
+// loadTable loads and resolves every loot table and the objects they drop,
+// returning the one named id.
+func loadTable(lootDir, objDir, id string) (*assets.LootTable, error) {
+	objs, err := storage.NewFileStore[*assets.Object](objDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading objects: %w", err)
+	}
+	tables, err := storage.NewFileStore[*assets.LootTable](lootDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading loot tables: %w", err)
+	}
+	for tid, t := range tables.GetAll() {
+		if err := t.Resolve(objs, tables); err != nil {
+			return nil, fmt.Errorf("loot table %s: %w", tid, err)
+		}
+	}
+	table := tables.Get(id)
+	if table == nil {
+		return nil, fmt.Errorf("no loot table %q in %s", id, lootDir)
+	}
+	if cycle := table.FindCycle(id); cycle != nil {
+		return nil, fmt.Errorf("loot table %s nests itself via %s", id, strings.Join(cycle, " -> "))
+	}
+	return table, nil
+}
+
+// dropRate is how often an object came out of the rolls.
+type dropRate struct {
+	object string
+	rolls  int // rolls that dropped at least one
+	total  int // copies dropped across all rolls
+}
+
+// preview rolls table n times and tallies each object it drops, most common
+// first. The empty object name counts the rolls that dropped nothing.
+func preview(table *assets.LootTable, level, n int, randIntN func(int) int) []dropRate {
+	rates := make(map[string]*dropRate)
+	tally := func(id string, count int) {
+		r, ok := rates[id]
+		if !ok {
+			r = &dropRate{object: id}
+			rates[id] = r
+		}
+		r.rolls++
+		r.total += count
+	}
+	for range n {
+		counts := make(map[string]int)
+		for _, spec := range table.Roll(level, randIntN) {
+			counts[spec.Object.Id()]++
+		}
+		if len(counts) == 0 {
+			tally("", 0)
+		}
+		for id, count := range counts {
+			tally(id, count)
+		}
+	}
+
+	out := make([]dropRate, 0, len(rates))
+	for _, r := range rates {
+		out = append(out, *r)
+	}
+	slices.SortFunc(out, func(a, b dropRate) int {
+		if a.rolls != b.rolls {
+			return b.rolls - a.rolls
+		}
+		return strings.Compare(a.object, b.object)
+	})
+	return out
+}
+
+// printPreview writes a table of drop rates out of n rolls.
+func printPreview(out io.Writer, rates []dropRate, n int) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OBJECT\tDROP RATE\tAVG PER ROLL")
+	for _, r := range rates {
+		rate := 100 * float64(r.rolls) / float64(n)
+		if r.object == "" {
+			fmt.Fprintf(w, "(nothing)\t%.1f%%\t-\n", rate)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%.1f%%\t%.2f\n", r.object, rate, float64(r.total)/float64(n))
+	}
+	w.Flush()
+}
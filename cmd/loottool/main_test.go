@@ -0,0 +1,29 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestPreview(t *testing.T) {
+	coin := storage.NewResolvedSmartIdentifier("coin", &assets.Object{Aliases: []string{"coin"}, ShortDesc: "a coin"})
+	table := &assets.LootTable{Entries: []assets.LootEntry{
+		{OneOf: []assets.LootEntry{{Object: coin, MinCount: 2}, {}}},
+	}}
+
+	// Alternate between the coin and the blank.
+	roll := 0
+	dice := func(int) int {
+		roll++
+		return roll % 2
+	}
+
+	got := preview(table, 1, 4, dice)
+	want := []dropRate{{object: "", rolls: 2}, {object: "coin", rolls: 2, total: 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("preview() = %+v, want %+v", got, want)
+	}
+}
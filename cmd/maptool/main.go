@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+	"github.com/pixil98/go-mud/internal/worldmap"
+)
+
+func main() {
+	dir := flag.String("rooms", filepath.Join("assets", "rooms"), "directory of room assets")
+	radius := flag.Int("radius", 5, "how many rooms out from the start room to map")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: maptool [-rooms dir] [-radius n] <room-id>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	start := flag.Arg(0)
+
+	rooms, err := storage.NewFileStore[*assets.Room](*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: loading rooms: %v\n", err)
+		os.Exit(1)
+	}
+	if rooms.Get(start) == nil {
+		fmt.Fprintf(os.Stderr, "error: no room %q in %s\n", start, *dir)
+		os.Exit(1)
+	}
+
+	grid := worldmap.Layout(assetGraph{rooms}, start, *radius, nil)
+	fmt.Println(grid.Render(worldmap.RenderOptions{Here: start}))
+	fmt.Println()
+	fmt.Println(worldmap.Legend)
+}
+
+// assetGraph presents room assets to the map layout. Exits are shown as
+// builders wrote them: hidden exits included, doors in their starting state.
+type assetGraph struct {
+	rooms storage.Storer[*assets.Room]
+}
+
+func (g assetGraph) Exits(roomId string) []worldmap.Exit {
+	room := g.rooms.Get(roomId)
+	if room == nil {
+		return nil
+	}
+	exits := make([]worldmap.Exit, 0, len(room.Exits))
+	for dir, ex := range room.Exits {
+		e := worldmap.Exit{Direction: dir, Closed: ex.Closure != nil && ex.Closure.Closed}
+		if g.rooms.Get(ex.Room.Id()) != nil {
+			e.To = ex.Room.Id()
+		}
+		exits = append(exits, e)
+	}
+	return exits
+}
+
+func (g assetGraph) Zone(roomId string) string {
+	if room := g.rooms.Get(roomId); room != nil {
+		return room.Zone.Id()
+	}
+	return ""
+}
@@ -9,12 +9,14 @@ import (
 // Config holds the top-level server configuration.
 type Config struct {
 	TickInterval  string              `json:"tick_interval"`
+	HoursPerTick  float64             `json:"hours_per_tick"` // game hours per tick; 0 uses the default
 	Listeners     []ListenerConfig    `json:"listeners"`
 	Storage       StorageConfig       `json:"storage"`
 	PlayerManager PlayerManagerConfig `json:"player_manager"`
 }
 
-// Validate checks all sub-configs and ensures tick_interval is a valid duration of at least one second.
+// Validate checks all sub-configs, ensures tick_interval is a valid duration of
+// at least one second and that hours_per_tick is not negative.
 func (c *Config) Validate() error {
 	var errs []error
 
@@ -24,6 +26,9 @@ func (c *Config) Validate() error {
 	} else if d < time.Second {
 		errs = append(errs, errors.New("tick_interval must be at least 1 second"))
 	}
+	if c.HoursPerTick < 0 {
+		errs = append(errs, errors.New("hours_per_tick must not be negative"))
+	}
 
 	for i, l := range c.Listeners {
 		err := l.validate()
@@ -26,6 +26,10 @@ type StorageConfig struct {
 	Races      AssetConfig[*assets.Race]      `json:"races"`
 	Trees      AssetConfig[*assets.Tree]      `json:"trees"`
 	Abilities  AssetConfig[*assets.Ability]   `json:"abilities"`
+	LootTables AssetConfig[*assets.LootTable] `json:"loot_tables"`
+	Dialogues  AssetConfig[*assets.Dialogue]  `json:"dialogues"`
+	Quests     AssetConfig[*assets.Quest]     `json:"quests"`
+	Factions   AssetConfig[*assets.Faction]   `json:"factions"`
 }
 
 // BuildDictionary creates and resolves all asset stores into a game.Dictionary.
@@ -41,6 +45,10 @@ func (c *StorageConfig) BuildDictionary() (*game.Dictionary, error) {
 		races     *storage.FileStore[*assets.Race]
 		trees     *storage.FileStore[*assets.Tree]
 		abilities *storage.FileStore[*assets.Ability]
+		loot      *storage.FileStore[*assets.LootTable]
+		dialogues *storage.FileStore[*assets.Dialogue]
+		quests    *storage.FileStore[*assets.Quest]
+		factions  *storage.FileStore[*assets.Faction]
 	)
 
 	build := func(g *errgroup.Group, name string, run func() error) {
@@ -64,6 +72,10 @@ func (c *StorageConfig) BuildDictionary() (*game.Dictionary, error) {
 	build(&g, "race", func() (err error) { races, err = c.Races.BuildFileStore(); return })
 	build(&g, "tree", func() (err error) { trees, err = c.Trees.BuildFileStore(); return })
 	build(&g, "ability", func() (err error) { abilities, err = c.Abilities.BuildFileStore(); return })
+	build(&g, "loot table", func() (err error) { loot, err = c.LootTables.BuildFileStore(); return })
+	build(&g, "dialogue", func() (err error) { dialogues, err = c.Dialogues.BuildFileStore(); return })
+	build(&g, "quest", func() (err error) { quests, err = c.Quests.BuildFileStore(); return })
+	build(&g, "faction", func() (err error) { factions, err = c.Factions.BuildFileStore(); return })
 
 	if err := g.Wait(); err != nil {
 		return nil, err
@@ -79,6 +91,10 @@ func (c *StorageConfig) BuildDictionary() (*game.Dictionary, error) {
 		Races:      races,
 		Trees:      trees,
 		Abilities:  abilities,
+		LootTables: loot,
+		Dialogues:  dialogues,
+		Quests:     quests,
+		Factions:   factions,
 	}
 
 	if err := dict.Resolve(); err != nil {
@@ -100,6 +116,10 @@ func (c *StorageConfig) validate() error {
 	errs = append(errs, c.Races.Validate("races"))
 	errs = append(errs, c.Trees.Validate("trees"))
 	errs = append(errs, c.Abilities.Validate("abilities"))
+	errs = append(errs, c.LootTables.Validate("loot_tables"))
+	errs = append(errs, c.Dialogues.Validate("dialogues"))
+	errs = append(errs, c.Quests.Validate("quests"))
+	errs = append(errs, c.Factions.Validate("factions"))
 	return errors.Join(errs...)
 }
 
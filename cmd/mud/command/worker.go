@@ -23,7 +23,7 @@ func (c *boundCommander) ExecCommand(ctx context.Context, cmd string, args ...st
 }
 
 func (c *boundCommander) ExecAbility(ctx context.Context, abilityId string, target game.Actor) error {
-	return c.handler.ExecAbility(abilityId, c.actor, target)
+	return c.handler.ExecAbility(ctx, abilityId, c.actor, target)
 }
 
 // BuildWorkers assembles and returns all service workers from the config.
@@ -50,6 +50,9 @@ func BuildWorkers(config interface{}) (service.WorkerList, error) {
 	if err != nil {
 		return nil, fmt.Errorf("creating world state: %w", err)
 	}
+	if cfg.HoursPerTick > 0 {
+		world.SetCalendar(game.NewCalendar(cfg.HoursPerTick))
+	}
 
 	// Create command handler and compile all commands
 	cmdHandler, err := commands.NewHandler(storeCmds, dict, world)
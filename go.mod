@@ -10,6 +10,7 @@ require (
 	github.com/muesli/reflow v0.3.0
 	github.com/pixil98/go-service v0.0.0-20260417183655-e748a89d22a0
 	github.com/pixil98/go-testutil v0.0.0-20260123222118-fa427b8db36a
+	go.starlark.net v0.0.0-20231121155337-90ade8b19d09
 	golang.org/x/crypto v0.49.0
 	golang.org/x/sync v0.20.0
 	golang.org/x/text v0.35.0
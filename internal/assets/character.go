@@ -3,6 +3,7 @@ package assets
 import (
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/pixil98/go-mud/internal/storage"
 )
@@ -27,12 +28,20 @@ type Character struct {
 	LastZone string `json:"last_zone,omitempty"`
 	LastRoom string `json:"last_room,omitempty"`
 
+	// Bind point that recall returns the character to
+	BindZone string `json:"bind_zone,omitempty"`
+	BindRoom string `json:"bind_room,omitempty"`
+
+	// Rooms the character has set foot in, used to limit the map to known ground
+	VisitedRooms []string `json:"visited_rooms,omitempty"`
+
 	Pronoun storage.SmartIdentifier[*Pronoun] `json:"pronoun,omitempty"`
 	Race    storage.SmartIdentifier[*Race]    `json:"race,omitempty"`
 
 	Level      int             `json:"level,omitempty"`
 	BaseStats  map[StatKey]int `json:"base_stats,omitempty"`
 	Experience int             `json:"experience,omitempty"`
+	Gold       int             `json:"gold,omitempty"`
 
 	// Persisted resource current values (max is always computed from perks).
 	Resources map[string]int `json:"resources,omitempty"`
@@ -40,6 +49,62 @@ type Character struct {
 	// Inventory and equipment stored as spawn specs so objects are re-materialized on login
 	Inventory []ObjectSpawn    `json:"inventory,omitempty"`
 	Equipment []EquipmentSpawn `json:"equipment,omitempty"`
+
+	// Perks the character has earned in play, such as abilities a mobile taught them
+	Perks []Perk `json:"perks,omitempty"`
+
+	// Quests the character has started
+	Quests []QuestProgress `json:"quests,omitempty"`
+
+	// Standing with each faction the character has had dealings with, by
+	// faction ID
+	Standings map[string]int `json:"standings,omitempty"`
+
+	// Whether the character has agreed to fight other players outside
+	// arenas, when they last changed their mind, and their record
+	PvP          bool      `json:"pvp,omitempty"`
+	PvPToggledAt time.Time `json:"pvp_toggled_at,omitzero"`
+	PvPKills     int       `json:"pvp_kills,omitempty"`
+	PvPDeaths    int       `json:"pvp_deaths,omitempty"`
+
+	// How the character shares out loot when leading a group, and whether
+	// they take loot and split gold with their group automatically
+	LootMode  LootMode `json:"loot_mode,omitempty"`
+	AutoLoot  bool     `json:"auto_loot,omitempty"`
+	AutoSplit bool     `json:"auto_split,omitempty"`
+}
+
+// LootMode is how a group leader shares out the corpses of the group's kills.
+type LootMode string
+
+// Loot modes. While a corpse's grace period lasts, only the group members
+// the mode picks may take from it.
+const (
+	LootModeFreeForAll LootMode = "ffa"        // any member present at the kill
+	LootModeRoundRobin LootMode = "roundrobin" // each member present in turn
+	LootModeLeader     LootMode = "leader"     // the leader alone
+)
+
+// LootModes lists the valid loot modes.
+var LootModes = []LootMode{LootModeFreeForAll, LootModeRoundRobin, LootModeLeader}
+
+// Quest states. A quest the character has never started is in
+// QuestStateNone and has no progress recorded.
+const (
+	QuestStateNone      = "none"
+	QuestStateActive    = "active"
+	QuestStateCompleted = "completed"
+)
+
+// QuestProgress is how far a character has got with one quest.
+type QuestProgress struct {
+	Quest storage.SmartIdentifier[*Quest] `json:"quest_id"`
+	State string                          `json:"state"` // QuestStateActive or QuestStateCompleted
+
+	// Counts are how many times each objective has been done, by index.
+	// Collect objectives are counted from what the character carries
+	// instead.
+	Counts []int `json:"counts,omitempty"`
 }
 
 // NewCharacter creates a new level-0 character with default values.
@@ -69,7 +134,7 @@ func (c *Character) Validate() error {
 }
 
 // Resolve resolves all foreign key references on the character.
-func (c *Character) Resolve(pronouns storage.Storer[*Pronoun], races storage.Storer[*Race], objs storage.Storer[*Object]) error {
+func (c *Character) Resolve(pronouns storage.Storer[*Pronoun], races storage.Storer[*Race], objs storage.Storer[*Object], quests storage.Storer[*Quest]) error {
 	if c.Race.Id() != "" {
 		if err := c.Race.Resolve(races); err != nil {
 			return err
@@ -92,6 +157,13 @@ func (c *Character) Resolve(pronouns storage.Storer[*Pronoun], races storage.Sto
 			c.Equipment[i].Object = unknownObject(c.Equipment[i].Object.Id())
 		}
 	}
+	for i := range c.Quests {
+		// Progress on a quest that no longer exists is kept in case it
+		// comes back, but plays no part in the game until it does.
+		if err := c.Quests[i].Quest.Resolve(quests); err != nil {
+			slog.Warn("unresolvable quest", "character", c.Name, "error", err)
+		}
+	}
 	return nil
 }
 
@@ -30,6 +30,10 @@ type Closure struct {
 
 	// Lock optionally makes this closure lockable with a key.
 	Lock *Lock `json:"lock,omitempty"`
+
+	// Trap optionally fires when someone without the key opens or unlocks
+	// the closure. Traps re-arm when the zone resets.
+	Trap *Trap `json:"trap,omitempty"`
 }
 
 // Validate checks that any lock is valid and consistent with the closure state.
@@ -44,6 +48,9 @@ func (c *Closure) Validate() error {
 			errs = append(errs, errors.New("locked closure must also be closed"))
 		}
 	}
+	if c.Trap != nil {
+		errs = append(errs, c.Trap.Validate())
+	}
 	return errors.Join(errs...)
 }
 
@@ -65,14 +72,96 @@ type Lock struct {
 
 	// Pickproof means the lock can only be opened with the key, not picked.
 	Pickproof bool `json:"pickproof,omitempty"`
+
+	// Difficulty is the check a pick attempt must meet.
+	// Default: DefaultCheckDifficulty.
+	Difficulty int `json:"difficulty,omitempty"`
 }
 
-// Validate checks that the key reference is set.
+// DefaultCheckDifficulty is the difficulty of lock and trap checks that
+// don't set their own.
+const DefaultCheckDifficulty = 15
+
+// Validate checks that the key reference is set and the difficulty is sane.
 func (l *Lock) Validate() error {
-	return l.KeyId.Validate()
+	var errs []error
+	errs = append(errs, l.KeyId.Validate())
+	if l.Difficulty < 0 {
+		errs = append(errs, errors.New("lock difficulty must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// PickDifficulty returns the check needed to pick the lock.
+func (l *Lock) PickDifficulty() int {
+	if l.Difficulty == 0 {
+		return DefaultCheckDifficulty
+	}
+	return l.Difficulty
 }
 
 // Resolve resolves the key's object reference.
 func (l *Lock) Resolve(objs storage.Storer[*Object]) error {
 	return l.KeyId.Resolve(objs)
 }
+
+// TrapType values select what a trap does when it fires.
+const (
+	TrapTypeDamage = "damage" // rolls Damage against the victim's hit points
+	TrapTypePoison = "poison" // applies Perks to the victim for Duration ticks
+	TrapTypeAlarm  = "alarm"  // alerts everyone in the zone
+)
+
+// Trap defines a trap on a Closure.
+type Trap struct {
+	// Type is one of the TrapType values.
+	Type string `json:"type"`
+
+	// Difficulty is the check needed to find or disarm the trap.
+	// Default: DefaultCheckDifficulty.
+	Difficulty int `json:"difficulty,omitempty"`
+
+	// Damage is a dice expression (e.g. "2d6+2") for damage traps.
+	Damage string `json:"damage,omitempty"`
+
+	// Perks and Duration define the affliction of poison traps.
+	Perks    []Perk `json:"perks,omitempty"`
+	Duration int    `json:"duration,omitempty"`
+
+	// Message is shown to the victim instead of the type's default.
+	Message string `json:"message,omitempty"`
+}
+
+// Validate checks that the trap's fields match its type.
+func (t *Trap) Validate() error {
+	var errs []error
+	switch t.Type {
+	case TrapTypeDamage:
+		if t.Damage == "" {
+			errs = append(errs, errors.New("damage trap requires damage"))
+		}
+	case TrapTypePoison:
+		if len(t.Perks) == 0 {
+			errs = append(errs, errors.New("poison trap requires perks"))
+		}
+		if t.Duration <= 0 {
+			errs = append(errs, errors.New("poison trap requires a positive duration"))
+		}
+		errs = append(errs, validatePerks(t.Perks))
+	case TrapTypeAlarm:
+	default:
+		errs = append(errs, fmt.Errorf("unknown trap type %q", t.Type))
+	}
+	if t.Difficulty < 0 {
+		errs = append(errs, errors.New("trap difficulty must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// CheckDifficulty returns the check needed to find or disarm the trap.
+func (t *Trap) CheckDifficulty() int {
+	if t.Difficulty == 0 {
+		return DefaultCheckDifficulty
+	}
+	return t.Difficulty
+}
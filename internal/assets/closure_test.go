@@ -0,0 +1,69 @@
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrap_Validate(t *testing.T) {
+	tests := map[string]struct {
+		trap   Trap
+		expErr string
+	}{
+		"damage trap": {
+			trap: Trap{Type: TrapTypeDamage, Damage: "2d6"},
+		},
+		"damage trap without damage": {
+			trap:   Trap{Type: TrapTypeDamage},
+			expErr: "damage trap requires damage",
+		},
+		"poison trap": {
+			trap: Trap{Type: TrapTypePoison, Duration: 5, Perks: []Perk{{Type: PerkTypeModifier, Key: "core.stats.str", Value: -1}}},
+		},
+		"poison trap without perks": {
+			trap:   Trap{Type: TrapTypePoison, Duration: 5},
+			expErr: "poison trap requires perks",
+		},
+		"poison trap without duration": {
+			trap:   Trap{Type: TrapTypePoison, Perks: []Perk{{Type: PerkTypeModifier, Key: "core.stats.str", Value: -1}}},
+			expErr: "poison trap requires a positive duration",
+		},
+		"alarm trap": {
+			trap: Trap{Type: TrapTypeAlarm},
+		},
+		"unknown type": {
+			trap:   Trap{Type: "pit"},
+			expErr: `unknown trap type "pit"`,
+		},
+		"negative difficulty": {
+			trap:   Trap{Type: TrapTypeAlarm, Difficulty: -1},
+			expErr: "trap difficulty must not be negative",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.trap.Validate()
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+				t.Errorf("error = %v, want containing %q", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestDifficultyDefaults(t *testing.T) {
+	if got := (&Lock{}).PickDifficulty(); got != DefaultCheckDifficulty {
+		t.Errorf("Lock.PickDifficulty() = %d, want %d", got, DefaultCheckDifficulty)
+	}
+	if got := (&Lock{Difficulty: 8}).PickDifficulty(); got != 8 {
+		t.Errorf("Lock.PickDifficulty() = %d, want 8", got)
+	}
+	if got := (&Trap{}).CheckDifficulty(); got != DefaultCheckDifficulty {
+		t.Errorf("Trap.CheckDifficulty() = %d, want %d", got, DefaultCheckDifficulty)
+	}
+}
@@ -47,12 +47,13 @@ const (
 	ScopeGroup            = "group"
 	ScopeFollowers        = "followers"
 	ScopeGroupedFollowers = "grouped_followers"
+	ScopeAdjacentRooms    = "adjacent_rooms"
 )
 
 var (
 	validInputTypes  = []string{InputTypeString, InputTypeNumber}
 	validTargetTypes = []string{TargetPlayer, TargetMobile, TargetObject, TargetExit}
-	validScopes      = []string{ScopeRoom, ScopeInventory, ScopeEquipment, ScopeWorld, ScopeZone, ScopeContents, ScopeGroup, ScopeFollowers, ScopeGroupedFollowers}
+	validScopes      = []string{ScopeRoom, ScopeInventory, ScopeEquipment, ScopeWorld, ScopeZone, ScopeContents, ScopeGroup, ScopeFollowers, ScopeGroupedFollowers, ScopeAdjacentRooms}
 )
 
 // InputSpec defines an input parameter that a command accepts from user input.
@@ -68,10 +69,12 @@ type InputSpec struct {
 // When ScopeTarget is set, the referenced target must appear earlier in the targets
 // array so it is resolved first. If the referenced target resolved to an object with
 // contents, this target is resolved exclusively from those contents.
+// The adjacent_rooms scope looks out through open exits up to Range rooms
+// away, along the direction named by the Direction input when one is given.
 type TargetSpec struct {
 	Name            string   `json:"name"`                       // Name to access in templates (e.g., "target" -> .Targets.target)
 	Types           []string `json:"types"`                      // Entity types: player, mobile, object, exit
-	Scopes          []string `json:"scopes,omitempty"`           // Resolution scopes: room, world, zone, inventory, equipment, contents, group
+	Scopes          []string `json:"scopes,omitempty"`           // Resolution scopes: room, world, zone, inventory, equipment, contents, group, adjacent_rooms
 	Input           string   `json:"input"`                      // Which input provides the name to resolve
 	Optional        bool     `json:"optional,omitempty"`         // If true, missing input -> nil (no error)
 	Default         string   `json:"default,omitempty"`          // Default value when input is empty; "combat_target" resolves to actor's combat target
@@ -79,6 +82,8 @@ type TargetSpec struct {
 	NotFound        string   `json:"not_found,omitempty"`        // Custom template for "not found" error; supports {{ .Input }}
 	AllowUnresolved bool     `json:"allow_unresolved,omitempty"` // If true, unresolved optional target is nil (not an error); requires Optional
 	AllowAll        bool     `json:"allow_all,omitempty"`        // If true, "all.keyword" and "all" select multiple targets
+	Range           int      `json:"range,omitempty"`            // How many rooms away adjacent_rooms reaches (default 1)
+	Direction       string   `json:"direction,omitempty"`        // Input naming the direction adjacent_rooms looks along; all directions when empty
 }
 
 // Command defines a command loaded from JSON.
@@ -221,6 +226,17 @@ func (c *Command) ValidateInputsTargets() error {
 			return fmt.Errorf("target %q: \"contents\" scope requires scope_target to be set", target.Name)
 		}
 
+		if slices.Contains(target.Scopes, ScopeAdjacentRooms) {
+			if target.Range < 0 {
+				return fmt.Errorf("target %q: range must not be negative", target.Name)
+			}
+			if target.Direction != "" && !validInputs[target.Direction] {
+				return fmt.Errorf("target %q: direction input %q does not exist in inputs", target.Name, target.Direction)
+			}
+		} else if target.Range != 0 || target.Direction != "" {
+			return fmt.Errorf("target %q: range and direction require the \"adjacent_rooms\" scope", target.Name)
+		}
+
 		if target.NotFound != "" {
 			if _, err := template.New("").Funcs(sprigFuncs).Parse(target.NotFound); err != nil {
 				return fmt.Errorf("target %q: invalid not_found template: %w", target.Name, err)
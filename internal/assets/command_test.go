@@ -222,6 +222,54 @@ func TestCommand_Validate(t *testing.T) {
 			},
 			expErr: `target "test-target": "contents" scope requires scope_target to be set`,
 		},
+		"adjacent rooms with range and direction": {
+			cmd: Command{
+				Handler: "test-handler",
+				Targets: []TargetSpec{
+					{Name: "test-target", Types: []string{TargetMobile}, Scopes: []string{ScopeAdjacentRooms}, Input: "test-target", Range: 2, Direction: "test-dir"},
+				},
+				Inputs: []InputSpec{
+					{Name: "test-target", Type: InputTypeString},
+					{Name: "test-dir", Type: InputTypeString},
+				},
+			},
+		},
+		"adjacent rooms direction must be an input": {
+			cmd: Command{
+				Handler: "test-handler",
+				Targets: []TargetSpec{
+					{Name: "test-target", Types: []string{TargetMobile}, Scopes: []string{ScopeAdjacentRooms}, Input: "test-target", Direction: "test-dir"},
+				},
+				Inputs: []InputSpec{
+					{Name: "test-target", Type: InputTypeString},
+				},
+			},
+			expErr: `target "test-target": direction input "test-dir" does not exist in inputs`,
+		},
+		"negative range": {
+			cmd: Command{
+				Handler: "test-handler",
+				Targets: []TargetSpec{
+					{Name: "test-target", Types: []string{TargetMobile}, Scopes: []string{ScopeAdjacentRooms}, Input: "test-target", Range: -1},
+				},
+				Inputs: []InputSpec{
+					{Name: "test-target", Type: InputTypeString},
+				},
+			},
+			expErr: `target "test-target": range must not be negative`,
+		},
+		"range requires adjacent rooms scope": {
+			cmd: Command{
+				Handler: "test-handler",
+				Targets: []TargetSpec{
+					{Name: "test-target", Types: []string{TargetMobile}, Scopes: []string{ScopeRoom}, Input: "test-target", Range: 2},
+				},
+				Inputs: []InputSpec{
+					{Name: "test-target", Type: InputTypeString},
+				},
+			},
+			expErr: `target "test-target": range and direction require the "adjacent_rooms" scope`,
+		},
 		"scope_target must reference earlier target": {
 			cmd: Command{
 				Handler: "test-handler",
@@ -0,0 +1,61 @@
+package assets
+
+import (
+	"errors"
+	"strings"
+)
+
+// Food makes an object edible. Eating consumes the object.
+type Food struct {
+	// Fill is how much hunger one serving restores.
+	Fill int `json:"fill"`
+}
+
+// Validate checks that the food restores a positive amount of hunger.
+func (f *Food) Validate() error {
+	if f.Fill <= 0 {
+		return errors.New("food fill must be positive")
+	}
+	return nil
+}
+
+// Drink defines a liquid holder that can be drunk from, filled, and poured.
+// A finite drink container tracks its remaining sips per instance; an
+// infinite one (a fountain) never runs dry.
+type Drink struct {
+	// Liquid is the name of the liquid the container starts with (e.g., "water", "ale").
+	Liquid string `json:"liquid,omitempty"`
+
+	// Capacity is the maximum number of sips the container holds. Ignored when Infinite.
+	Capacity int `json:"capacity,omitempty"`
+
+	// Sips is the number of sips a freshly spawned instance holds. Ignored when Infinite.
+	Sips int `json:"sips,omitempty"`
+
+	// Infinite marks an endless source such as a fountain or well.
+	Infinite bool `json:"infinite,omitempty"`
+}
+
+// Validate checks that the liquid amounts are consistent.
+func (d *Drink) Validate() error {
+	var errs []error
+	if strings.TrimSpace(d.Liquid) != d.Liquid {
+		errs = append(errs, errors.New("drink liquid must not have surrounding whitespace"))
+	}
+	if d.Infinite {
+		if d.Liquid == "" {
+			errs = append(errs, errors.New("infinite drink sources require a liquid"))
+		}
+		return errors.Join(errs...)
+	}
+	if d.Capacity <= 0 {
+		errs = append(errs, errors.New("drink capacity must be positive"))
+	}
+	if d.Sips < 0 || d.Sips > d.Capacity {
+		errs = append(errs, errors.New("drink sips must be between 0 and capacity"))
+	}
+	if d.Sips > 0 && d.Liquid == "" {
+		errs = append(errs, errors.New("a filled drink container requires a liquid"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,225 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Dialogue is a branching conversation a mobile holds with players who talk
+// to it. The mobile speaks a node's text, and the player answers by picking
+// one of the node's numbered replies.
+// Dialogue IDs follow the convention <zone>-<mobile> (e.g., "millbrook-innkeeper").
+type Dialogue struct {
+	// Start names the node every conversation opens on.
+	Start string `json:"start"`
+
+	// Nodes are the points in the conversation, by name.
+	Nodes map[string]*DialogueNode `json:"nodes"`
+}
+
+// DialogueNode is one thing the mobile says and the replies open to the
+// player.
+type DialogueNode struct {
+	// Text is what the mobile says. It is a template over .Self, .Actor and
+	// .Vars, as trigger scripts see them.
+	Text string `json:"text"`
+
+	// Effects happen, in order, when the conversation reaches the node.
+	Effects []DialogueEffect `json:"effects,omitempty"`
+
+	// Replies are the player's options. The conversation ends at a node
+	// with none to offer.
+	Replies []DialogueReply `json:"replies,omitempty"`
+}
+
+// DialogueReply is an answer the player can give.
+type DialogueReply struct {
+	Text string `json:"text"`
+
+	// Next names the node the reply leads to. Empty ends the conversation.
+	Next string `json:"next,omitempty"`
+
+	// Conditions must all hold for the reply to be offered.
+	Conditions []DialogueCondition `json:"conditions,omitempty"`
+}
+
+// DialogueCondition is a test on the player. Every field set must hold.
+type DialogueCondition struct {
+	// MinLevel and MaxLevel bound the player's level. Zero leaves that end
+	// open.
+	MinLevel int `json:"min_level,omitempty"`
+	MaxLevel int `json:"max_level,omitempty"`
+
+	// Object must be in the player's inventory or equipment.
+	Object storage.SmartIdentifier[*Object] `json:"object_id"`
+
+	// Quest must be in QuestState: none, available, active, ready or
+	// completed.
+	Quest      storage.SmartIdentifier[*Quest] `json:"quest_id"`
+	QuestState string                          `json:"quest_state,omitempty"`
+
+	// Alignment is the band the player must be in: good, neutral or evil.
+	Alignment string `json:"alignment,omitempty"`
+
+	// StandingRequirement bounds the player's standing with a faction.
+	StandingRequirement
+}
+
+// DialogueEffect is something the mobile does for the player. Exactly one
+// field is set.
+type DialogueEffect struct {
+	// Object is given to the player.
+	Object storage.SmartIdentifier[*Object] `json:"object_id"`
+
+	// Quest is started for the player, if they meet its prerequisites.
+	Quest storage.SmartIdentifier[*Quest] `json:"quest_id"`
+
+	// Complete hands in the player's quest, if its objectives are done.
+	Complete storage.SmartIdentifier[*Quest] `json:"complete_quest_id"`
+
+	// Ability is taught to the player for good.
+	Ability storage.SmartIdentifier[*Ability] `json:"ability_id"`
+
+	// Open unlocks and opens the door in the named direction of the
+	// mobile's room.
+	Open string `json:"open,omitempty"`
+}
+
+// Validate satisfies storage.ValidatingSpec.
+func (d *Dialogue) Validate() error {
+	var errs []error
+	if d.Start == "" {
+		errs = append(errs, errors.New("start is required"))
+	} else if d.Nodes[d.Start] == nil {
+		errs = append(errs, fmt.Errorf("start names unknown node %q", d.Start))
+	}
+
+	names := make([]string, 0, len(d.Nodes))
+	for name := range d.Nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := d.Nodes[name].validate(d.Nodes); err != nil {
+			errs = append(errs, fmt.Errorf("nodes[%s]: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve resolves the objects, abilities, quests and factions the dialogue
+// refers to.
+func (d *Dialogue) Resolve(objs storage.Storer[*Object], abilities storage.Storer[*Ability], quests storage.Storer[*Quest], factions storage.Storer[*Faction]) error {
+	var errs []error
+	for _, n := range d.Nodes {
+		for i := range n.Effects {
+			e := &n.Effects[i]
+			if e.Object.Id() != "" {
+				errs = append(errs, e.Object.Resolve(objs))
+			}
+			if e.Ability.Id() != "" {
+				errs = append(errs, e.Ability.Resolve(abilities))
+			}
+			if e.Quest.Id() != "" {
+				errs = append(errs, e.Quest.Resolve(quests))
+			}
+			if e.Complete.Id() != "" {
+				errs = append(errs, e.Complete.Resolve(quests))
+			}
+		}
+		for i := range n.Replies {
+			for j := range n.Replies[i].Conditions {
+				c := &n.Replies[i].Conditions[j]
+				if c.Object.Id() != "" {
+					errs = append(errs, c.Object.Resolve(objs))
+				}
+				if c.Quest.Id() != "" {
+					errs = append(errs, c.Quest.Resolve(quests))
+				}
+				errs = append(errs, c.StandingRequirement.resolve(factions))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *DialogueNode) validate(nodes map[string]*DialogueNode) error {
+	if n == nil {
+		return errors.New("node is empty")
+	}
+	var errs []error
+	if n.Text == "" {
+		errs = append(errs, errors.New("text is required"))
+	}
+	if _, err := ParseScriptTemplate(n.Text, scriptFuncStubs()); err != nil {
+		errs = append(errs, err)
+	}
+	for i := range n.Effects {
+		if err := n.Effects[i].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("effects[%d]: %w", i, err))
+		}
+	}
+	for i := range n.Replies {
+		if err := n.Replies[i].validate(nodes); err != nil {
+			errs = append(errs, fmt.Errorf("replies[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *DialogueReply) validate(nodes map[string]*DialogueNode) error {
+	var errs []error
+	if r.Text == "" {
+		errs = append(errs, errors.New("text is required"))
+	}
+	if r.Next != "" && nodes[r.Next] == nil {
+		errs = append(errs, fmt.Errorf("next names unknown node %q", r.Next))
+	}
+	for i := range r.Conditions {
+		if err := r.Conditions[i].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("conditions[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *DialogueCondition) validate() error {
+	var errs []error
+	if c.MinLevel < 0 || c.MaxLevel < 0 {
+		errs = append(errs, errors.New("levels must not be negative"))
+	}
+	if c.MaxLevel > 0 && c.MaxLevel < c.MinLevel {
+		errs = append(errs, errors.New("max_level must not be below min_level"))
+	}
+	if (c.Quest.Id() == "") != (c.QuestState == "") {
+		errs = append(errs, errors.New("quest_id and quest_state go together"))
+	}
+	switch c.QuestState {
+	case "", QuestStateNone, QuestStateAvailable, QuestStateActive, QuestStateReady, QuestStateCompleted:
+	default:
+		errs = append(errs, fmt.Errorf("unknown quest_state %q", c.QuestState))
+	}
+	switch c.Alignment {
+	case "", AlignmentGood, AlignmentNeutral, AlignmentEvil:
+	default:
+		errs = append(errs, fmt.Errorf("unknown alignment %q", c.Alignment))
+	}
+	errs = append(errs, c.StandingRequirement.validate())
+	return errors.Join(errs...)
+}
+
+func (e *DialogueEffect) validate() error {
+	actions := 0
+	for _, set := range []bool{e.Object.Id() != "", e.Quest.Id() != "", e.Complete.Id() != "", e.Ability.Id() != "", e.Open != ""} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return errors.New("exactly one of object_id, quest_id, complete_quest_id, ability_id or open is required")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestDialogue_Validate(t *testing.T) {
+	bye := []DialogueReply{{Text: "Bye."}}
+	quest := storage.NewSmartIdentifier[*Quest]("q")
+	tests := map[string]struct {
+		dialogue Dialogue
+		expErr   bool
+	}{
+		"branching": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{
+				"hi": {Text: "Hello, {{ .Actor.Name }}.", Replies: []DialogueReply{
+					{Text: "Work?", Next: "work", Conditions: []DialogueCondition{{MinLevel: 2, Quest: quest, QuestState: QuestStateNone}}},
+					{Text: "Bye."},
+				}},
+				"work": {Text: "Here.", Effects: []DialogueEffect{{Quest: quest}, {Complete: quest}, {Open: "north"}}, Replies: bye},
+			}},
+		},
+		"missing start": {
+			dialogue: Dialogue{Nodes: map[string]*DialogueNode{"hi": {Text: "Hello."}}},
+			expErr:   true,
+		},
+		"unknown start": {
+			dialogue: Dialogue{Start: "yo", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello."}}},
+			expErr:   true,
+		},
+		"unknown next": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{
+				"hi": {Text: "Hello.", Replies: []DialogueReply{{Text: "Go.", Next: "nowhere"}}},
+			}},
+			expErr: true,
+		},
+		"node without text": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Replies: bye}}},
+			expErr:   true,
+		},
+		"bad template": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "{{ .Actor.Name "}}},
+			expErr:   true,
+		},
+		"reply without text": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Replies: []DialogueReply{{}}}}},
+			expErr:   true,
+		},
+		"effect with two actions": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Effects: []DialogueEffect{
+				{Quest: quest, Object: storage.NewSmartIdentifier[*Object]("coin")},
+			}}}},
+			expErr: true,
+		},
+		"empty effect": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Effects: []DialogueEffect{{}}}}},
+			expErr:   true,
+		},
+		"quest without state": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Replies: []DialogueReply{
+				{Text: "Bye.", Conditions: []DialogueCondition{{Quest: quest}}},
+			}}}},
+			expErr: true,
+		},
+		"unknown quest state": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Replies: []DialogueReply{
+				{Text: "Bye.", Conditions: []DialogueCondition{{Quest: quest, QuestState: "failed"}}},
+			}}}},
+			expErr: true,
+		},
+		"unknown alignment": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Replies: []DialogueReply{
+				{Text: "Bye.", Conditions: []DialogueCondition{{Alignment: "chaotic"}}},
+			}}}},
+			expErr: true,
+		},
+		"inverted levels": {
+			dialogue: Dialogue{Start: "hi", Nodes: map[string]*DialogueNode{"hi": {Text: "Hello.", Replies: []DialogueReply{
+				{Text: "Bye.", Conditions: []DialogueCondition{{MinLevel: 5, MaxLevel: 2}}},
+			}}}},
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.dialogue.Validate(); (err != nil) != tc.expErr {
+				t.Errorf("Validate() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestAlignmentBand(t *testing.T) {
+	tests := map[string]struct {
+		value int
+		want  string
+	}{
+		"saintly":      {value: 1000, want: AlignmentGood},
+		"barely good":  {value: 350, want: AlignmentGood},
+		"neutral":      {value: 0, want: AlignmentNeutral},
+		"leaning evil": {value: -349, want: AlignmentNeutral},
+		"evil":         {value: -350, want: AlignmentEvil},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := AlignmentBand(tc.value); got != tc.want {
+				t.Errorf("AlignmentBand(%d) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Standing is kept between StandingMin and StandingMax.
+const (
+	StandingMin = -1000
+	StandingMax = 1000
+)
+
+// Standing tiers, worst first.
+const (
+	StandingHated      = "hated"      // -700 and below
+	StandingHostile    = "hostile"    // -699 to -300
+	StandingUnfriendly = "unfriendly" // -299 to -100
+	StandingNeutral    = "neutral"    // -99 to 99
+	StandingFriendly   = "friendly"   // 100 to 499
+	StandingHonored    = "honored"    // 500 and above
+)
+
+// StandingTiers lists the tiers in order, worst first.
+var StandingTiers = []string{StandingHated, StandingHostile, StandingUnfriendly, StandingNeutral, StandingFriendly, StandingHonored}
+
+// StandingTier returns the tier a standing value falls in.
+func StandingTier(value int) string {
+	switch {
+	case value <= -700:
+		return StandingHated
+	case value <= -300:
+		return StandingHostile
+	case value <= -100:
+		return StandingUnfriendly
+	case value < 100:
+		return StandingNeutral
+	case value < 500:
+		return StandingFriendly
+	default:
+		return StandingHonored
+	}
+}
+
+// StandingRank returns a tier's place in StandingTiers, or -1 for an
+// unknown tier.
+func StandingRank(tier string) int {
+	return slices.Index(StandingTiers, tier)
+}
+
+// Faction is a group mobiles belong to. Each character has a standing with
+// every faction, which killing its members wears down.
+// Faction IDs follow the convention <zone>-<name> (e.g., "millbrook-townsfolk").
+type Faction struct {
+	// Name is used mid-sentence, so include any article (e.g., "the
+	// people of Millbrook").
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Starting is a character's standing before they have had any dealings
+	// with the faction.
+	Starting int `json:"starting,omitempty"`
+
+	// KillStanding is how a character's standing changes when they kill a
+	// member, usually by a negative amount. Standing with allies changes by
+	// half as much, and with enemies by half as much the other way.
+	KillStanding int `json:"kill_standing,omitempty"`
+
+	Allies  []storage.SmartIdentifier[*Faction] `json:"allies,omitempty"`
+	Enemies []storage.SmartIdentifier[*Faction] `json:"enemies,omitempty"`
+}
+
+// Validate satisfies storage.ValidatingSpec.
+func (f *Faction) Validate() error {
+	var errs []error
+	if f.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if f.Starting < StandingMin || f.Starting > StandingMax {
+		errs = append(errs, fmt.Errorf("starting must be between %d and %d", StandingMin, StandingMax))
+	}
+	if f.KillStanding < StandingMin || f.KillStanding > StandingMax {
+		errs = append(errs, fmt.Errorf("kill_standing must be between %d and %d", StandingMin, StandingMax))
+	}
+	for _, a := range f.Allies {
+		if slices.ContainsFunc(f.Enemies, func(e storage.SmartIdentifier[*Faction]) bool { return e.Id() == a.Id() }) {
+			errs = append(errs, fmt.Errorf("%q is both an ally and an enemy", a.Id()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve resolves the faction's allies and enemies.
+func (f *Faction) Resolve(factions storage.Storer[*Faction]) error {
+	var errs []error
+	for i := range f.Allies {
+		errs = append(errs, f.Allies[i].Resolve(factions))
+	}
+	for i := range f.Enemies {
+		errs = append(errs, f.Enemies[i].Resolve(factions))
+	}
+	return errors.Join(errs...)
+}
+
+// StandingRequirement bounds a character's standing with a faction by tier.
+// An empty bound leaves that end open.
+type StandingRequirement struct {
+	Faction     storage.SmartIdentifier[*Faction] `json:"faction_id"`
+	MinStanding string                            `json:"min_standing,omitempty"`
+	MaxStanding string                            `json:"max_standing,omitempty"`
+}
+
+// Allows reports whether a standing value is within the bounds.
+func (r *StandingRequirement) Allows(standing int) bool {
+	rank := StandingRank(StandingTier(standing))
+	if r.MinStanding != "" && rank < StandingRank(r.MinStanding) {
+		return false
+	}
+	if r.MaxStanding != "" && rank > StandingRank(r.MaxStanding) {
+		return false
+	}
+	return true
+}
+
+func (r *StandingRequirement) validate() error {
+	if r.Faction.Id() == "" {
+		if r.MinStanding != "" || r.MaxStanding != "" {
+			return errors.New("min_standing and max_standing need faction_id")
+		}
+		return nil
+	}
+	var errs []error
+	if r.MinStanding == "" && r.MaxStanding == "" {
+		errs = append(errs, errors.New("faction_id needs min_standing or max_standing"))
+	}
+	for _, tier := range []string{r.MinStanding, r.MaxStanding} {
+		if tier != "" && StandingRank(tier) < 0 {
+			errs = append(errs, fmt.Errorf("unknown standing %q", tier))
+		}
+	}
+	if r.MinStanding != "" && r.MaxStanding != "" && StandingRank(r.MaxStanding) < StandingRank(r.MinStanding) {
+		errs = append(errs, errors.New("max_standing must not be below min_standing"))
+	}
+	return errors.Join(errs...)
+}
+
+func (r *StandingRequirement) resolve(factions storage.Storer[*Faction]) error {
+	if r.Faction.Id() == "" {
+		return nil
+	}
+	return r.Faction.Resolve(factions)
+}
@@ -0,0 +1,123 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestStandingTier(t *testing.T) {
+	tests := map[string]struct {
+		value int
+		want  string
+	}{
+		"floor":        {value: StandingMin, want: StandingHated},
+		"top of hated": {value: -700, want: StandingHated},
+		"hostile":      {value: -699, want: StandingHostile},
+		"unfriendly":   {value: -100, want: StandingUnfriendly},
+		"neutral low":  {value: -99, want: StandingNeutral},
+		"neutral high": {value: 99, want: StandingNeutral},
+		"friendly":     {value: 100, want: StandingFriendly},
+		"honored":      {value: 500, want: StandingHonored},
+		"ceiling":      {value: StandingMax, want: StandingHonored},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := StandingTier(tc.value); got != tc.want {
+				t.Errorf("StandingTier(%d) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFaction_Validate(t *testing.T) {
+	band := storage.NewSmartIdentifier[*Faction]("band")
+	tests := map[string]struct {
+		faction Faction
+		expErr  bool
+	}{
+		"valid": {
+			faction: Faction{Name: "the town", Starting: 50, KillStanding: -40, Enemies: []storage.SmartIdentifier[*Faction]{band}},
+		},
+		"no name": {
+			faction: Faction{},
+			expErr:  true,
+		},
+		"starting out of range": {
+			faction: Faction{Name: "the town", Starting: 1001},
+			expErr:  true,
+		},
+		"ally and enemy": {
+			faction: Faction{Name: "the town", Allies: []storage.SmartIdentifier[*Faction]{band}, Enemies: []storage.SmartIdentifier[*Faction]{band}},
+			expErr:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.faction.Validate(); (err != nil) != tc.expErr {
+				t.Errorf("Validate() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestStandingRequirement(t *testing.T) {
+	town := storage.NewSmartIdentifier[*Faction]("town")
+	tests := map[string]struct {
+		req      StandingRequirement
+		standing int
+		expErr   bool
+		want     bool
+	}{
+		"no requirement": {
+			want: true,
+		},
+		"min met": {
+			req:      StandingRequirement{Faction: town, MinStanding: StandingFriendly},
+			standing: 100,
+			want:     true,
+		},
+		"min not met": {
+			req:      StandingRequirement{Faction: town, MinStanding: StandingFriendly},
+			standing: 99,
+		},
+		"max met": {
+			req:      StandingRequirement{Faction: town, MaxStanding: StandingHostile},
+			standing: -300,
+			want:     true,
+		},
+		"max exceeded": {
+			req:      StandingRequirement{Faction: town, MaxStanding: StandingHostile},
+			standing: -299,
+		},
+		"bounds without a faction": {
+			req:    StandingRequirement{MinStanding: StandingFriendly},
+			expErr: true,
+		},
+		"faction without bounds": {
+			req:    StandingRequirement{Faction: town},
+			expErr: true,
+		},
+		"unknown tier": {
+			req:    StandingRequirement{Faction: town, MinStanding: "adored"},
+			expErr: true,
+		},
+		"max below min": {
+			req:    StandingRequirement{Faction: town, MinStanding: StandingFriendly, MaxStanding: StandingNeutral},
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.req.validate(); (err != nil) != tc.expErr {
+				t.Errorf("validate() error = %v, expErr %v", err, tc.expErr)
+			}
+			if tc.expErr {
+				return
+			}
+			if got := tc.req.Allows(tc.standing); got != tc.want {
+				t.Errorf("Allows(%d) = %v, want %v", tc.standing, got, tc.want)
+			}
+		})
+	}
+}
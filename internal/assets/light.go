@@ -0,0 +1,23 @@
+package assets
+
+import "errors"
+
+// WearSlotLight is the equipment slot for carried light sources. Equipping
+// a light source here lights it.
+const WearSlotLight = "light"
+
+// Light makes an object a light source. While lit, it lights the whole room
+// it is in, whether it lies on the floor or is carried by someone there.
+type Light struct {
+	// Burn is how many ticks a fresh instance can stay lit before it burns
+	// out. Zero means it never burns out.
+	Burn int `json:"burn,omitempty"`
+}
+
+// Validate checks that the burn time is not negative.
+func (l *Light) Validate() error {
+	if l.Burn < 0 {
+		return errors.New("light burn must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// maxLootDepth caps how deep nested tables are followed while rolling. Cycles
+// are rejected at load, so this only guards against absurdly deep chains.
+const maxLootDepth = 16
+
+// LootTable is a set of random drops. Mobiles roll their tables into their
+// corpse, and rooms roll theirs on zone reset, onto the floor or into a
+// spawned container.
+// Loot table IDs follow the convention <zone>-<name> (e.g., "darkwood-bandit-pockets").
+type LootTable struct {
+	// Entries are each rolled independently.
+	Entries []LootEntry `json:"entries"`
+}
+
+// Validate satisfies storage.ValidatingSpec.
+func (t *LootTable) Validate() error {
+	if len(t.Entries) == 0 {
+		return errors.New("loot table needs at least one entry")
+	}
+	var errs []error
+	for i := range t.Entries {
+		if err := t.Entries[i].validate(false); err != nil {
+			errs = append(errs, fmt.Errorf("entries[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve resolves the objects and nested tables the entries drop.
+func (t *LootTable) Resolve(objs storage.Storer[*Object], tables storage.Storer[*LootTable]) error {
+	var errs []error
+	for i := range t.Entries {
+		errs = append(errs, t.Entries[i].resolve(objs, tables))
+	}
+	return errors.Join(errs...)
+}
+
+// FindCycle returns the chain of table IDs by which the table named id nests
+// itself, or nil if it never does. Tables must be resolved first.
+func (t *LootTable) FindCycle(id string) []string {
+	seen := make(map[*LootTable]bool)
+	var walk func(entries []LootEntry, path []string) []string
+	walk = func(entries []LootEntry, path []string) []string {
+		for _, e := range entries {
+			if cycle := walk(e.OneOf, path); cycle != nil {
+				return cycle
+			}
+			nested := e.Table.Get()
+			if nested == nil {
+				continue
+			}
+			chain := append(path[:len(path):len(path)], e.Table.Id())
+			if nested == t {
+				return chain
+			}
+			if seen[nested] {
+				continue
+			}
+			seen[nested] = true
+			if cycle := walk(nested.Entries, chain); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk(t.Entries, []string{id})
+}
+
+// Roll rolls the table for a source of the given level and returns what
+// drops. randIntN supplies the dice, as rand.IntN does.
+func (t *LootTable) Roll(level int, randIntN func(int) int) []ObjectSpawn {
+	var out []ObjectSpawn
+	t.roll(level, randIntN, 0, &out)
+	return out
+}
+
+func (t *LootTable) roll(level int, randIntN func(int) int, depth int, out *[]ObjectSpawn) {
+	if depth > maxLootDepth {
+		return
+	}
+	for i := range t.Entries {
+		t.Entries[i].roll(level, randIntN, depth, out)
+	}
+}
+
+// LootEntry is one line of a loot table. It drops an object, rolls a nested
+// table, or picks one of a weighted group; an entry with none of these drops
+// nothing, which is useful as a blank in a group.
+type LootEntry struct {
+	Object storage.SmartIdentifier[*Object]    `json:"object_id"`
+	Table  storage.SmartIdentifier[*LootTable] `json:"table_id"`
+	OneOf  []LootEntry                         `json:"one_of,omitempty"`
+
+	// Weight is the entry's share when picked from a one_of group. Zero
+	// counts as 1.
+	Weight int `json:"weight,omitempty"`
+
+	// Chance is the percent chance the entry drops at all. Zero means it
+	// always does, unless ChancePerLevel is set.
+	Chance int `json:"chance,omitempty"`
+	// ChancePerLevel adds to Chance for each level the source is above
+	// MinLevel, up to 100.
+	ChancePerLevel int `json:"chance_per_level,omitempty"`
+
+	// MinLevel and MaxLevel restrict the entry to sources in that level
+	// range. Zero leaves that end open.
+	MinLevel int `json:"min_level,omitempty"`
+	MaxLevel int `json:"max_level,omitempty"`
+
+	// MinCount and MaxCount give how many times the entry drops when it
+	// does. Zero counts as 1; MaxCount defaults to MinCount.
+	MinCount int `json:"min_count,omitempty"`
+	MaxCount int `json:"max_count,omitempty"`
+}
+
+func (e *LootEntry) validate(inGroup bool) error {
+	var errs []error
+	kinds := 0
+	if e.Object.Id() != "" {
+		kinds++
+	}
+	if e.Table.Id() != "" {
+		kinds++
+	}
+	if len(e.OneOf) > 0 {
+		kinds++
+	}
+	if kinds > 1 {
+		errs = append(errs, errors.New("only one of object_id, table_id and one_of may be set"))
+	}
+	if kinds == 0 && !inGroup {
+		errs = append(errs, errors.New("one of object_id, table_id or one_of is required"))
+	}
+	if e.Weight < 0 {
+		errs = append(errs, errors.New("weight must not be negative"))
+	}
+	if e.Chance < 0 || e.Chance > 100 {
+		errs = append(errs, errors.New("chance must be between 0 and 100"))
+	}
+	if e.ChancePerLevel < 0 {
+		errs = append(errs, errors.New("chance_per_level must not be negative"))
+	}
+	if e.MinLevel < 0 || e.MaxLevel < 0 {
+		errs = append(errs, errors.New("levels must not be negative"))
+	}
+	if e.MaxLevel > 0 && e.MaxLevel < e.MinLevel {
+		errs = append(errs, errors.New("max_level must not be below min_level"))
+	}
+	if e.MinCount < 0 || e.MaxCount < 0 {
+		errs = append(errs, errors.New("counts must not be negative"))
+	}
+	if e.MaxCount > 0 && e.MaxCount < e.MinCount {
+		errs = append(errs, errors.New("max_count must not be below min_count"))
+	}
+	for i := range e.OneOf {
+		if err := e.OneOf[i].validate(true); err != nil {
+			errs = append(errs, fmt.Errorf("one_of[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *LootEntry) resolve(objs storage.Storer[*Object], tables storage.Storer[*LootTable]) error {
+	var errs []error
+	if e.Object.Id() != "" {
+		errs = append(errs, e.Object.Resolve(objs))
+	}
+	if e.Table.Id() != "" {
+		errs = append(errs, e.Table.Resolve(tables))
+	}
+	for i := range e.OneOf {
+		errs = append(errs, e.OneOf[i].resolve(objs, tables))
+	}
+	return errors.Join(errs...)
+}
+
+// eligible reports whether the entry can drop for a source of level.
+func (e *LootEntry) eligible(level int) bool {
+	return level >= e.MinLevel && (e.MaxLevel == 0 || level <= e.MaxLevel)
+}
+
+// chanceAt returns the entry's percent chance to drop for a source of level.
+func (e *LootEntry) chanceAt(level int) int {
+	if e.Chance == 0 && e.ChancePerLevel == 0 {
+		return 100
+	}
+	return min(100, e.Chance+e.ChancePerLevel*max(0, level-e.MinLevel))
+}
+
+func (e *LootEntry) roll(level int, randIntN func(int) int, depth int, out *[]ObjectSpawn) {
+	if !e.eligible(level) {
+		return
+	}
+	if chance := e.chanceAt(level); chance < 100 && randIntN(100) >= chance {
+		return
+	}
+	lo := max(1, e.MinCount)
+	hi := max(lo, e.MaxCount)
+	count := lo
+	if hi > lo {
+		count += randIntN(hi - lo + 1)
+	}
+	for range count {
+		switch {
+		case e.Object.Id() != "":
+			*out = append(*out, ObjectSpawn{Object: e.Object})
+		case e.Table.Id() != "":
+			e.Table.Get().roll(level, randIntN, depth+1, out)
+		case len(e.OneOf) > 0:
+			if pick := pickLoot(e.OneOf, level, randIntN); pick != nil {
+				pick.roll(level, randIntN, depth, out)
+			}
+		}
+	}
+}
+
+// pickLoot picks one of the entries eligible at level by weight, or nil if
+// none are.
+func pickLoot(entries []LootEntry, level int, randIntN func(int) int) *LootEntry {
+	weight := func(e *LootEntry) int {
+		if !e.eligible(level) {
+			return 0
+		}
+		return max(1, e.Weight)
+	}
+	total := 0
+	for i := range entries {
+		total += weight(&entries[i])
+	}
+	if total == 0 {
+		return nil
+	}
+	r := randIntN(total)
+	for i := range entries {
+		if r -= weight(&entries[i]); r < 0 {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// LootRoll references a loot table to roll. In JSON it is either a bare
+// table ID or an object with the ID and the level to roll at.
+type LootRoll struct {
+	Table storage.SmartIdentifier[*LootTable] `json:"table_id"`
+	// Level is the level the table is rolled at. Zero uses the source's own
+	// level: the mob's for mob loot, otherwise zero.
+	Level int `json:"level,omitempty"`
+}
+
+// UnmarshalJSON accepts a bare table ID as well as the full object.
+func (r *LootRoll) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		*r = LootRoll{}
+		return json.Unmarshal(data, &r.Table)
+	}
+	type plain LootRoll
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// Validate returns an error if the roll names no table or a negative level.
+func (r *LootRoll) Validate() error {
+	var errs []error
+	errs = append(errs, r.Table.Validate())
+	if r.Level < 0 {
+		errs = append(errs, errors.New("level must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Roll rolls the table at the roll's level, or at level if it sets none.
+func (r *LootRoll) Roll(level int, randIntN func(int) int) []ObjectSpawn {
+	if r.Level > 0 {
+		level = r.Level
+	}
+	return r.Table.Get().Roll(level, randIntN)
+}
+
+// validateLootRolls validates each roll, naming it by index.
+func validateLootRolls(rolls []LootRoll) error {
+	var errs []error
+	for i := range rolls {
+		if err := rolls[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("loot[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveLootRolls resolves each roll's table.
+func resolveLootRolls(rolls []LootRoll, tables storage.Storer[*LootTable]) error {
+	var errs []error
+	for i := range rolls {
+		errs = append(errs, rolls[i].Table.Resolve(tables))
+	}
+	return errors.Join(errs...)
+}
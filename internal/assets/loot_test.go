@@ -0,0 +1,190 @@
+package assets
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// scriptedDice returns a randIntN that answers with rolls in order, then
+// zeros once they run out.
+func scriptedDice(rolls ...int) func(int) int {
+	return func(int) int {
+		if len(rolls) == 0 {
+			return 0
+		}
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+}
+
+func lootObj(id string) storage.SmartIdentifier[*Object] {
+	return storage.NewResolvedSmartIdentifier(id, &Object{Aliases: []string{id}, ShortDesc: id})
+}
+
+func TestLootTable_Validate(t *testing.T) {
+	obj := storage.NewSmartIdentifier[*Object]("coin")
+	tests := map[string]struct {
+		table  LootTable
+		expErr bool
+	}{
+		"object entry": {
+			table: LootTable{Entries: []LootEntry{{Object: obj, Chance: 50, MinCount: 1, MaxCount: 3}}},
+		},
+		"group with a blank": {
+			table: LootTable{Entries: []LootEntry{{OneOf: []LootEntry{{Object: obj, Weight: 2}, {Weight: 8}}}}},
+		},
+		"no entries": {
+			expErr: true,
+		},
+		"blank outside a group": {
+			table:  LootTable{Entries: []LootEntry{{Chance: 50}}},
+			expErr: true,
+		},
+		"object and table together": {
+			table:  LootTable{Entries: []LootEntry{{Object: obj, Table: storage.NewSmartIdentifier[*LootTable]("gems")}}},
+			expErr: true,
+		},
+		"chance over 100": {
+			table:  LootTable{Entries: []LootEntry{{Object: obj, Chance: 101}}},
+			expErr: true,
+		},
+		"max count below min": {
+			table:  LootTable{Entries: []LootEntry{{Object: obj, MinCount: 3, MaxCount: 2}}},
+			expErr: true,
+		},
+		"max level below min": {
+			table:  LootTable{Entries: []LootEntry{{Object: obj, MinLevel: 10, MaxLevel: 5}}},
+			expErr: true,
+		},
+		"bad entry inside a group": {
+			table:  LootTable{Entries: []LootEntry{{OneOf: []LootEntry{{Object: obj, Weight: -1}}}}},
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.table.Validate(); (err != nil) != tc.expErr {
+				t.Errorf("Validate() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestLootTable_Roll(t *testing.T) {
+	coin, gem, ring := lootObj("coin"), lootObj("gem"), lootObj("ring")
+	gems := storage.NewResolvedSmartIdentifier("gems", &LootTable{Entries: []LootEntry{{Object: gem}}})
+
+	tests := map[string]struct {
+		entries []LootEntry
+		level   int
+		rolls   []int
+		want    []string
+	}{
+		"always drops": {
+			entries: []LootEntry{{Object: coin}},
+			want:    []string{"coin"},
+		},
+		"chance hit": {
+			entries: []LootEntry{{Object: coin, Chance: 25}},
+			rolls:   []int{24},
+			want:    []string{"coin"},
+		},
+		"chance missed": {
+			entries: []LootEntry{{Object: coin, Chance: 25}},
+			rolls:   []int{25},
+		},
+		"chance grows with level": {
+			entries: []LootEntry{{Object: coin, Chance: 10, ChancePerLevel: 5, MinLevel: 2}},
+			level:   6,
+			rolls:   []int{29},
+			want:    []string{"coin"},
+		},
+		"below min level": {
+			entries: []LootEntry{{Object: coin, MinLevel: 5}},
+			level:   4,
+		},
+		"above max level": {
+			entries: []LootEntry{{Object: coin, MaxLevel: 5}},
+			level:   6,
+		},
+		"quantity range": {
+			entries: []LootEntry{{Object: coin, MinCount: 2, MaxCount: 4}},
+			rolls:   []int{1},
+			want:    []string{"coin", "coin", "coin"},
+		},
+		"nested table": {
+			entries: []LootEntry{{Table: gems}, {Object: coin}},
+			want:    []string{"gem", "coin"},
+		},
+		"one of picks by weight": {
+			entries: []LootEntry{{OneOf: []LootEntry{{Object: coin, Weight: 3}, {Object: ring, Weight: 1}}}},
+			rolls:   []int{3},
+			want:    []string{"ring"},
+		},
+		"one of can pick the blank": {
+			entries: []LootEntry{{OneOf: []LootEntry{{Object: coin}, {Weight: 9}}}},
+			rolls:   []int{5},
+		},
+		"one of skips entries out of level": {
+			entries: []LootEntry{{OneOf: []LootEntry{{Object: ring, MinLevel: 10, Weight: 100}, {Object: coin}}}},
+			level:   1,
+			want:    []string{"coin"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := &LootTable{Entries: tc.entries}
+			var got []string
+			for _, spec := range table.Roll(tc.level, scriptedDice(tc.rolls...)) {
+				got = append(got, spec.Object.Id())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Roll() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLootTable_FindCycle(t *testing.T) {
+	a, b, c := &LootTable{}, &LootTable{}, &LootTable{}
+	ref := func(id string, t *LootTable) storage.SmartIdentifier[*LootTable] {
+		return storage.NewResolvedSmartIdentifier(id, t)
+	}
+	a.Entries = []LootEntry{{Table: ref("b", b)}, {Table: ref("c", c)}}
+	b.Entries = []LootEntry{{Table: ref("c", c)}}
+	c.Entries = []LootEntry{{OneOf: []LootEntry{{Table: ref("a", a)}}}}
+
+	if got, want := a.FindCycle("a"), []string{"a", "b", "c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("FindCycle() = %v, want %v", got, want)
+	}
+	c.Entries = nil
+	if got := a.FindCycle("a"); got != nil {
+		t.Errorf("FindCycle() = %v, want nil", got)
+	}
+}
+
+func TestLootRoll_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		json      string
+		wantTable string
+		wantLevel int
+	}{
+		"bare id":    {json: `"darkwood-trinkets"`, wantTable: "darkwood-trinkets"},
+		"with level": {json: `{"table_id": "darkwood-trinkets", "level": 8}`, wantTable: "darkwood-trinkets", wantLevel: 8},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got LootRoll
+			if err := json.Unmarshal([]byte(tc.json), &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got.Table.Id() != tc.wantTable || got.Level != tc.wantLevel {
+				t.Errorf("Unmarshal() = %q level %d, want %q level %d", got.Table.Id(), got.Level, tc.wantTable, tc.wantLevel)
+			}
+		})
+	}
+}
@@ -55,6 +55,34 @@ func parseMobileFlag(s string) MobileFlag {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Mobile behavior
+// ---------------------------------------------------------------------------
+
+// MobBehavior tunes how often an idle mob acts. Each chance is 1-in-N per
+// tick; zero leaves it to the next level up (mob, then zone, then the game
+// default).
+type MobBehavior struct {
+	WanderChance   int `json:"wander_chance,omitempty"`
+	ScavengeChance int `json:"scavenge_chance,omitempty"`
+	AggroChance    int `json:"aggro_chance,omitempty"`
+}
+
+// Validate returns an error if any chance is negative.
+func (b *MobBehavior) Validate() error {
+	var errs []error
+	if b.WanderChance < 0 {
+		errs = append(errs, errors.New("wander_chance must not be negative"))
+	}
+	if b.ScavengeChance < 0 {
+		errs = append(errs, errors.New("scavenge_chance must not be negative"))
+	}
+	if b.AggroChance < 0 {
+		errs = append(errs, errors.New("aggro_chance must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
 // ---------------------------------------------------------------------------
 // Mobile
 // ---------------------------------------------------------------------------
@@ -82,6 +110,13 @@ type Mobile struct {
 	// Equipment is the mobile's starting equipment
 	Equipment []EquipmentSpawn `json:"equipment,omitempty"`
 
+	// Loot tables are rolled into the mobile's corpse when it dies.
+	Loot []LootRoll `json:"loot,omitempty"`
+
+	// Gold is what the mobile carries. It is paid out when the mobile is
+	// killed, to the first looter the killing group's loot mode picks.
+	Gold int `json:"gold,omitempty"`
+
 	Level int `json:"level,omitempty"`
 
 	// Perks define the mobile's resources, combat stats, and other perk-driven values.
@@ -93,6 +128,20 @@ type Mobile struct {
 	// ExpReward overrides the base XP awarded when this mobile is killed.
 	// If 0, base XP is calculated from the mobile's level.
 	ExpReward int `json:"exp_reward,omitempty"`
+
+	// Behavior overrides the zone's wander, scavenge and aggro chances.
+	Behavior MobBehavior `json:"behavior,omitempty"`
+
+	// Triggers run scripts when things happen to or around the mobile.
+	Triggers []Trigger `json:"triggers,omitempty"`
+
+	// Dialogue is the conversation players can hold with the mobile.
+	Dialogue storage.SmartIdentifier[*Dialogue] `json:"dialogue_id"`
+
+	// Faction is the faction the mobile belongs to. Killing it costs
+	// standing with the faction, and it attacks players the faction regards
+	// as hostile.
+	Faction storage.SmartIdentifier[*Faction] `json:"faction_id"`
 }
 
 // HasFlag returns true if the mobile has the given flag.
@@ -129,11 +178,23 @@ func (m *Mobile) Validate() error {
 			errs = append(errs, fmt.Errorf("unknown flag %q", f))
 		}
 	}
+	if err := m.Behavior.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("behavior: %w", err))
+	}
+	if err := validateLootRolls(m.Loot); err != nil {
+		errs = append(errs, err)
+	}
+	if m.Gold < 0 {
+		errs = append(errs, errors.New("gold must not be negative"))
+	}
+	if err := validateTriggers(m.Triggers, TriggerOwnerMobile); err != nil {
+		errs = append(errs, err)
+	}
 	return errors.Join(errs...)
 }
 
 // Resolve resolves foreign key references on the mobile definition.
-func (m *Mobile) Resolve(objs storage.Storer[*Object]) error {
+func (m *Mobile) Resolve(objs storage.Storer[*Object], loot storage.Storer[*LootTable], dialogues storage.Storer[*Dialogue], factions storage.Storer[*Faction]) error {
 	var errs []error
 	for i := range m.Inventory {
 		errs = append(errs, m.Inventory[i].Resolve(objs))
@@ -141,5 +202,13 @@ func (m *Mobile) Resolve(objs storage.Storer[*Object]) error {
 	for i := range m.Equipment {
 		errs = append(errs, m.Equipment[i].Resolve(objs))
 	}
+	errs = append(errs, resolveLootRolls(m.Loot, loot))
+	errs = append(errs, resolveTriggers(m.Triggers, objs))
+	if m.Dialogue.Id() != "" {
+		errs = append(errs, m.Dialogue.Resolve(dialogues))
+	}
+	if m.Faction.Id() != "" {
+		errs = append(errs, m.Faction.Resolve(factions))
+	}
 	return errors.Join(errs...)
 }
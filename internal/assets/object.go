@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"text/template"
 
 	"github.com/pixil98/go-mud/internal/storage"
 )
@@ -71,6 +72,29 @@ type Object struct {
 	// Closure defines open/close/lock behavior. Only meaningful when the "container" flag is set.
 	Closure *Closure `json:"closure,omitempty"`
 
+	// Weight counts against the carrier's carry limit and a container's capacity.
+	Weight int `json:"weight,omitempty"`
+
+	// Capacity is the total weight a container can hold. Zero means unbounded.
+	// Requires the "container" flag.
+	Capacity int `json:"capacity,omitempty"`
+
+	// Food makes the object edible.
+	Food *Food `json:"food,omitempty"`
+
+	// Drink makes the object a liquid holder (drink container or fountain).
+	Drink *Drink `json:"drink,omitempty"`
+
+	// Light makes the object a light source that can be lit and extinguished.
+	Light *Light `json:"light,omitempty"`
+
+	// Lever makes the object reveal a hidden exit in its room when pulled.
+	Lever *Lever `json:"lever,omitempty"`
+
+	// Portal makes the object a doorway to another room. Each trip spends a
+	// charge when the object has Charges.
+	Portal *Portal `json:"portal,omitempty"`
+
 	// Weapon damage dice (intrinsic weapon properties, not additive bonuses).
 	DamageDice  int `json:"damage_dice,omitempty"`
 	DamageSides int `json:"damage_sides,omitempty"`
@@ -78,13 +102,44 @@ type Object struct {
 	// Perks granted while this item is equipped (AC, stat mods, damage mods, etc.).
 	Perks []Perk `json:"perks,omitempty"`
 
+	// Durability is how much damage a fresh instance can take before it
+	// breaks. A broken item grants no perks. Zero means it can't be damaged.
+	Durability int `json:"durability,omitempty"`
+
+	// Charges is the number of uses a fresh instance holds (e.g., a wand).
+	Charges int `json:"charges,omitempty"`
+
 	// Lifetime is the number of game ticks before a spawned instance of this
 	// object decays and is removed. Zero (the default) means permanent.
 	// Decayable items (Lifetime > 0) are not persisted across logout.
 	Lifetime int `json:"lifetime,omitempty"`
 
+	// DecayMessage is a Go template shown when an instance decays, with the
+	// object's short description available as {{ .Object }}
+	// (e.g., "{{ .Object }} crumbles to dust."). Requires a Lifetime.
+	DecayMessage string `json:"decay_message,omitempty"`
+
 	// ExtraDescs are keyword-accessible descriptions on this object.
 	ExtraDescs []ExtraDesc `json:"extra_descs,omitempty"`
+
+	// Triggers run scripts when players use the object, carried or in the
+	// room.
+	Triggers []Trigger `json:"triggers,omitempty"`
+
+	decayTmpl *template.Template
+}
+
+// DecayTemplate returns the compiled DecayMessage, or nil if the object has
+// none. Loaded objects are compiled when validated.
+func (o *Object) DecayTemplate() *template.Template {
+	return o.decayTmpl
+}
+
+// SetDecayTemplate sets the template shown when an instance decays, in place
+// of DecayMessage, for objects built at runtime rather than loaded. It must be
+// called before the object is shared.
+func (o *Object) SetDecayTemplate(t *template.Template) {
+	o.decayTmpl = t
 }
 
 // MatchName returns true if name matches any of this object's aliases (case-insensitive).
@@ -127,26 +182,93 @@ func (o *Object) Validate() error {
 	if err := validatePerks(o.Perks); err != nil {
 		errs = append(errs, err)
 	}
+	if o.Weight < 0 {
+		errs = append(errs, errors.New("weight must not be negative"))
+	}
+	if o.Capacity < 0 {
+		errs = append(errs, errors.New("capacity must not be negative"))
+	}
+	if o.Capacity > 0 && !o.HasFlag(ObjectFlagContainer) {
+		errs = append(errs, errors.New("capacity requires the container flag"))
+	}
+	if o.Durability < 0 {
+		errs = append(errs, errors.New("durability must not be negative"))
+	}
+	if o.Charges < 0 {
+		errs = append(errs, errors.New("charges must not be negative"))
+	}
 	if o.Closure != nil {
 		if !o.HasFlag(ObjectFlagContainer) {
 			errs = append(errs, errors.New("closure requires the container flag"))
 		}
 		errs = append(errs, o.Closure.Validate())
 	}
+	if o.Food != nil {
+		if err := o.Food.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("food: %w", err))
+		}
+	}
+	if o.Drink != nil {
+		if err := o.Drink.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("drink: %w", err))
+		}
+		if o.Drink.Infinite && !o.HasFlag(ObjectFlagImmobile) {
+			errs = append(errs, errors.New("infinite drink sources require the immobile flag"))
+		}
+	}
+	if o.Light != nil {
+		if err := o.Light.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("light: %w", err))
+		}
+	}
+	if o.Lever != nil {
+		if o.Lever.Exit == "" {
+			errs = append(errs, errors.New("lever: exit is required"))
+		}
+		if !o.HasFlag(ObjectFlagImmobile) {
+			errs = append(errs, errors.New("lever requires the immobile flag"))
+		}
+	}
+	if o.Portal != nil {
+		if o.Portal.Room.Id() == "" {
+			errs = append(errs, errors.New("portal: room_id is required"))
+		}
+		if !o.HasFlag(ObjectFlagImmobile) {
+			errs = append(errs, errors.New("portal requires the immobile flag"))
+		}
+	}
+	if o.DecayMessage != "" {
+		if o.Lifetime <= 0 {
+			errs = append(errs, errors.New("decay_message requires a lifetime"))
+		}
+		t, err := template.New("decay").Parse(o.DecayMessage)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("decay_message: %w", err))
+		}
+		o.decayTmpl = t
+	}
 	for i := range o.ExtraDescs {
 		if err := o.ExtraDescs[i].Validate(); err != nil {
 			errs = append(errs, fmt.Errorf("extra_descs[%d]: %w", i, err))
 		}
 	}
+	if err := validateTriggers(o.Triggers, TriggerOwnerObject); err != nil {
+		errs = append(errs, err)
+	}
 	return errors.Join(errs...)
 }
 
 // Resolve resolves foreign key references on the object definition.
-func (o *Object) Resolve(objs storage.Storer[*Object]) error {
+func (o *Object) Resolve(zones storage.Storer[*Zone], rooms storage.Storer[*Room], objs storage.Storer[*Object]) error {
+	var errs []error
 	if o.Closure != nil {
-		return o.Closure.Resolve(objs)
+		errs = append(errs, o.Closure.Resolve(objs))
+	}
+	if o.Portal != nil {
+		errs = append(errs, o.Portal.Resolve(zones, rooms))
 	}
-	return nil
+	errs = append(errs, resolveTriggers(o.Triggers, objs))
+	return errors.Join(errs...)
 }
 
 // ObjectSpawn defines an object to spawn in a room or mobile inventory during zone reset.
@@ -155,6 +277,35 @@ func (o *Object) Resolve(objs storage.Storer[*Object]) error {
 type ObjectSpawn struct {
 	Object   storage.SmartIdentifier[*Object] `json:"object_id"`
 	Contents []ObjectSpawn                    `json:"contents,omitempty"`
+
+	// State carries per-instance runtime state when a live object is saved
+	// (e.g., a half-empty waterskin). Nil spawns a fresh instance.
+	State *ObjectState `json:"state,omitempty"`
+}
+
+// ObjectState is the persisted per-instance state of an object that differs
+// from its definition.
+type ObjectState struct {
+	// Liquid and Sips record a drink container's current contents.
+	Liquid string `json:"liquid,omitempty"`
+	Sips   int    `json:"sips,omitempty"`
+
+	// Lit and Burn record a light source's flame and remaining burn ticks.
+	Lit  bool `json:"lit,omitempty"`
+	Burn int  `json:"burn,omitempty"`
+
+	// Perks are extra perks on this instance only (e.g., an enchantment),
+	// granted on top of the definition's perks while equipped.
+	Perks []Perk `json:"perks,omitempty"`
+
+	// Damage and ChargesUsed are stored as amounts spent rather than amounts
+	// left, so raising a definition's durability or charges later doesn't
+	// leave older saves broken or empty.
+	Damage      int `json:"damage,omitempty"`
+	ChargesUsed int `json:"charges_used,omitempty"`
+
+	// Name replaces the definition's short description (a restring).
+	Name string `json:"name,omitempty"`
 }
 
 // Resolve resolves foreign key references in the spawn spec.
@@ -59,6 +59,37 @@ var StatPerkKeys = map[PerkKey]StatKey{
 
 const PerkKeyActionPointsMax PerkKey = "core.action_points.max"
 
+// ---------------------------------------------------------------------------
+// Carry capacity — core.carry.max (individual key, added to the STR limit)
+// ---------------------------------------------------------------------------
+
+const PerkKeyCarryMax PerkKey = "core.carry.max"
+
+// ---------------------------------------------------------------------------
+// Alignment — core.alignment (individual key, -1000 evil to 1000 good)
+// ---------------------------------------------------------------------------
+
+const PerkKeyAlignment PerkKey = "core.alignment"
+
+// Alignment bands, split where CircleMUD splits them.
+const (
+	AlignmentGood    = "good"    // 350 and above
+	AlignmentNeutral = "neutral" // between the other two
+	AlignmentEvil    = "evil"    // -350 and below
+)
+
+// AlignmentBand returns the band an alignment value falls in.
+func AlignmentBand(value int) string {
+	switch {
+	case value >= 350:
+		return AlignmentGood
+	case value <= -350:
+		return AlignmentEvil
+	default:
+		return AlignmentNeutral
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Combat prefixes — core.combat.<property>.<suffix>
 // All combat modifiers use the flat/pct pattern via ApplyModifiers.
@@ -70,6 +101,18 @@ const (
 	CombatThreatPrefix = "core.combat.threat" // threat generation scaling
 )
 
+// ---------------------------------------------------------------------------
+// Skill prefixes — core.skill.<skill>.flat
+// Flat bonuses added to d20 skill checks on top of the governing stat.
+// ---------------------------------------------------------------------------
+
+const (
+	SkillPickPrefix   = "core.skill.pick"   // picking locks (DEX)
+	SkillSearchPrefix = "core.skill.search" // finding traps (WIS)
+	SkillDisarmPrefix = "core.skill.disarm" // disarming traps (DEX)
+	SkillTrackPrefix  = "core.skill.track"  // following trails (WIS)
+)
+
 // ---------------------------------------------------------------------------
 // Key builder — joins parts with "." to form perk keys
 // ---------------------------------------------------------------------------
@@ -110,6 +153,17 @@ const (
 
 const ResourceHp = "hp"
 
+// ResourceMove is spent walking between rooms; each step costs the
+// destination's sector move cost. Opt-in like the survival resources.
+const ResourceMove = "move"
+
+// Survival resources. Both are opt-in: a race (or other perk source) enables
+// them by granting a max, and they drain by their drain aspect over time.
+const (
+	ResourceHunger = "hunger"
+	ResourceThirst = "thirst"
+)
+
 const ResourcePrefix = "core.resource"
 
 // Resource aspect constants.
@@ -117,6 +171,8 @@ const (
 	ResourceAspectMax      = "max"
 	ResourceAspectPerLevel = "per_level"
 	ResourceAspectRegen    = "regen"
+	ResourceAspectRest     = "rest_regen" // extra regen while resting
+	ResourceAspectDrain    = "drain"      // amount lost per survival interval
 )
 
 // ---------------------------------------------------------------------------
@@ -140,6 +196,23 @@ const (
 	// darkvision, "room_water" for waterwalk). Granted by racial traits,
 	// spells, or equipment like torches and lanterns.
 	PerkGrantIgnoreRestriction = "ignore_restriction"
+	// PerkGrantNoHunger and PerkGrantNoThirst suspend the matching survival
+	// resource: it stops draining and no longer penalizes regen. Zones grant
+	// these to opt out of survival mechanics entirely.
+	PerkGrantNoHunger = "nohunger"
+	PerkGrantNoThirst = "nothirst"
+	// PerkGrantMoveCost overrides the movement cost of entering a sector.
+	// Arg format: "sector:cost" (e.g. "mountain:2"); sector "all" matches
+	// every sector. The cheapest applicable cost wins. Granted by mounts
+	// and flight.
+	PerkGrantMoveCost = "move_cost"
+	// PerkGrantRevealExit lets the holder see and use a hidden exit.
+	// Arg format: "room_id:direction" (e.g. "millbrook-cellar:down").
+	PerkGrantRevealExit = "reveal_exit"
+	// PerkGrantGuardedBy marks the holder as guarded: the actor whose
+	// instance ID is in Arg steps in to take some of the attacks aimed at
+	// them. Granted as a timed perk by the guard ability.
+	PerkGrantGuardedBy = "guarded_by"
 )
 
 // ---------------------------------------------------------------------------
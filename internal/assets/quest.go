@@ -0,0 +1,194 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Quest objective types.
+const (
+	QuestObjectiveKill    = "kill"    // kill Count of Mobile
+	QuestObjectiveCollect = "collect" // carry Count of Object
+	QuestObjectiveDeliver = "deliver" // give Count of Object to Mobile
+	QuestObjectiveVisit   = "visit"   // set foot in Room
+	QuestObjectiveUse     = "use"     // use Ability Count times
+)
+
+// Quest states dialogue conditions can test for on top of those saved with
+// the character. Neither is ever saved.
+const (
+	// QuestStateAvailable is a quest not yet started whose prerequisites the
+	// character meets.
+	QuestStateAvailable = "available"
+	// QuestStateReady is an active quest with every objective done, waiting
+	// to be handed in.
+	QuestStateReady = "ready"
+)
+
+// Quest is a task a character takes on, usually from a mobile's dialogue.
+// Progress is saved with the character.
+// Quest IDs follow the convention <zone>-<name> (e.g., "millbrook-barrow").
+type Quest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// MinLevel, Requires and Standings are prerequisites: the level the
+	// character must have reached, the quests they must have completed and
+	// the standing they must have with factions.
+	MinLevel  int                               `json:"min_level,omitempty"`
+	Requires  []storage.SmartIdentifier[*Quest] `json:"requires,omitempty"`
+	Standings []StandingRequirement             `json:"standings,omitempty"`
+
+	// Objectives are what the character must do. When Ordered, each only
+	// counts once the ones before it are done; otherwise they can be done in
+	// any order.
+	Objectives []QuestObjective `json:"objectives"`
+	Ordered    bool             `json:"ordered,omitempty"`
+
+	// TurnIn quests wait, once their objectives are done, for a mobile's
+	// dialogue to complete them. Others complete as soon as the last
+	// objective is done.
+	TurnIn bool `json:"turn_in,omitempty"`
+
+	Rewards QuestRewards `json:"rewards"`
+}
+
+// QuestObjective is one thing a quest asks for. Which fields apply depends
+// on the type.
+type QuestObjective struct {
+	Type    string                            `json:"type"`
+	Mobile  storage.SmartIdentifier[*Mobile]  `json:"mobile_id"`
+	Object  storage.SmartIdentifier[*Object]  `json:"object_id"`
+	Room    storage.SmartIdentifier[*Room]    `json:"room_id"`
+	Ability storage.SmartIdentifier[*Ability] `json:"ability_id"`
+
+	// Count is how many times it must be done. Zero means once.
+	Count int `json:"count,omitempty"`
+
+	// Text describes the objective in the quest journal. Empty uses a
+	// description built from the type.
+	Text string `json:"text,omitempty"`
+}
+
+// QuestRewards are given when a quest is completed. Collected objects are
+// taken from the character at the same time.
+type QuestRewards struct {
+	XP      int           `json:"xp,omitempty"`
+	Gold    int           `json:"gold,omitempty"`
+	Objects []ObjectSpawn `json:"objects,omitempty"`
+
+	// Perks are given for good, as if learned.
+	Perks []Perk `json:"perks,omitempty"`
+}
+
+// Needed returns how many times the objective must be done.
+func (o *QuestObjective) Needed() int {
+	return max(o.Count, 1)
+}
+
+// Validate satisfies storage.ValidatingSpec.
+func (q *Quest) Validate() error {
+	var errs []error
+	if q.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if q.MinLevel < 0 {
+		errs = append(errs, errors.New("min_level must not be negative"))
+	}
+	for i := range q.Standings {
+		s := &q.Standings[i]
+		if s.Faction.Id() == "" {
+			errs = append(errs, fmt.Errorf("standings[%d]: faction_id is required", i))
+		} else if err := s.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("standings[%d]: %w", i, err))
+		}
+	}
+	if len(q.Objectives) == 0 {
+		errs = append(errs, errors.New("at least one objective is required"))
+	}
+	for i := range q.Objectives {
+		if err := q.Objectives[i].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("objectives[%d]: %w", i, err))
+		}
+	}
+	if q.Rewards.XP < 0 || q.Rewards.Gold < 0 {
+		errs = append(errs, errors.New("rewards must not be negative"))
+	}
+	if err := validatePerks(q.Rewards.Perks); err != nil {
+		errs = append(errs, fmt.Errorf("rewards: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve resolves the quests, factions, mobiles, objects, rooms and
+// abilities the quest refers to.
+func (q *Quest) Resolve(quests storage.Storer[*Quest], factions storage.Storer[*Faction], mobs storage.Storer[*Mobile], objs storage.Storer[*Object], rooms storage.Storer[*Room], abilities storage.Storer[*Ability]) error {
+	var errs []error
+	for i := range q.Requires {
+		errs = append(errs, q.Requires[i].Resolve(quests))
+	}
+	for i := range q.Standings {
+		errs = append(errs, q.Standings[i].resolve(factions))
+	}
+	for i := range q.Objectives {
+		o := &q.Objectives[i]
+		if o.Mobile.Id() != "" {
+			errs = append(errs, o.Mobile.Resolve(mobs))
+		}
+		if o.Object.Id() != "" {
+			errs = append(errs, o.Object.Resolve(objs))
+		}
+		if o.Room.Id() != "" {
+			errs = append(errs, o.Room.Resolve(rooms))
+		}
+		if o.Ability.Id() != "" {
+			errs = append(errs, o.Ability.Resolve(abilities))
+		}
+	}
+	for i := range q.Rewards.Objects {
+		errs = append(errs, q.Rewards.Objects[i].Resolve(objs))
+	}
+	return errors.Join(errs...)
+}
+
+func (o *QuestObjective) validate() error {
+	var mobile, object, room, ability bool
+	switch o.Type {
+	case QuestObjectiveKill:
+		mobile = true
+	case QuestObjectiveCollect:
+		object = true
+	case QuestObjectiveDeliver:
+		mobile, object = true, true
+	case QuestObjectiveVisit:
+		room = true
+	case QuestObjectiveUse:
+		ability = true
+	default:
+		return fmt.Errorf("unknown type %q", o.Type)
+	}
+
+	var errs []error
+	check := func(name string, want, set bool) {
+		switch {
+		case want && !set:
+			errs = append(errs, fmt.Errorf("%s needs %s", o.Type, name))
+		case !want && set:
+			errs = append(errs, fmt.Errorf("%s does not take %s", o.Type, name))
+		}
+	}
+	check("mobile_id", mobile, o.Mobile.Id() != "")
+	check("object_id", object, o.Object.Id() != "")
+	check("room_id", room, o.Room.Id() != "")
+	check("ability_id", ability, o.Ability.Id() != "")
+
+	if o.Count < 0 {
+		errs = append(errs, errors.New("count must not be negative"))
+	}
+	if o.Type == QuestObjectiveVisit && o.Count > 1 {
+		errs = append(errs, errors.New("visit does not take a count"))
+	}
+	return errors.Join(errs...)
+}
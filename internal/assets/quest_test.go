@@ -0,0 +1,66 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestQuest_Validate(t *testing.T) {
+	wolf := storage.NewSmartIdentifier[*Mobile]("wolf")
+	pelt := storage.NewSmartIdentifier[*Object]("pelt")
+	kill := QuestObjective{Type: QuestObjectiveKill, Mobile: wolf, Count: 3}
+	tests := map[string]struct {
+		quest  Quest
+		expErr bool
+	}{
+		"every objective type": {
+			quest: Quest{Name: "Cull", Ordered: true, Objectives: []QuestObjective{
+				kill,
+				{Type: QuestObjectiveCollect, Object: pelt, Count: 3},
+				{Type: QuestObjectiveDeliver, Object: pelt, Mobile: storage.NewSmartIdentifier[*Mobile]("tanner")},
+				{Type: QuestObjectiveVisit, Room: storage.NewSmartIdentifier[*Room]("den")},
+				{Type: QuestObjectiveUse, Ability: storage.NewSmartIdentifier[*Ability]("track")},
+			}, Rewards: QuestRewards{XP: 100, Gold: 5, Perks: []Perk{{Type: PerkTypeGrant, Key: PerkGrantUnlockAbility, Arg: "skin"}}}},
+		},
+		"no name": {
+			quest:  Quest{Objectives: []QuestObjective{kill}},
+			expErr: true,
+		},
+		"no objectives": {
+			quest:  Quest{Name: "Cull"},
+			expErr: true,
+		},
+		"unknown objective type": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{{Type: "befriend", Mobile: wolf}}},
+			expErr: true,
+		},
+		"objective missing its target": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{{Type: QuestObjectiveDeliver, Object: pelt}}},
+			expErr: true,
+		},
+		"objective with a stray field": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{{Type: QuestObjectiveKill, Mobile: wolf, Object: pelt}}},
+			expErr: true,
+		},
+		"visit with a count": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{{Type: QuestObjectiveVisit, Room: storage.NewSmartIdentifier[*Room]("den"), Count: 2}}},
+			expErr: true,
+		},
+		"negative reward": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{kill}, Rewards: QuestRewards{Gold: -1}},
+			expErr: true,
+		},
+		"bad reward perk": {
+			quest:  Quest{Name: "Cull", Objectives: []QuestObjective{kill}, Rewards: QuestRewards{Perks: []Perk{{Type: "gift"}}}},
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.quest.Validate(); (err != nil) != tc.expErr {
+				t.Errorf("Validate() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
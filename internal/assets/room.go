@@ -25,8 +25,64 @@ const (
 	RoomFlagNoMagic        RoomFlag = "room_nomagic"         // Spellcasting blocked unless caster has "ignore_restriction:room_nomagic"
 	RoomFlagWater          RoomFlag = "room_water"           // Deep water; entry blocked unless actor has "ignore_restriction:room_water"
 	RoomFlagPeaceful       RoomFlag = "room_peaceful"        // Combat initiation blocked unless actor has "ignore_restriction:room_peaceful"
+	RoomFlagIndoors        RoomFlag = "room_indoors"         // Sheltered from the sky: no night darkness or weather messages
+	RoomFlagArena          RoomFlag = "room_arena"           // Players may always fight each other here, and a defeat is not a death
 )
 
+// ---------------------------------------------------------------------------
+// Sectors
+// ---------------------------------------------------------------------------
+
+// Sector is the terrain type of a room. It sets how many movement points it
+// costs to enter the room; an empty sector is treated as inside.
+type Sector string
+
+const (
+	SectorInside      Sector = "inside"
+	SectorCity        Sector = "city"
+	SectorField       Sector = "field"
+	SectorForest      Sector = "forest"
+	SectorHills       Sector = "hills"
+	SectorMountain    Sector = "mountain"
+	SectorWaterSwim   Sector = "water_swim"
+	SectorWaterNoSwim Sector = "water_noswim"
+	SectorUnderwater  Sector = "underwater"
+	SectorAir         Sector = "air"
+
+	// SectorAll matches every sector in a "move_cost" grant.
+	SectorAll = "all"
+)
+
+// sectorMoveCosts is the movement point cost of entering each sector.
+var sectorMoveCosts = map[Sector]int{
+	SectorInside:      1,
+	SectorCity:        1,
+	SectorField:       2,
+	SectorForest:      3,
+	SectorHills:       4,
+	SectorMountain:    6,
+	SectorWaterSwim:   4,
+	SectorWaterNoSwim: 1,
+	SectorUnderwater:  5,
+	SectorAir:         1,
+}
+
+// MoveCost returns the movement points needed to enter a room of this sector.
+func (s Sector) MoveCost() int {
+	if s == "" {
+		return sectorMoveCosts[SectorInside]
+	}
+	return sectorMoveCosts[s]
+}
+
+// Validate returns an error if the sector is not a known type.
+func (s Sector) Validate() error {
+	if _, ok := sectorMoveCosts[s]; s != "" && !ok {
+		return fmt.Errorf("unknown sector %q", s)
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Extra descriptions
 // ---------------------------------------------------------------------------
@@ -60,6 +116,68 @@ type Exit struct {
 	Room        storage.SmartIdentifier[*Room] `json:"room_id"`
 	Closure     *Closure                       `json:"closure,omitempty"`     // Optional open/close/lock barrier
 	Description string                         `json:"description,omitempty"` // Shown when player looks in this direction
+	Hidden      *HiddenExit                    `json:"hidden,omitempty"`      // Optional; exit is secret until discovered
+}
+
+// HiddenExit marks an exit as secret. Hidden exits are left out of the exit
+// list and can't be used until discovered by searching, pulling a lever, or
+// a script.
+type HiddenExit struct {
+	// Difficulty is the check a search must meet to find the exit.
+	// Default: DefaultCheckDifficulty.
+	Difficulty int `json:"difficulty,omitempty"`
+
+	// Duration is how many ticks a searcher remembers the exit. Zero reveals
+	// it to everyone until the zone resets.
+	Duration int `json:"duration,omitempty"`
+}
+
+// Validate checks that the difficulty and duration aren't negative.
+func (h *HiddenExit) Validate() error {
+	var errs []error
+	if h.Difficulty < 0 {
+		errs = append(errs, errors.New("difficulty must not be negative"))
+	}
+	if h.Duration < 0 {
+		errs = append(errs, errors.New("duration must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// SearchDifficulty returns the check needed to find the exit.
+func (h *HiddenExit) SearchDifficulty() int {
+	if h.Difficulty == 0 {
+		return DefaultCheckDifficulty
+	}
+	return h.Difficulty
+}
+
+// Lever lets an immobile object reveal a hidden exit in its room when pulled.
+// The exit is revealed to everyone until the zone resets.
+type Lever struct {
+	// Exit is the direction of the hidden exit in the object's room.
+	Exit string `json:"exit"`
+
+	// Message is shown to the room when the lever is pulled.
+	// Default: a grinding noise as the way opens.
+	Message string `json:"message,omitempty"`
+}
+
+// Portal makes an object a magic doorway that players enter to reach another
+// room. A portal with charges spends one per trip and fades away once they
+// run out; one without charges stays open for good.
+type Portal struct {
+	Zone storage.SmartIdentifier[*Zone] `json:"zone_id"` // Optional; defaults to the portal's zone
+	Room storage.SmartIdentifier[*Room] `json:"room_id"`
+}
+
+// Resolve resolves the portal's destination references.
+func (p *Portal) Resolve(zones storage.Storer[*Zone], rooms storage.Storer[*Room]) error {
+	errs := []error{p.Room.Resolve(rooms)}
+	if p.Zone.Id() != "" {
+		errs = append(errs, p.Zone.Resolve(zones))
+	}
+	return errors.Join(errs...)
 }
 
 // ---------------------------------------------------------------------------
@@ -68,14 +186,23 @@ type Exit struct {
 
 // Room represents a location within a zone.
 type Room struct {
-	Name        string                             `json:"name"`
-	Description string                             `json:"description"`
-	Zone        storage.SmartIdentifier[*Zone]     `json:"zone_id"`
-	Exits       map[string]Exit                    `json:"exits"`
-	MobSpawns   []storage.SmartIdentifier[*Mobile] `json:"mobile_spawns"` // mobile IDs to spawn; list duplicates for multiple
-	ObjSpawns   []ObjectSpawn                      `json:"object_spawns"` // objects to spawn
-	Perks       []Perk                             `json:"perks,omitempty"`
-	ExtraDescs  []ExtraDesc                        `json:"extra_descs,omitempty"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Zone        storage.SmartIdentifier[*Zone] `json:"zone_id"`
+	Sector      Sector                         `json:"sector,omitempty"`
+	Exits       map[string]Exit                `json:"exits"`
+	MobSpawns   []MobSpawn                     `json:"mobile_spawns"` // mobiles to spawn; list duplicates for multiple
+	TimedSpawns []TimedMobSpawn                `json:"timed_mobile_spawns,omitempty"`
+	ObjSpawns   []RoomObjectSpawn              `json:"object_spawns"`  // objects to spawn
+	Loot        []LootRoll                     `json:"loot,omitempty"` // loot tables rolled onto the floor on reset
+	Perks       []Perk                         `json:"perks,omitempty"`
+	ExtraDescs  []ExtraDesc                    `json:"extra_descs,omitempty"`
+
+	// TimeDescs replaces Description during the given times of day.
+	TimeDescs map[TimeOfDay]string `json:"time_descriptions,omitempty"`
+
+	// Triggers run scripts when things happen in the room.
+	Triggers []Trigger `json:"triggers,omitempty"`
 }
 
 // Validate returns an error if the room definition is invalid.
@@ -86,6 +213,9 @@ func (r *Room) Validate() error {
 		errs = append(errs, errors.New("room name is required"))
 	}
 	errs = append(errs, r.Zone.Validate())
+	if err := r.Sector.Validate(); err != nil {
+		errs = append(errs, err)
+	}
 
 	for dir, exit := range r.Exits {
 		if err := exit.Room.Validate(); err != nil {
@@ -99,6 +229,11 @@ func (r *Room) Validate() error {
 				errs = append(errs, fmt.Errorf("exit %s closure: %w", dir, err))
 			}
 		}
+		if exit.Hidden != nil {
+			if err := exit.Hidden.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("exit %s hidden: %w", dir, err))
+			}
+		}
 	}
 
 	if err := validatePerks(r.Perks); err != nil {
@@ -111,11 +246,38 @@ func (r *Room) Validate() error {
 		}
 	}
 
+	for i := range r.MobSpawns {
+		if err := r.MobSpawns[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("mobile_spawns[%d]: %w", i, err))
+		}
+	}
+	for i := range r.ObjSpawns {
+		if err := r.ObjSpawns[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("object_spawns[%d]: %w", i, err))
+		}
+	}
+	if err := validateLootRolls(r.Loot); err != nil {
+		errs = append(errs, err)
+	}
+	for i := range r.TimedSpawns {
+		if err := r.TimedSpawns[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("timed_mobile_spawns[%d]: %w", i, err))
+		}
+	}
+	for t := range r.TimeDescs {
+		if err := t.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("time_descriptions: %w", err))
+		}
+	}
+	if err := validateTriggers(r.Triggers, TriggerOwnerRoom); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errors.Join(errs...)
 }
 
 // Resolve resolves foreign key references on the room definition.
-func (r *Room) Resolve(zones storage.Storer[*Zone], rooms storage.Storer[*Room], mobiles storage.Storer[*Mobile], objects storage.Storer[*Object]) error {
+func (r *Room) Resolve(zones storage.Storer[*Zone], rooms storage.Storer[*Room], mobiles storage.Storer[*Mobile], objects storage.Storer[*Object], loot storage.Storer[*LootTable]) error {
 	var errs []error
 	errs = append(errs, r.Zone.Resolve(zones))
 	for dir, exit := range r.Exits {
@@ -130,10 +292,15 @@ func (r *Room) Resolve(zones storage.Storer[*Zone], rooms storage.Storer[*Room],
 	}
 
 	for i := range r.MobSpawns {
-		errs = append(errs, r.MobSpawns[i].Resolve(mobiles))
+		errs = append(errs, r.MobSpawns[i].Mobile.Resolve(mobiles))
+	}
+	for i := range r.TimedSpawns {
+		errs = append(errs, r.TimedSpawns[i].Mobile.Resolve(mobiles))
 	}
 	for i := range r.ObjSpawns {
-		errs = append(errs, r.ObjSpawns[i].Resolve(objects))
+		errs = append(errs, r.ObjSpawns[i].Resolve(objects, loot))
 	}
+	errs = append(errs, resolveLootRolls(r.Loot, loot))
+	errs = append(errs, resolveTriggers(r.Triggers, objects))
 	return errors.Join(errs...)
 }
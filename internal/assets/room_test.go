@@ -55,3 +55,51 @@ func TestRoom_Validate_ExtraDescs(t *testing.T) {
 		})
 	}
 }
+
+func TestSector(t *testing.T) {
+	tests := map[string]struct {
+		sector   Sector
+		wantCost int
+		wantErr  bool
+	}{
+		"empty is inside": {sector: "", wantCost: 1},
+		"mountain":        {sector: SectorMountain, wantCost: 6},
+		"unknown":         {sector: "lava", wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.sector.Validate()
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := tc.sector.MoveCost(); got != tc.wantCost {
+				t.Errorf("MoveCost() = %d, want %d", got, tc.wantCost)
+			}
+		})
+	}
+}
+
+func TestTimedMobSpawn_Validate(t *testing.T) {
+	tests := map[string]struct {
+		times   []TimeOfDay
+		wantErr bool
+	}{
+		"night only":   {times: []TimeOfDay{TimeNight}},
+		"no times":     {wantErr: true},
+		"unknown time": {times: []TimeOfDay{"teatime"}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := TimedMobSpawn{Mobile: storage.NewSmartIdentifier[*Mobile]("ghoul"), Times: tc.times}
+			if err := s.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
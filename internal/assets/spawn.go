@@ -0,0 +1,124 @@
+package assets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Spawn max scopes: where live instances are counted against a spawn's max.
+const (
+	SpawnScopeWorld = "world"
+	SpawnScopeZone  = "zone"
+)
+
+// SpawnRule limits when a room spawn fires. The zero rule always spawns.
+type SpawnRule struct {
+	// Max skips the spawn while this many instances of the definition are
+	// alive, counted across MaxScope. Zero means no limit.
+	Max int `json:"max,omitempty"`
+	// MaxScope is "world" (default) or "zone".
+	MaxScope string `json:"max_scope,omitempty"`
+	// Chance is the percent chance the spawn fires on a reset. Zero means
+	// it always does.
+	Chance int `json:"chance,omitempty"`
+	// IfAbsent skips the spawn while the room already holds an instance of
+	// the definition.
+	IfAbsent bool `json:"if_absent,omitempty"`
+}
+
+// Validate returns an error if the rule is out of range.
+func (r *SpawnRule) Validate() error {
+	var errs []error
+	if r.Max < 0 {
+		errs = append(errs, errors.New("max must not be negative"))
+	}
+	switch r.MaxScope {
+	case "", SpawnScopeWorld, SpawnScopeZone:
+	default:
+		errs = append(errs, fmt.Errorf("invalid max_scope: %s (must be %s or %s)", r.MaxScope, SpawnScopeWorld, SpawnScopeZone))
+	}
+	if r.Chance < 0 || r.Chance > 100 {
+		errs = append(errs, errors.New("chance must be between 0 and 100"))
+	}
+	return errors.Join(errs...)
+}
+
+// MobSpawn is an entry in a room's mobile spawns. In JSON it is either a
+// bare mobile ID or an object with the ID and spawn rule.
+type MobSpawn struct {
+	Mobile storage.SmartIdentifier[*Mobile] `json:"mobile_id"`
+	SpawnRule
+
+	// Respawn brings a killed mob back this long after it died (e.g. "10m"),
+	// without waiting for the zone to reset.
+	Respawn string `json:"respawn,omitempty"`
+}
+
+// UnmarshalJSON accepts a bare mobile ID as well as the full object.
+func (s *MobSpawn) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		*s = MobSpawn{}
+		return json.Unmarshal(data, &s.Mobile)
+	}
+	type plain MobSpawn
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Validate returns an error if the spawn rule or respawn timer is invalid.
+func (s *MobSpawn) Validate() error {
+	errs := []error{s.SpawnRule.Validate()}
+	if s.Respawn != "" {
+		if d, err := time.ParseDuration(s.Respawn); err != nil {
+			errs = append(errs, fmt.Errorf("invalid respawn %q: %w", s.Respawn, err))
+		} else if d <= 0 {
+			errs = append(errs, errors.New("respawn must be positive"))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("mobile %q: %w", s.Mobile.Id(), err)
+	}
+	return nil
+}
+
+// RespawnAfter returns how long after dying the mob returns, or zero if it
+// waits for the zone reset.
+func (s *MobSpawn) RespawnAfter() time.Duration {
+	d, _ := time.ParseDuration(s.Respawn)
+	return d
+}
+
+// RoomObjectSpawn is an entry in a room's object spawns: the object, with
+// its contents, and the spawn rule.
+type RoomObjectSpawn struct {
+	ObjectSpawn
+	SpawnRule
+
+	// Loot tables are rolled into the object, which must be a container,
+	// alongside its fixed contents.
+	Loot []LootRoll `json:"loot,omitempty"`
+}
+
+// Validate returns an error if the spawn rule or loot is invalid.
+func (s *RoomObjectSpawn) Validate() error {
+	if err := errors.Join(s.SpawnRule.Validate(), validateLootRolls(s.Loot)); err != nil {
+		return fmt.Errorf("object %q: %w", s.Object.Id(), err)
+	}
+	return nil
+}
+
+// Resolve resolves the object, its contents and its loot tables, and
+// checks that loot only goes into a container.
+func (s *RoomObjectSpawn) Resolve(objs storage.Storer[*Object], loot storage.Storer[*LootTable]) error {
+	if err := errors.Join(s.ObjectSpawn.Resolve(objs), resolveLootRolls(s.Loot, loot)); err != nil {
+		return err
+	}
+	if len(s.Loot) > 0 && !s.Object.Get().HasFlag(ObjectFlagContainer) {
+		return fmt.Errorf("object %q: loot requires a container", s.Object.Id())
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package assets
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestMobSpawn_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		json    string
+		want    MobSpawn
+		wantErr bool
+	}{
+		"bare id": {
+			json: `"millbrook-guard"`,
+			want: MobSpawn{Mobile: storage.NewSmartIdentifier[*Mobile]("millbrook-guard")},
+		},
+		"full object": {
+			json: `{"mobile_id": "darkwood-wolf", "max": 3, "max_scope": "zone", "chance": 50, "if_absent": true, "respawn": "5m"}`,
+			want: MobSpawn{
+				Mobile:    storage.NewSmartIdentifier[*Mobile]("darkwood-wolf"),
+				SpawnRule: SpawnRule{Max: 3, MaxScope: SpawnScopeZone, Chance: 50, IfAbsent: true},
+				Respawn:   "5m",
+			},
+		},
+		"not a spawn": {
+			json:    `42`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got MobSpawn
+			err := json.Unmarshal([]byte(tc.json), &got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.Mobile.Id() != tc.want.Mobile.Id() || got.SpawnRule != tc.want.SpawnRule || got.Respawn != tc.want.Respawn {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMobSpawn_Validate(t *testing.T) {
+	tests := map[string]struct {
+		spawn       MobSpawn
+		wantErr     bool
+		wantRespawn time.Duration
+	}{
+		"plain":            {},
+		"full rule":        {spawn: MobSpawn{SpawnRule: SpawnRule{Max: 1, MaxScope: SpawnScopeWorld, Chance: 100}, Respawn: "10m"}, wantRespawn: 10 * time.Minute},
+		"negative max":     {spawn: MobSpawn{SpawnRule: SpawnRule{Max: -1}}, wantErr: true},
+		"unknown scope":    {spawn: MobSpawn{SpawnRule: SpawnRule{Max: 1, MaxScope: "room"}}, wantErr: true},
+		"chance over 100":  {spawn: MobSpawn{SpawnRule: SpawnRule{Chance: 101}}, wantErr: true},
+		"bad respawn":      {spawn: MobSpawn{Respawn: "soon"}, wantErr: true},
+		"negative respawn": {spawn: MobSpawn{Respawn: "-1m"}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.spawn.Mobile = storage.NewSmartIdentifier[*Mobile]("wolf")
+			if err := tc.spawn.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr {
+				if got := tc.spawn.RespawnAfter(); got != tc.wantRespawn {
+					t.Errorf("RespawnAfter() = %v, want %v", got, tc.wantRespawn)
+				}
+			}
+		})
+	}
+}
+
+// mapStore is an in-memory Storer for resolving test assets.
+type mapStore[T storage.ValidatingSpec] map[string]T
+
+func (s mapStore[T]) Save(id string, v T) error { s[id] = v; return nil }
+func (s mapStore[T]) Get(id string) T           { return s[id] }
+func (s mapStore[T]) GetAll() map[string]T      { return s }
+
+func TestRoomObjectSpawn_Resolve(t *testing.T) {
+	tests := map[string]struct {
+		object  string
+		loot    bool
+		noTable bool
+		wantErr bool
+	}{
+		"loot in a chest":    {object: "chest", loot: true},
+		"plain rock":         {object: "rock"},
+		"loot in a rock":     {object: "rock", loot: true, wantErr: true},
+		"missing loot table": {object: "chest", loot: true, noTable: true, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs := mapStore[*Object]{
+				"chest": {Aliases: []string{"chest"}, ShortDesc: "a chest", Flags: []string{"container"}},
+				"rock":  {Aliases: []string{"rock"}, ShortDesc: "a rock"},
+			}
+			tables := mapStore[*LootTable]{"coins": {}}
+			if tc.noTable {
+				tables = mapStore[*LootTable]{}
+			}
+			s := RoomObjectSpawn{ObjectSpawn: ObjectSpawn{Object: storage.NewSmartIdentifier[*Object](tc.object)}}
+			if tc.loot {
+				s.Loot = []LootRoll{{Table: storage.NewSmartIdentifier[*LootTable]("coins")}}
+			}
+
+			if err := s.Resolve(objs, tables); (err != nil) != tc.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package assets
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// TimeOfDay is a phase of the in-game day, used to condition room
+// descriptions and mob spawns on the world calendar.
+type TimeOfDay string
+
+const (
+	TimeDawn  TimeOfDay = "dawn"
+	TimeDay   TimeOfDay = "day"
+	TimeDusk  TimeOfDay = "dusk"
+	TimeNight TimeOfDay = "night"
+)
+
+// Validate returns an error if the phase is not a known time of day.
+func (t TimeOfDay) Validate() error {
+	switch t {
+	case TimeDawn, TimeDay, TimeDusk, TimeNight:
+		return nil
+	default:
+		return fmt.Errorf("unknown time of day %q", t)
+	}
+}
+
+// TimedMobSpawn is a mobile that only spawns on zone reset during the given
+// times of day.
+type TimedMobSpawn struct {
+	Mobile storage.SmartIdentifier[*Mobile] `json:"mobile_id"`
+	Times  []TimeOfDay                      `json:"times"`
+}
+
+// Validate returns an error if the spawn has no times or an unknown one.
+func (s *TimedMobSpawn) Validate() error {
+	if len(s.Times) == 0 {
+		return fmt.Errorf("mobile %q: at least one time is required", s.Mobile.Id())
+	}
+	for _, t := range s.Times {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("mobile %q: %w", s.Mobile.Id(), err)
+		}
+	}
+	return nil
+}
+
+// SpawnsAt reports whether the spawn is active at the given time of day.
+func (s *TimedMobSpawn) SpawnsAt(t TimeOfDay) bool {
+	return slices.Contains(s.Times, t)
+}
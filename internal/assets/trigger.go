@@ -0,0 +1,290 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"text/template"
+
+	"github.com/pixil98/go-mud/internal/scripting"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// TriggerType identifies the event a trigger fires on.
+type TriggerType string
+
+// TriggerType values.
+const (
+	TriggerGreet   TriggerType = "greet"   // a player walks into the mob's room
+	TriggerEnter   TriggerType = "enter"   // anyone walks into the room
+	TriggerSpeech  TriggerType = "speech"  // a player says something matching Pattern
+	TriggerReceive TriggerType = "receive" // a player gives the mob an item
+	TriggerFight   TriggerType = "fight"   // the mob is drawn into combat
+	TriggerHP      TriggerType = "hp"      // the mob's HP falls to Percent or below
+	TriggerDeath   TriggerType = "death"   // the mob dies
+	TriggerRandom  TriggerType = "random"  // Chance percent each tick while players are near
+	TriggerCommand TriggerType = "command" // a player types a command matching Pattern
+)
+
+// Trigger owners, which decide the trigger types and steps allowed.
+const (
+	TriggerOwnerMobile = "mobile"
+	TriggerOwnerRoom   = "room"
+	TriggerOwnerObject = "object"
+)
+
+// triggerTypes lists the trigger types each owner supports.
+var triggerTypes = map[string][]TriggerType{
+	TriggerOwnerMobile: {TriggerGreet, TriggerSpeech, TriggerReceive, TriggerFight, TriggerHP, TriggerDeath, TriggerRandom, TriggerCommand},
+	TriggerOwnerRoom:   {TriggerEnter, TriggerSpeech, TriggerRandom, TriggerCommand},
+	TriggerOwnerObject: {TriggerCommand},
+}
+
+// ScriptFuncs names the functions scripts can call from templates, beyond
+// text/template's builtins. The game supplies their implementations.
+var ScriptFuncs = []string{"carries", "random"}
+
+// TriggerCodeGlobals names what trigger code can refer to beyond Starlark's
+// builtins. The game supplies their values.
+var TriggerCodeGlobals = []string{"actor", "self", "room", "object", "text", "match", "vars", "random", "allow"}
+
+// Trigger runs a script when an event happens to the mob, room or object it
+// is attached to.
+type Trigger struct {
+	Type TriggerType `json:"type"`
+
+	// Pattern is a case-insensitive regular expression. Speech triggers fire
+	// when it matches anywhere in what was said (empty matches anything);
+	// command triggers fire when it matches the whole command word.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Chance is the percent chance a random trigger fires each tick, or that
+	// any other trigger fires when its event happens. Zero means always,
+	// except for random triggers where it is required.
+	Chance int `json:"chance,omitempty"`
+
+	// Percent is the HP percentage an hp trigger fires at.
+	Percent int `json:"percent,omitempty"`
+
+	// Object limits a receive trigger to this item. Empty accepts any.
+	Object storage.SmartIdentifier[*Object] `json:"object_id"`
+
+	// Script is the steps run when the trigger fires.
+	Script []ScriptStep `json:"script,omitempty"`
+
+	// Code is Starlark run when the trigger fires, in place of Script, for
+	// logic steps can't express.
+	Code string `json:"code,omitempty"`
+
+	re   *regexp.Regexp
+	prog *scripting.Program
+}
+
+// Match reports whether text sets off a speech or command trigger, returning
+// the pattern's submatches (the whole match first).
+func (t *Trigger) Match(text string) ([]string, bool) {
+	re := t.re
+	if re == nil {
+		var err error
+		if re, err = compileTriggerPattern(t.Type, t.Pattern); err != nil {
+			return nil, false
+		}
+	}
+	m := re.FindStringSubmatch(text)
+	return m, m != nil
+}
+
+// Program returns the trigger's compiled Code, or nil if it has none or
+// hasn't been compiled. Triggers are compiled when their owner is validated,
+// so Program never writes and is safe to call from any goroutine.
+func (t *Trigger) Program() *scripting.Program {
+	return t.prog
+}
+
+// Compile compiles the trigger's Code for Program. It must be called before
+// the trigger is shared, which validation does for loaded assets.
+func (t *Trigger) Compile() error {
+	if t.Code == "" {
+		return nil
+	}
+	prog, err := scripting.Compile(string(t.Type), t.Code, TriggerCodeGlobals)
+	if err != nil {
+		return err
+	}
+	t.prog = prog
+	return nil
+}
+
+func compileTriggerPattern(typ TriggerType, pattern string) (*regexp.Regexp, error) {
+	if typ == TriggerCommand {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func (t *Trigger) validate(owner string) error {
+	var errs []error
+	if !slices.Contains(triggerTypes[owner], t.Type) {
+		errs = append(errs, fmt.Errorf("trigger type %q not supported on a %s", t.Type, owner))
+	}
+	switch t.Type {
+	case TriggerSpeech, TriggerCommand:
+		if t.Type == TriggerCommand && t.Pattern == "" {
+			errs = append(errs, errors.New("command triggers need a pattern"))
+		}
+		re, err := compileTriggerPattern(t.Type, t.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern: %w", err))
+		}
+		t.re = re
+	case TriggerHP:
+		if t.Percent < 1 || t.Percent > 99 {
+			errs = append(errs, errors.New("hp triggers need a percent between 1 and 99"))
+		}
+	case TriggerRandom:
+		if t.Chance == 0 {
+			errs = append(errs, errors.New("random triggers need a chance"))
+		}
+	}
+	if t.Object.Id() != "" && t.Type != TriggerReceive {
+		errs = append(errs, errors.New("object_id only applies to receive triggers"))
+	}
+	if t.Chance < 0 || t.Chance > 100 {
+		errs = append(errs, errors.New("chance must be between 0 and 100"))
+	}
+	if (len(t.Script) == 0) == (t.Code == "") {
+		errs = append(errs, errors.New("exactly one of script or code is required"))
+	}
+	if err := validateScript(t.Script, owner); err != nil {
+		errs = append(errs, err)
+	}
+	if err := t.Compile(); err != nil {
+		errs = append(errs, fmt.Errorf("code: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// ScriptStep is one step of a trigger script. Exactly one of the actions is
+// set. Text fields are templates over the trigger's context: .Self, .Actor,
+// .Object, .Text, .Match and .Vars.
+type ScriptStep struct {
+	// Do runs a command as the mob the trigger is attached to.
+	Do string `json:"do,omitempty"`
+	// Force runs a command as the player who set the trigger off.
+	Force string `json:"force,omitempty"`
+	// Echo sends a message to everyone in the room.
+	Echo string `json:"echo,omitempty"`
+	// Send sends a message to the player who set the trigger off.
+	Send string `json:"send,omitempty"`
+
+	// Set stores Value in the named variable, kept on the trigger's owner
+	// and readable as .Vars.<name>.
+	Set   string `json:"set,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	// If runs Then when the condition, a template pipeline such as
+	// `gt .Actor.Level 5`, is true, and Else otherwise.
+	If   string       `json:"if,omitempty"`
+	Then []ScriptStep `json:"then,omitempty"`
+	Else []ScriptStep `json:"else,omitempty"`
+
+	// Allow lets the command that set off a command trigger go ahead once
+	// the script ends, rather than being swallowed.
+	Allow bool `json:"allow,omitempty"`
+	// Stop ends the script.
+	Stop bool `json:"stop,omitempty"`
+}
+
+// ScriptCondition wraps an If pipeline into a template that renders "true"
+// when it holds.
+func ScriptCondition(cond string) string {
+	return "{{ if " + cond + " }}true{{ end }}"
+}
+
+// ParseScriptTemplate parses a script template, with funcs supplying the
+// ScriptFuncs.
+func ParseScriptTemplate(text string, funcs template.FuncMap) (*template.Template, error) {
+	return template.New("script").Option("missingkey=zero").Funcs(funcs).Parse(text)
+}
+
+// scriptFuncStubs stands in for the ScriptFuncs when templates are parsed
+// only to check them.
+func scriptFuncStubs() template.FuncMap {
+	stub := make(template.FuncMap, len(ScriptFuncs))
+	for _, name := range ScriptFuncs {
+		stub[name] = func(...any) any { return nil }
+	}
+	return stub
+}
+
+// validateScript checks each step has one action and that its templates
+// parse.
+func validateScript(steps []ScriptStep, owner string) error {
+	stub := scriptFuncStubs()
+	var errs []error
+	for i := range steps {
+		if err := steps[i].validate(owner, stub); err != nil {
+			errs = append(errs, fmt.Errorf("script[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ScriptStep) validate(owner string, funcs template.FuncMap) error {
+	var errs []error
+	actions := 0
+	for _, set := range []bool{s.Do != "", s.Force != "", s.Echo != "", s.Send != "", s.Set != "", s.If != "", s.Allow, s.Stop} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		errs = append(errs, errors.New("exactly one of do, force, echo, send, set, if, allow or stop is required"))
+	}
+	if s.Do != "" && owner != TriggerOwnerMobile {
+		errs = append(errs, errors.New("do is only available to mobile triggers"))
+	}
+	for _, text := range []string{s.Do, s.Force, s.Echo, s.Send, s.Value} {
+		if _, err := ParseScriptTemplate(text, funcs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.If != "" {
+		if _, err := ParseScriptTemplate(ScriptCondition(s.If), funcs); err != nil {
+			errs = append(errs, fmt.Errorf("if: %w", err))
+		}
+	} else if len(s.Then) > 0 || len(s.Else) > 0 {
+		errs = append(errs, errors.New("then and else need an if"))
+	}
+	if err := validateScript(s.Then, owner); err != nil {
+		errs = append(errs, fmt.Errorf("then: %w", err))
+	}
+	if err := validateScript(s.Else, owner); err != nil {
+		errs = append(errs, fmt.Errorf("else: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// validateTriggers validates each trigger for the given owner, naming it by
+// index.
+func validateTriggers(ts []Trigger, owner string) error {
+	var errs []error
+	for i := range ts {
+		if err := ts[i].validate(owner); err != nil {
+			errs = append(errs, fmt.Errorf("triggers[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveTriggers resolves the items receive triggers wait for.
+func resolveTriggers(ts []Trigger, objs storage.Storer[*Object]) error {
+	var errs []error
+	for i := range ts {
+		if ts[i].Object.Id() != "" {
+			errs = append(errs, ts[i].Object.Resolve(objs))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+package assets
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestTrigger_validate(t *testing.T) {
+	send := []ScriptStep{{Send: "Hello, {{ .Actor.Name }}."}}
+	tests := map[string]struct {
+		trigger Trigger
+		owner   string
+		expErr  bool
+	}{
+		"mobile greet": {
+			trigger: Trigger{Type: TriggerGreet, Script: send},
+			owner:   TriggerOwnerMobile,
+		},
+		"room command": {
+			trigger: Trigger{Type: TriggerCommand, Pattern: "pull|yank", Script: []ScriptStep{{Echo: "Click."}, {Allow: true}}},
+			owner:   TriggerOwnerRoom,
+		},
+		"nested if": {
+			trigger: Trigger{Type: TriggerSpeech, Script: []ScriptStep{{
+				If:   `carries .Actor "pass"`,
+				Then: []ScriptStep{{Do: "nod"}},
+				Else: []ScriptStep{{Set: "seen", Value: "{{ .Actor.Name }}"}, {Stop: true}},
+			}}},
+			owner: TriggerOwnerMobile,
+		},
+		"receive with object": {
+			trigger: Trigger{Type: TriggerReceive, Object: storage.NewSmartIdentifier[*Object]("letter"), Script: send},
+			owner:   TriggerOwnerMobile,
+		},
+		"code instead of a script": {
+			trigger: Trigger{Type: TriggerSpeech, Code: "if vars.get(\"seen\") == None:\n    actor.send(\"Hello.\")\n"},
+			owner:   TriggerOwnerMobile,
+		},
+		"code and script together": {
+			trigger: Trigger{Type: TriggerGreet, Script: send, Code: "actor.send(\"hi\")"},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"code with an unknown name": {
+			trigger: Trigger{Type: TriggerGreet, Code: "player.send(\"hi\")"},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"type not allowed on owner": {
+			trigger: Trigger{Type: TriggerGreet, Script: send},
+			owner:   TriggerOwnerRoom,
+			expErr:  true,
+		},
+		"unknown type": {
+			trigger: Trigger{Type: "sneeze", Script: send},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"command without pattern": {
+			trigger: Trigger{Type: TriggerCommand, Script: send},
+			owner:   TriggerOwnerObject,
+			expErr:  true,
+		},
+		"bad pattern": {
+			trigger: Trigger{Type: TriggerSpeech, Pattern: "(", Script: send},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"hp without percent": {
+			trigger: Trigger{Type: TriggerHP, Script: send},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"random without chance": {
+			trigger: Trigger{Type: TriggerRandom, Script: send},
+			owner:   TriggerOwnerRoom,
+			expErr:  true,
+		},
+		"object on a non-receive trigger": {
+			trigger: Trigger{Type: TriggerGreet, Object: storage.NewSmartIdentifier[*Object]("letter"), Script: send},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"no script": {
+			trigger: Trigger{Type: TriggerGreet},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"step with two actions": {
+			trigger: Trigger{Type: TriggerGreet, Script: []ScriptStep{{Send: "hi", Echo: "hi"}}},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"do outside a mobile": {
+			trigger: Trigger{Type: TriggerEnter, Script: []ScriptStep{{Do: "wave"}}},
+			owner:   TriggerOwnerRoom,
+			expErr:  true,
+		},
+		"bad template": {
+			trigger: Trigger{Type: TriggerGreet, Script: []ScriptStep{{Send: "{{ .Actor.Name "}}},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"unknown function in if": {
+			trigger: Trigger{Type: TriggerGreet, Script: []ScriptStep{{If: "wields .Actor", Then: send}}},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+		"then without if": {
+			trigger: Trigger{Type: TriggerGreet, Script: []ScriptStep{{Send: "hi", Then: send}}},
+			owner:   TriggerOwnerMobile,
+			expErr:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.trigger.validate(tc.owner); (err != nil) != tc.expErr {
+				t.Errorf("validate() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestTrigger_Match(t *testing.T) {
+	tests := map[string]struct {
+		trigger Trigger
+		text    string
+		want    []string
+	}{
+		"speech matches anywhere": {
+			trigger: Trigger{Type: TriggerSpeech, Pattern: `password is (\w+)`},
+			text:    "I think the PASSWORD is swordfish",
+			want:    []string{"PASSWORD is swordfish", "swordfish"},
+		},
+		"empty speech pattern matches anything": {
+			trigger: Trigger{Type: TriggerSpeech},
+			text:    "hello",
+			want:    []string{""},
+		},
+		"command matches the whole word": {
+			trigger: Trigger{Type: TriggerCommand, Pattern: "pull|yank"},
+			text:    "Yank",
+			want:    []string{"Yank"},
+		},
+		"command ignores longer words": {
+			trigger: Trigger{Type: TriggerCommand, Pattern: "pull|yank"},
+			text:    "pullover",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := tc.trigger.Match(tc.text)
+			if ok != (tc.want != nil) || !slices.Equal(got, tc.want) {
+				t.Errorf("Match(%q) = %q, %v, want %q", tc.text, got, ok, tc.want)
+			}
+		})
+	}
+}
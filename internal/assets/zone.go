@@ -13,11 +13,25 @@ const (
 	ZoneResetEmpty    = "empty"    // Zone resets when lifespan is reached and is empty
 )
 
+// Zone stray policies: what a reset does with the zone's mobs that have
+// wandered off into other zones.
+const (
+	ZoneStraysDespawn = "despawn" // Strays vanish and fresh copies spawn at home
+	ZoneStraysRecall  = "recall"  // Strays return home in place of fresh copies
+)
+
 // Zone represents a region in the game world that contains rooms.
 type Zone struct {
 	Lifespan  string `json:"lifespan"` // duration string (e.g., "1m", "30s", "2h")
 	ResetMode string `json:"reset_mode"`
 	Perks     []Perk `json:"perks,omitempty"`
+
+	// Strays is the stray policy applied on reset; defaults to despawn.
+	Strays string `json:"strays,omitempty"`
+
+	// MobBehavior sets the wander, scavenge and aggro chances of mobs that
+	// spawn in this zone, unless a mob sets its own.
+	MobBehavior MobBehavior `json:"mob_behavior,omitempty"`
 }
 
 // Validate satisfies storage.ValidatingSpec.
@@ -50,5 +64,16 @@ func (z *Zone) Validate() error {
 		}
 	}
 
+	switch z.Strays {
+	case "", ZoneStraysDespawn, ZoneStraysRecall:
+	default:
+		errs = append(errs, fmt.Errorf("invalid strays: %s (must be %s or %s)",
+			z.Strays, ZoneStraysDespawn, ZoneStraysRecall))
+	}
+
+	if err := z.MobBehavior.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("mob_behavior: %w", err))
+	}
+
 	return errors.Join(errs...)
 }
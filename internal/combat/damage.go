@@ -21,6 +21,11 @@ func RollAttack(attackMod int) int {
 	return rand.IntN(20) + 1 + attackMod
 }
 
+// RollCheck rolls a d20 and adds the bonus for a non-combat skill check.
+func RollCheck(bonus int) int {
+	return rand.IntN(20) + 1 + bonus
+}
+
 // CalcDamage applies all perk-based damage modifiers to a raw damage value for a
 // single damage type and returns the final damage dealt and any reflected damage.
 //
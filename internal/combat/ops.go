@@ -8,6 +8,8 @@ import (
 
 // StartCombat registers mutual threat between attacker and target.
 // Idempotent: re-entering after flee preserves existing threat entries.
+// An attack from another room only puts the target on guard; the attacker
+// isn't pinned in a fight it can't reach.
 func StartCombat(attacker, target game.Actor) error {
 	if !attacker.IsAlive() {
 		return fmt.Errorf("%s is not alive", attacker.Name())
@@ -15,7 +17,9 @@ func StartCombat(attacker, target game.Actor) error {
 	if !target.IsAlive() {
 		return fmt.Errorf("%s is not alive", target.Name())
 	}
-	attacker.EnsureThreat(target.Id(), target)
+	if attacker.Room() == target.Room() {
+		attacker.EnsureThreat(target.Id(), target)
+	}
 	target.EnsureThreat(attacker.Id(), attacker)
 	return nil
 }
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -56,13 +57,16 @@ type buffScope int
 
 const (
 	buffScopeActor buffScope = iota
+	buffScopeSelf
 	buffScopeRoom
 	buffScopeZone
 	buffScopeWorld
 )
 
 // buffEffect applies timed perks to a target determined by scope: a specific
-// actor (or self), the caster's room, zone, or the entire world.
+// actor (or self), the caster whatever the ability targets, the caster's
+// room, zone, or the entire world. Actor buffs skip anyone the caster may not
+// interfere with; see game.CheckDuel.
 type buffEffect struct {
 	scope buffScope
 }
@@ -115,7 +119,7 @@ func (e *buffEffect) Create(id string, config map[string]string, targets []asset
 
 	grantKey := config["grant_key"]
 
-	return func(actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
 		p := perks
 		if grantKey != "" {
 			var err error
@@ -131,11 +135,19 @@ func (e *buffEffect) Create(id string, config map[string]string, targets []asset
 		case buffScopeActor:
 			for _, spec := range targets {
 				for _, ref := range resolved[spec.Name] {
-					if ref.Actor != nil {
-						ref.Actor.Actor().AddTimedPerks(name, p, dur)
+					if ref.Actor == nil {
+						continue
 					}
+					target := ref.Actor.Actor()
+					if game.CheckDuel(actor, target) != nil {
+						// Outsiders can't buff or debuff either side of a duel.
+						continue
+					}
+					target.AddTimedPerks(name, p, dur)
 				}
 			}
+		case buffScopeSelf:
+			actor.AddTimedPerks(name, p, dur)
 		case buffScopeRoom:
 			actor.Room().Perks.AddTimedPerks(name, p, dur)
 		case buffScopeZone:
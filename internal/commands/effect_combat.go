@@ -1,23 +1,32 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"strings"
 
 	"github.com/pixil98/go-mud/internal/assets"
 	"github.com/pixil98/go-mud/internal/combat"
+	"github.com/pixil98/go-mud/internal/display"
 	"github.com/pixil98/go-mud/internal/game"
 )
 
 // attackEffect reads the actor's attack grants and performs one attack roll per
 // grant. Each hit delegates to dealDamage for damage application and threat.
-type attackEffect struct{}
+// A guard may step in to take the attacks, and a target in the back rank
+// takes less damage. Each hit wears down one of the target's equipped items.
+// When a guard steps in, the target is only told so; the guard gets the hit
+// lines.
+type attackEffect struct {
+	randIntN func(int) int // picks guards and worn items; nil uses rand.IntN
+}
 
 func (e *attackEffect) Spec() *HandlerSpec {
 	return &HandlerSpec{
 		Targets: []TargetRequirement{
-			{Name: "target", Type: targetTypeMobile, Required: true},
+			{Name: "target", Type: targetTypeMobile | targetTypePlayer, Required: true},
 		},
 	}
 }
@@ -25,21 +34,36 @@ func (e *attackEffect) Spec() *HandlerSpec {
 func (e *attackEffect) ValidateConfig(_ map[string]string) error { return nil }
 
 func (e *attackEffect) Create(_ string, _ map[string]string, targets []assets.TargetSpec) EffectFunc {
-	return func(actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
-		if actor.Room().Restricts(actor, assets.RoomFlagPeaceful) {
-			return errPeacefulArea
-		}
+	randIntN := e.randIntN
+	if randIntN == nil {
+		randIntN = rand.IntN
+	}
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
 		for _, spec := range targets {
 			for _, ref := range resolved[spec.Name] {
 				if ref.Actor == nil {
 					continue
 				}
 				target := ref.Actor.Actor()
-				if err := combat.StartCombat(actor, target); err != nil {
-					return NewUserError(err.Error())
+				if err := checkAttack(actor, target); err != nil {
+					return err
 				}
 				actorName := actor.Name()
 				targetName := ref.Actor.Name
+				hitLines := &result.TargetLines
+				if guard := game.Interceptor(actor, target, randIntN); guard != nil {
+					guardName := display.Capitalize(guard.Name())
+					result.ActorLines = append(result.ActorLines, fmt.Sprintf("%s steps in front of %s!", guardName, targetName))
+					result.TargetLines = append(result.TargetLines, fmt.Sprintf("%s steps in front of you!", guardName))
+					result.InterceptorLines = append(result.InterceptorLines, fmt.Sprintf("You step in front of %s!", targetName))
+					result.RoomLines = append(result.RoomLines, fmt.Sprintf("%s steps in front of %s!", guardName, targetName))
+					result.Interceptor = guard
+					target, targetName = guard, guard.Name()
+					hitLines = &result.InterceptorLines
+				}
+				if err := combat.StartCombat(actor, target); err != nil {
+					return NewUserError(err.Error())
+				}
 				attackArgs := actor.GrantArgs(assets.PerkGrantAttack)
 				if len(attackArgs) == 0 {
 					attackArgs = []string{"1d4"}
@@ -55,11 +79,18 @@ func (e *attackEffect) Create(_ string, _ map[string]string, targets []assets.Ta
 						if err != nil {
 							dice = combat.DiceRoll{Count: 1, Sides: 4}
 						}
-						damage = dealDamage(actor, target, dice.Roll(), dmgType)
+						damage = dealDamage(actor, target, game.BackRankDamage(target, dice.Roll()), dmgType)
 					}
 					result.ActorLines = append(result.ActorLines, combat.HitMsgActor(targetName, damage))
-					result.TargetLines = append(result.TargetLines, combat.HitMsgTarget(actorName, damage))
+					*hitLines = append(*hitLines, combat.HitMsgTarget(actorName, damage))
 					result.RoomLines = append(result.RoomLines, combat.HitMsgRoom(actorName, targetName, damage))
+					if damage > 0 {
+						if eq := target.Equipment(); eq != nil {
+							if broke := eq.Wear(1, randIntN); broke != nil {
+								*hitLines = append(*hitLines, fmt.Sprintf("%s breaks!", display.Capitalize(broke.ShortDesc())))
+							}
+						}
+					}
 				}
 			}
 		}
@@ -105,16 +136,15 @@ func (e *damageEffect) Create(_ string, config map[string]string, targets []asse
 		primaryType = damageTypes[0]
 	}
 
-	return func(actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
-		if actor.Room().Restricts(actor, assets.RoomFlagPeaceful) {
-			return errPeacefulArea
-		}
-
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
 		for _, spec := range targets {
 			for _, ref := range resolved[spec.Name] {
 				if ref.Actor == nil {
 					continue
 				}
+				if err := checkAttack(actor, ref.Actor.Actor()); err != nil {
+					return err
+				}
 				dealDamage(actor, ref.Actor.Actor(), dice.Roll(), primaryType)
 			}
 		}
@@ -122,6 +152,53 @@ func (e *damageEffect) Create(_ string, config map[string]string, targets []asse
 	}
 }
 
+// inPeacefulArea reports whether target stands in a peaceful room, which
+// matters when it is struck from another room.
+func inPeacefulArea(actor, target game.Actor) bool {
+	room := target.Room()
+	return room != nil && room.Restricts(actor, assets.RoomFlagPeaceful)
+}
+
+// checkAttack returns a user error if actor may not attack target: from or
+// into a peaceful room (unless they are dueling there), into someone else's
+// duel, or against the PvP rules.
+func checkAttack(actor, target game.Actor) error {
+	peaceful := actor.Room().Restricts(actor, assets.RoomFlagPeaceful) || inPeacefulArea(actor, target)
+	if peaceful && !game.DuelIgnoresPeace(actor, target) {
+		return errPeacefulArea
+	}
+	if err := checkDuel(actor, target); err != nil {
+		return err
+	}
+	return checkPvP(actor, target)
+}
+
+// checkDuel applies the rules on duels, returning a user error if an attack
+// by actor on target would break into one.
+func checkDuel(actor, target game.Actor) error {
+	err := game.CheckDuel(actor, target)
+	switch {
+	case errors.Is(err, game.ErrDueling):
+		return NewUserError("You're in the middle of a duel!")
+	case errors.Is(err, game.ErrDuelInterference):
+		return NewUserError(fmt.Sprintf("%s is in the middle of a duel.", display.Capitalize(target.Name())))
+	}
+	return err
+}
+
+// checkPvP applies the rules on fights between players, returning a user
+// error if actor may not attack target.
+func checkPvP(actor, target game.Actor) error {
+	err := game.CheckPvP(actor, target)
+	switch {
+	case errors.Is(err, game.ErrPvPOff):
+		return NewUserError("You must turn on PvP to attack other players outside an arena.")
+	case errors.Is(err, game.ErrPvPProtected):
+		return NewUserError(fmt.Sprintf("%s can't be attacked outside an arena.", display.Capitalize(target.Name())))
+	}
+	return err
+}
+
 // dealDamage applies raw damage of the given type to a target, handling CalcDamage,
 // reflected damage, combat initiation, and threat. Returns the final damage dealt.
 func dealDamage(actor, target game.Actor, raw int, dmgType string) int {
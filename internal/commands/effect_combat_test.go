@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -38,7 +39,7 @@ func TestAttackEffect(t *testing.T) {
 			targetSpecs := []assets.TargetSpec{{Name: "target"}}
 
 			fn := effect.Create("test:0", nil, targetSpecs)
-			err := fn(player, tc.targets, &AbilityResult{})
+			err := fn(context.Background(), player, tc.targets, &AbilityResult{})
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -56,6 +57,69 @@ func TestAttackEffect(t *testing.T) {
 	}
 }
 
+func TestAttackEffect_Guard(t *testing.T) {
+	tests := map[string]struct {
+		guarded             bool
+		expInterceptor      bool
+		expTargetLines      int
+		expInterceptorLines int
+	}{
+		"guard steps in: ward only told": {
+			guarded:             true,
+			expInterceptor:      true,
+			expTargetLines:      1,
+			expInterceptorLines: 2,
+		},
+		"no guard: ward takes the hit": {
+			expTargetLines: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			ward := newTestPlayer("bob", "Bob", room)
+			guard := newTestPlayer("alice", "Alice", room)
+			setCombatReady(ward)
+			setCombatReady(guard)
+			ward.SetFollowing(guard)
+			guard.AddFollower(ward)
+			guard.SetFollowerGrouped(ward.Id(), true)
+			if tc.guarded {
+				ward.AddTimedPerks("guard:alice", []assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantGuardedBy, Arg: guard.Id()}}, 3)
+			}
+			wolf := newCombatMob("wolf", "a wolf")
+			room.AddMob(wolf)
+
+			effect := &attackEffect{randIntN: func(int) int { return 0 }}
+			fn := effect.Create("test:0", nil, []assets.TargetSpec{{Name: "target"}})
+			targets := map[string][]*TargetRef{
+				"target": {{Type: targetTypeActor, Actor: actorRefFromPlayer(ward)}},
+			}
+			result := &AbilityResult{}
+			if err := fn(context.Background(), wolf, targets, result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := result.Interceptor != nil; got != tc.expInterceptor {
+				t.Fatalf("interceptor set = %v, want %v", got, tc.expInterceptor)
+			}
+			if tc.expInterceptor && result.Interceptor.Id() != guard.Id() {
+				t.Errorf("interceptor = %q, want %q", result.Interceptor.Id(), guard.Id())
+			}
+			if len(result.TargetLines) != tc.expTargetLines {
+				t.Errorf("target lines = %q, want %d", result.TargetLines, tc.expTargetLines)
+			}
+			if tc.expInterceptor && result.TargetLines[0] != "Alice steps in front of you!" {
+				t.Errorf("target line = %q, want the guard stepping in", result.TargetLines[0])
+			}
+			if len(result.InterceptorLines) != tc.expInterceptorLines {
+				t.Errorf("interceptor lines = %q, want %d", result.InterceptorLines, tc.expInterceptorLines)
+			}
+		})
+	}
+}
+
 func TestAttackEffect_PeacefulArea(t *testing.T) {
 	room, _ := newTestRoomInZone("r", "Room", "z")
 	player := newTestPlayer("player", "Player", room)
@@ -75,7 +139,7 @@ func TestAttackEffect_PeacefulArea(t *testing.T) {
 	}
 
 	fn := effect.Create("test:0", nil, targetSpecs)
-	err := fn(player, targets, &AbilityResult{})
+	err := fn(context.Background(), player, targets, &AbilityResult{})
 	if err == nil {
 		t.Fatal("expected peaceful area error, got nil")
 	}
@@ -92,6 +156,7 @@ func TestDamageEffect_InitiatesCombat(t *testing.T) {
 	mob := &gametest.BaseActor{
 		ActorId:   "mob-1",
 		ActorName: "Goblin",
+		ActorRoom: room,
 		Alive:     true,
 		Resources: map[string][2]int{assets.ResourceHp: {100, 100}},
 	}
@@ -107,7 +172,7 @@ func TestDamageEffect_InitiatesCombat(t *testing.T) {
 	}
 
 	fn := effect.Create("test:0", config, targetSpecs)
-	if err := fn(player, targets, &AbilityResult{}); err != nil {
+	if err := fn(context.Background(), player, targets, &AbilityResult{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -119,3 +184,63 @@ func TestDamageEffect_InitiatesCombat(t *testing.T) {
 		t.Errorf("mob HP should have decreased, got %d", cur)
 	}
 }
+
+func TestAttackEffect_PvP(t *testing.T) {
+	tests := map[string]struct {
+		alicePvP bool
+		bobPvP   bool
+		arena    bool
+		wantErr  string
+	}{
+		"attacker has not agreed": {
+			bobPvP:  true,
+			wantErr: "You must turn on PvP to attack other players outside an arena.",
+		},
+		"target has not agreed": {
+			alicePvP: true,
+			wantErr:  "Bob can't be attacked outside an arena.",
+		},
+		"both agreed": {
+			alicePvP: true,
+			bobPvP:   true,
+		},
+		"arena": {
+			arena: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			if tc.arena {
+				room.Perks.SetOwn([]assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagArena)}})
+			}
+			alice := newTestPlayer("alice", "Alice", room)
+			bob := newTestPlayer("bob", "Bob", room)
+			setCombatReady(alice)
+			setCombatReady(bob)
+			alice.Asset().PvP = tc.alicePvP
+			bob.Asset().PvP = tc.bobPvP
+
+			fn := (&attackEffect{}).Create("test:0", nil, []assets.TargetSpec{{Name: "target"}})
+			err := fn(context.Background(), alice, map[string][]*TargetRef{
+				"target": {{Type: targetTypeActor, Actor: actorRefFromActor(bob)}},
+			}, &AbilityResult{})
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				if bob.IsInCombat() {
+					t.Error("Bob should not be in combat")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bob.HasThreatFrom(alice.Id()) {
+				t.Error("Bob should be fighting Alice")
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// enchantEffect adds a perk to each target object instance, on top of the
+// perks its definition grants. Enchanting the same perk again replaces it
+// rather than stacking. Other copies of the object are unaffected.
+//
+// Config fields:
+//   - "key" (string, required): the modifier key or grant keyword.
+//   - "value" (int string, optional): adds a modifier of this amount; when
+//     empty, the perk is a grant instead.
+//   - "arg" (string, optional): the grant's argument.
+type enchantEffect struct{}
+
+func (e *enchantEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+	}
+}
+
+func (e *enchantEffect) ValidateConfig(config map[string]string) error {
+	if config["key"] == "" {
+		return errors.New("key config required")
+	}
+	if v := config["value"]; v != "" {
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("value must be an integer, got %q", v)
+		}
+	}
+	return nil
+}
+
+func (e *enchantEffect) Create(_ string, config map[string]string, targets []assets.TargetSpec) EffectFunc {
+	perk := assets.Perk{Type: assets.PerkTypeGrant, Key: config["key"], Arg: config["arg"]}
+	if v := config["value"]; v != "" {
+		perk.Type = assets.PerkTypeModifier
+		perk.Value, _ = strconv.Atoi(v)
+	}
+
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		for _, spec := range targets {
+			for _, ref := range resolved[spec.Name] {
+				if ref.Obj == nil {
+					continue
+				}
+				oi := ref.Obj.instance
+				if oi.Broken() {
+					return NewUserError(fmt.Sprintf("%s is broken.", display.Capitalize(ref.Obj.Name)))
+				}
+				oi.AddPerks(perk)
+				result.ActorLines = append(result.ActorLines, fmt.Sprintf("%s glows briefly.", display.Capitalize(ref.Obj.Name)))
+			}
+		}
+		if eq := actor.Equipment(); eq != nil {
+			eq.RefreshPerks()
+		}
+		return nil
+	}
+}
+
+// repairEffect restores each target object instance to full durability.
+type repairEffect struct{}
+
+func (e *repairEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+	}
+}
+
+func (e *repairEffect) ValidateConfig(_ map[string]string) error { return nil }
+
+func (e *repairEffect) Create(_ string, _ map[string]string, targets []assets.TargetSpec) EffectFunc {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		for _, spec := range targets {
+			for _, ref := range resolved[spec.Name] {
+				if ref.Obj == nil {
+					continue
+				}
+				oi := ref.Obj.instance
+				full := oi.Object.Get().Durability
+				if full == 0 {
+					return NewUserError(fmt.Sprintf("%s can't be repaired.", display.Capitalize(ref.Obj.Name)))
+				}
+				if oi.Durability == full {
+					return NewUserError(fmt.Sprintf("%s doesn't need repairing.", display.Capitalize(ref.Obj.Name)))
+				}
+				oi.Repair()
+				result.ActorLines = append(result.ActorLines, fmt.Sprintf("You repair %s.", ref.Obj.Name))
+				result.RoomLines = append(result.RoomLines, fmt.Sprintf("%s repairs %s.", actor.Name(), ref.Obj.Name))
+			}
+		}
+		if eq := actor.Equipment(); eq != nil {
+			eq.RefreshPerks()
+		}
+		return nil
+	}
+}
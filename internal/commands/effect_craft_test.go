@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestEnchantEffect(t *testing.T) {
+	tests := map[string]struct {
+		config  map[string]string
+		times   int // how many times to enchant; zero means once
+		broken  bool
+		wantErr string
+		wantAC  int
+	}{
+		"adds a modifier": {
+			config: map[string]string{"key": "core.combat.ac.flat", "value": "2"},
+			wantAC: 3,
+		},
+		"enchanting again doesn't stack": {
+			config: map[string]string{"key": "core.combat.ac.flat", "value": "2"},
+			times:  2,
+			wantAC: 3,
+		},
+		"broken item": {
+			config:  map[string]string{"key": "core.combat.ac.flat", "value": "2"},
+			broken:  true,
+			wantErr: "A shield is broken.",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			player, shield := newShieldBearer(t)
+			if tc.broken {
+				shield.Damage(shield.Durability)
+			}
+
+			fn := (&enchantEffect{}).Create("test:0", tc.config, []assets.TargetSpec{{Name: "target"}})
+			var err error
+			for range max(tc.times, 1) {
+				if err = fn(context.Background(), player, shieldTarget(player, shield), &AbilityResult{}); err != nil {
+					break
+				}
+			}
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := player.Equipment().ModifierValue("core.combat.ac.flat"); got != tc.wantAC {
+				t.Errorf("equipment AC = %d, want %d", got, tc.wantAC)
+			}
+		})
+	}
+}
+
+func TestRepairEffect(t *testing.T) {
+	tests := map[string]struct {
+		wear    int
+		wantErr string
+	}{
+		"mends a broken item": {wear: 4},
+		"mends a worn item":   {wear: 1},
+		"nothing to mend":     {wantErr: "A shield doesn't need repairing."},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			player, shield := newShieldBearer(t)
+			shield.Damage(tc.wear)
+			player.Equipment().RefreshPerks()
+
+			fn := (&repairEffect{}).Create("test:0", nil, []assets.TargetSpec{{Name: "target"}})
+			err := fn(context.Background(), player, shieldTarget(player, shield), &AbilityResult{})
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if shield.Durability != 4 {
+				t.Errorf("Durability = %d, want 4", shield.Durability)
+			}
+			if got := player.Equipment().ModifierValue("core.combat.ac.flat"); got != 1 {
+				t.Errorf("equipment AC = %d, want 1", got)
+			}
+		})
+	}
+}
+
+// newShieldBearer returns a player wearing a shield with 4 durability that
+// grants 1 AC.
+func newShieldBearer(t *testing.T) (*game.CharacterInstance, *game.ObjectInstance) {
+	t.Helper()
+	room, _ := newTestRoomInZone("r", "Room", "z")
+	player := newTestPlayer("smith", "Smith", room)
+	player.SetOwn([]assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantWearSlot, Arg: "shield"}})
+	shield, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier("shield", &assets.Object{
+		Aliases: []string{"shield"}, ShortDesc: "a shield",
+		Flags: []string{"wearable"}, WearSlots: []string{"shield"},
+		Durability: 4,
+		Perks:      []assets.Perk{{Type: assets.PerkTypeModifier, Key: "core.combat.ac.flat", Value: 1}},
+	}))
+	if err != nil {
+		t.Fatalf("NewObjectInstance: %v", err)
+	}
+	if err := player.Equip("shield", shield); err != nil {
+		t.Fatalf("Equip: %v", err)
+	}
+	return player, shield
+}
+
+func shieldTarget(player *game.CharacterInstance, shield *game.ObjectInstance) map[string][]*TargetRef {
+	return map[string][]*TargetRef{
+		"target": {{Type: targetTypeObject, Obj: objRefFromInstance(shield, player.Equipment())}},
+	}
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// guardEffect sets the actor to guard each target, a member of their group,
+// for a while. The guard steps in to take some of the attacks aimed at them;
+// see game.Interceptor.
+//
+// Config fields:
+//   - "duration" (int string, required): how many ticks the guard lasts.
+type guardEffect struct{}
+
+func (e *guardEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeMobile | targetTypePlayer, Required: true},
+		},
+	}
+}
+
+func (e *guardEffect) ValidateConfig(config map[string]string) error {
+	dur, err := strconv.Atoi(config["duration"])
+	if err != nil || dur <= 0 {
+		return errors.New("positive duration config required")
+	}
+	return nil
+}
+
+func (e *guardEffect) Create(_ string, config map[string]string, targets []assets.TargetSpec) EffectFunc {
+	dur, _ := strconv.Atoi(config["duration"])
+
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
+		for _, spec := range targets {
+			for _, ref := range resolved[spec.Name] {
+				if ref.Actor == nil {
+					continue
+				}
+				ward := ref.Actor.Actor()
+				if ward.Id() == actor.Id() {
+					return NewUserError("You can't guard yourself.")
+				}
+				if !game.AreAllies(actor, ward) {
+					return NewUserError(fmt.Sprintf("%s isn't in your group.", display.Capitalize(ref.Actor.Name)))
+				}
+				perk := assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantGuardedBy, Arg: actor.Id()}
+				ward.AddTimedPerks("guard:"+actor.Id(), []assets.Perk{perk}, dur)
+			}
+		}
+		return nil
+	}
+}
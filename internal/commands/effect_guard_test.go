@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestGuardEffect(t *testing.T) {
+	tests := map[string]struct {
+		self      bool
+		ungrouped bool
+		wantErr   string
+	}{
+		"guard a group member": {},
+		"self": {
+			self:    true,
+			wantErr: "You can't guard yourself.",
+		},
+		"not in the group": {
+			ungrouped: true,
+			wantErr:   "Bob isn't in your group.",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			alice := newTestPlayer("alice", "Alice", room)
+			bob := newTestPlayer("bob", "Bob", room)
+			if !tc.ungrouped {
+				bob.SetFollowing(alice)
+				alice.AddFollower(bob)
+				alice.SetFollowerGrouped(bob.Id(), true)
+			}
+			ward := bob
+			if tc.self {
+				ward = alice
+			}
+
+			effect := &guardEffect{}
+			fn := effect.Create("test:0", map[string]string{"duration": "3"}, []assets.TargetSpec{{Name: "target"}})
+			err := fn(context.Background(), alice, map[string][]*TargetRef{
+				"target": {{Type: targetTypeActor, Actor: actorRefFromPlayer(ward)}},
+			}, &AbilityResult{})
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := bob.GrantArgs(assets.PerkGrantGuardedBy); !slices.Equal(got, []string{"alice"}) {
+				t.Errorf("guarded_by grants = %v, want [alice]", got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -40,7 +41,7 @@ func (e *healEffect) Create(_ string, config map[string]string, _ []assets.Targe
 	dice, _ := combat.ParseDice(config["amount"])
 	overheal := config["overheal"] == "true"
 
-	return func(actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
 		var occupants []game.Actor
 		if ri := actor.Room(); ri != nil {
 			ri.ForEachActor(func(a game.Actor) { occupants = append(occupants, a) })
@@ -50,6 +51,10 @@ func (e *healEffect) Create(_ string, config map[string]string, _ []assets.Targe
 				continue
 			}
 			target := ref.Actor.Actor()
+			if game.CheckDuel(actor, target) != nil {
+				// Outsiders can't prop up either side of a duel.
+				continue
+			}
 			healAmount := dice.Roll()
 			target.AdjustResource(assets.ResourceHp, healAmount, overheal)
 			combat.NotifyHeal(actor, target, healAmount/2, occupants)
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.starlark.net/starlark"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/scripting"
+)
+
+// scriptEffectGlobals names what script effect code can refer to beyond
+// Starlark's builtins.
+var scriptEffectGlobals = []string{"actor", "targets", "room", "random", "to_actor", "to_target", "to_room", "fail"}
+
+// scriptEffect runs builder-written Starlark code, for abilities the other
+// effects can't express.
+//
+// Config fields:
+//   - "code" (string, required): Starlark run on each use. It sees actor,
+//     targets (the actors resolved as "target"), room and random(n). It adds
+//     lines to the ability's messages with to_actor, to_target and to_room,
+//     and fail(msg) stops it and refuses the ability with msg.
+type scriptEffect struct{}
+
+func (e *scriptEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeActor, Required: false},
+		},
+	}
+}
+
+func (e *scriptEffect) ValidateConfig(config map[string]string) error {
+	if config["code"] == "" {
+		return errors.New("code config required")
+	}
+	_, err := scripting.Compile("script", config["code"], scriptEffectGlobals)
+	return err
+}
+
+func (e *scriptEffect) Create(id string, config map[string]string, _ []assets.TargetSpec) EffectFunc {
+	prog, _ := scripting.Compile(id, config["code"], scriptEffectGlobals)
+
+	return func(ctx context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		ctx, ok := game.EnterScript(ctx)
+		if !ok {
+			slog.Warn("script depth limit reached", "effect", id)
+			return NewUserError("Nothing happens.")
+		}
+		var targets []starlark.Value
+		for _, ref := range resolved["target"] {
+			if ref.Actor != nil {
+				targets = append(targets, game.ScriptActor(ctx, ref.Actor.Actor()))
+			}
+		}
+		room := starlark.Value(starlark.None)
+		if ri := actor.Room(); ri != nil {
+			room = game.ScriptRoom(ctx, ri)
+		}
+
+		var failMsg string
+		failed := errors.New("ability refused")
+		err := prog.Run(ctx, starlark.StringDict{
+			"actor":     game.ScriptActor(ctx, actor),
+			"targets":   starlark.NewList(targets),
+			"room":      room,
+			"random":    game.ScriptRandom,
+			"to_actor":  appendLine("to_actor", &result.ActorLines),
+			"to_target": appendLine("to_target", &result.TargetLines),
+			"to_room":   appendLine("to_room", &result.RoomLines),
+			"fail": starlark.NewBuiltin("fail", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+				if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &failMsg); err != nil {
+					return nil, err
+				}
+				return nil, failed
+			}),
+		})
+		switch {
+		case errors.Is(err, failed):
+			return NewUserError(failMsg)
+		case err != nil:
+			slog.Warn("script effect failed", "effect", id, "error", err)
+		}
+		return nil
+	}
+}
+
+// appendLine returns a Starlark builtin that appends its argument to lines.
+func appendLine(name string, lines *[]string) *starlark.Builtin {
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var line string
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &line); err != nil {
+			return nil, err
+		}
+		*lines = append(*lines, line)
+		return starlark.None, nil
+	})
+}
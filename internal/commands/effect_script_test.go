@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+func TestScriptEffect(t *testing.T) {
+	tests := map[string]struct {
+		code       string
+		target     bool
+		expErr     bool
+		wantActor  []string
+		wantTarget []string
+		wantRoom   []string
+	}{
+		"messages": {
+			code:      "to_actor(\"You hum.\")\nto_room(actor.name + \" hums.\")\n",
+			wantActor: []string{"You hum."},
+			wantRoom:  []string{"Caster hums."},
+		},
+		"targets": {
+			code:       "for t in targets:\n    to_target(actor.name + \" points at \" + t.name + \".\")\n",
+			target:     true,
+			wantTarget: []string{"Caster points at Bob."},
+		},
+		"room queries": {
+			code:      `to_actor(room.name)`,
+			wantActor: []string{"Hall"},
+		},
+		"fail refuses the ability": {
+			code:   "to_actor(\"partial\")\nfail(\"Not here.\")\n",
+			expErr: true,
+			// Lines added before fail stay on the result; the caller discards it.
+			wantActor: []string{"partial"},
+		},
+		"runtime errors are logged, not returned": {
+			code: `to_actor(1 + "x")`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("hall", "Hall", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			caster := newTestPlayer("caster", "Caster", room)
+			targets := map[string][]*TargetRef{}
+			if tc.target {
+				bob := newTestPlayer("bob", "Bob", room)
+				targets["target"] = []*TargetRef{{Type: targetTypeActor, Actor: actorRefFromPlayer(bob)}}
+			}
+			config := map[string]string{"code": tc.code}
+			effect := &scriptEffect{}
+			if err := effect.ValidateConfig(config); err != nil {
+				t.Fatalf("ValidateConfig: %v", err)
+			}
+
+			result := &AbilityResult{}
+			err = effect.Create("script:0", config, nil)(context.Background(), caster, targets, result)
+
+			var ue *UserError
+			if tc.expErr != errors.As(err, &ue) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if !slices.Equal(result.ActorLines, tc.wantActor) {
+				t.Errorf("actor lines = %q, want %q", result.ActorLines, tc.wantActor)
+			}
+			if !slices.Equal(result.TargetLines, tc.wantTarget) {
+				t.Errorf("target lines = %q, want %q", result.TargetLines, tc.wantTarget)
+			}
+			if !slices.Equal(result.RoomLines, tc.wantRoom) {
+				t.Errorf("room lines = %q, want %q", result.RoomLines, tc.wantRoom)
+			}
+		})
+	}
+}
+
+func TestScriptEffect_ValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]string
+		expErr bool
+	}{
+		"code":         {config: map[string]string{"code": `to_actor("hi")`}},
+		"missing":      {config: map[string]string{}, expErr: true},
+		"syntax error": {config: map[string]string{"code": "to_actor("}, expErr: true},
+		"unknown name": {config: map[string]string{"code": `say("hi")`}, expErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := (&scriptEffect{}).ValidateConfig(tc.config)
+			if tc.expErr != (err != nil) {
+				t.Errorf("err = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+// handlerCommander binds a Handler to one actor, as the server does.
+type handlerCommander struct {
+	h     *Handler
+	actor game.Actor
+}
+
+func (c *handlerCommander) ExecCommand(ctx context.Context, cmd string, args ...string) error {
+	return c.h.Exec(ctx, c.actor, cmd, args...)
+}
+
+func (c *handlerCommander) ExecAbility(ctx context.Context, abilityId string, target game.Actor) error {
+	return c.h.ExecAbility(ctx, abilityId, c.actor, target)
+}
+
+func TestScriptEffect_recursionIsBounded(t *testing.T) {
+	h := &Handler{
+		factories: make(map[string]HandlerFactory),
+		compiled:  make(map[string]*compiledCommand),
+		abilities: make(map[string]*compiledAbility),
+		effects:   map[string]EffectHandler{"script": &scriptEffect{}},
+	}
+	err := h.registerAbility("echo", &assets.Ability{
+		Effects: []assets.EffectSpec{{Type: "script", Config: map[string]string{
+			"code": "to_actor(\"Echo!\")\nactor.command(\"echo\")\n",
+		}}},
+		Command: assets.Command{Config: map[string]string{"ap_cost": "1"}},
+	})
+	if err != nil {
+		t.Fatalf("registerAbility: %v", err)
+	}
+	room, err := newTestRoom("hall", "Hall", "town")
+	if err != nil {
+		t.Fatalf("newTestRoom: %v", err)
+	}
+	caster, msgs := newRecordingPlayer("caster", "Caster", room)
+	caster.SetOwn([]assets.Perk{
+		{Type: assets.PerkTypeGrant, Key: assets.PerkGrantUnlockAbility, Arg: "echo"},
+		{Type: assets.PerkTypeModifier, Key: assets.PerkKeyActionPointsMax, Value: 100},
+	})
+	caster.ResetAP()
+	caster.SetCommander(&handlerCommander{h: h, actor: caster})
+
+	if err := h.Exec(context.Background(), caster, "echo"); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	var echoes int
+	for _, m := range drainAll(msgs) {
+		echoes += strings.Count(m, "Echo!")
+	}
+	// One echo per script level until the trigger depth limit (4) stops it.
+	if echoes != 4 {
+		t.Errorf("echoed %d times, want 4", echoes)
+	}
+}
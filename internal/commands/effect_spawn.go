@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -40,7 +41,7 @@ func (e *spawnMobEffect) Create(_ string, config map[string]string, _ []assets.T
 	mobId := config["mobile_id"]
 	followCaster := config["follow_caster"] == "true"
 
-	return func(actor game.Actor, _ map[string][]*TargetRef, _ *AbilityResult) error {
+	return func(_ context.Context, actor game.Actor, _ map[string][]*TargetRef, _ *AbilityResult) error {
 		si := storage.NewSmartIdentifier[*assets.Mobile](mobId)
 		if err := si.Resolve(e.mobiles); err != nil {
 			return fmt.Errorf("spawn_mob: %w", err)
@@ -96,7 +97,7 @@ func (e *spawnObjEffect) Create(_ string, config map[string]string, _ []assets.T
 		dest = SpawnDestRoom
 	}
 
-	return func(actor game.Actor, _ map[string][]*TargetRef, _ *AbilityResult) error {
+	return func(_ context.Context, actor game.Actor, _ map[string][]*TargetRef, _ *AbilityResult) error {
 		si := storage.NewSmartIdentifier[*assets.Object](objId)
 		if err := si.Resolve(e.objects); err != nil {
 			return fmt.Errorf("spawn_obj: %w", err)
@@ -107,15 +108,17 @@ func (e *spawnObjEffect) Create(_ string, config map[string]string, _ []assets.T
 		}
 		oi.ActivateDecay()
 
+		ri := actor.Room()
 		switch dest {
 		case SpawnDestInventory:
 			actor.Inventory().AddObj(oi)
+			ri.EmitObjectEvent(game.ObjectEventSpawn, oi, actor)
 		case SpawnDestRoom:
-			ri := actor.Room()
 			if ri == nil {
 				return nil
 			}
 			ri.AddObj(oi)
+			ri.EmitObjectEvent(game.ObjectEventSpawn, oi, nil)
 		}
 		return nil
 	}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Teleport destinations.
+const (
+	teleportToRoom   = "room"
+	teleportToBind   = "bind"
+	teleportToRandom = "random"
+	teleportToActor  = "actor"
+)
+
+// BindHolder is an actor with a bind point to recall to.
+type BindHolder interface {
+	BindPoint() (zoneId, roomId string)
+}
+
+var _ BindHolder = (*game.CharacterInstance)(nil)
+
+// teleportEffect moves its targets to another room in an instant. Targets
+// that aren't the actor's allies resist with the nosummon grant. Fights
+// don't follow across the gap: a teleported target drops out of combat.
+//
+// Config fields:
+//   - "destination" (required): "room" for the room named by "room",
+//     "bind" for the target's bind point, "random" for a random room of a
+//     zone, or "actor" to summon the target to the actor.
+//   - "room" (required for "room"): the destination room ID.
+//   - "zone" (optional): the zone of "room" or "random"; defaults to the
+//     target's own zone.
+//   - "out_of_combat" (optional): "true" to refuse while the actor fights.
+type teleportEffect struct{}
+
+func (e *teleportEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeActor, Required: true},
+		},
+	}
+}
+
+func (e *teleportEffect) ValidateConfig(config map[string]string) error {
+	switch config["destination"] {
+	case teleportToRoom:
+		if config["room"] == "" {
+			return errors.New("room config required for the room destination")
+		}
+	case teleportToBind, teleportToRandom, teleportToActor:
+	default:
+		return fmt.Errorf("destination must be room, bind, random, or actor (got %q)", config["destination"])
+	}
+	return nil
+}
+
+func (e *teleportEffect) Create(_ string, config map[string]string, _ []assets.TargetSpec) EffectFunc {
+	dest, roomId, zoneId := config["destination"], config["room"], config["zone"]
+	outOfCombat := config["out_of_combat"] == "true"
+
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		if outOfCombat && actor.IsInCombat() {
+			return NewUserError("You can't concentrate enough while fighting!")
+		}
+		for _, ref := range resolved["target"] {
+			if ref.Actor == nil {
+				continue
+			}
+			target := ref.Actor.Actor()
+			from := target.Room()
+			if from == nil {
+				continue
+			}
+			if !game.AreAllies(actor, target) && target.HasGrant(assets.PerkGrantNoSummon, "") {
+				result.ActorLines = append(result.ActorLines, fmt.Sprintf("%s resists your magic.", display.Capitalize(target.Name())))
+				continue
+			}
+
+			to, err := teleportDestination(actor, target, dest, zoneId, roomId)
+			if err != nil {
+				return err
+			}
+			if to == from {
+				continue
+			}
+
+			if d, ok := target.(interface{ Disengage() []game.Actor }); ok {
+				d.Disengage()
+			}
+			relocate(target, from, to,
+				fmt.Sprintf("%s vanishes in a flash of light!", display.Capitalize(target.Name())),
+				fmt.Sprintf("%s appears in a flash of light!", display.Capitalize(target.Name())))
+
+			desc := DescribeRoom(target, to)
+			if target.Id() == actor.Id() {
+				result.ActorLines = append(result.ActorLines, desc)
+			} else {
+				target.Publish([]byte(desc), nil)
+			}
+			// Effects run without a context; arrival scripts start afresh.
+			game.FireArrival(context.Background(), target, to)
+		}
+		return nil
+	}
+}
+
+// teleportDestination finds the room a teleport sends target to.
+func teleportDestination(actor, target game.Actor, dest, zoneId, roomId string) (*game.RoomInstance, error) {
+	from := target.Room()
+	world := from.Zone().World()
+	if zoneId == "" {
+		zoneId = from.Zone().Zone.Id()
+	}
+
+	var to *game.RoomInstance
+	switch dest {
+	case teleportToRoom:
+		to = world.GetRoom(zoneId, roomId)
+	case teleportToBind:
+		bh, ok := target.(BindHolder)
+		if !ok {
+			return nil, NewUserError(fmt.Sprintf("%s has nowhere to return to.", display.Capitalize(target.Name())))
+		}
+		bindZone, bindRoom := bh.BindPoint()
+		to = world.GetRoom(bindZone, bindRoom)
+		if to == nil {
+			return nil, NewUserError("You have no bind point to return to.")
+		}
+	case teleportToRandom:
+		to = randomRoom(world.GetZone(zoneId), target)
+	case teleportToActor:
+		to = actor.Room()
+	}
+	if to == nil {
+		return nil, NewUserError("The magic fizzles.")
+	}
+	if to != from && to.Restricts(target, assets.RoomFlagSingleOccupant) && to.PlayerCount() >= 1 {
+		return nil, NewUserError("There isn't enough room there.")
+	}
+	return to, nil
+}
+
+// randomRoom picks a random room of zone that traveller could safely stand
+// in. Returns nil if there is none.
+func randomRoom(zone *game.ZoneInstance, traveller game.GrantHolder) *game.RoomInstance {
+	if zone == nil {
+		return nil
+	}
+	var ids []string
+	zone.ForEachRoom(func(id string, ri *game.RoomInstance) {
+		if !ri.Restricts(traveller, assets.RoomFlagDeath) && !ri.Restricts(traveller, assets.RoomFlagWater) {
+			ids = append(ids, id)
+		}
+	})
+	if len(ids) == 0 {
+		return nil
+	}
+	slices.Sort(ids)
+	return zone.GetRoom(ids[rand.IntN(len(ids))])
+}
+
+// relocate moves actor between rooms without walking, telling those it
+// leaves and those it joins with the given messages.
+func relocate(actor game.Actor, from, to *game.RoomInstance, leaveMsg, arriveMsg string) {
+	announceToRoom(from, actor, leaveMsg)
+	actor.Move(from, to)
+	announceToRoom(to, actor, arriveMsg)
+}
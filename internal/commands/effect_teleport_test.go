@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestTeleportEffect(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]string
+		bind      string // room the caster is bound to
+		start     string // room the caster starts in
+		summon    bool   // target Bob in the square rather than the caster
+		nosummon  bool
+		fighting  bool
+		expErr    string
+		wantRoom  string // where the target should end up
+		wantActor string // substring expected in the caster's lines
+	}{
+		"recall to bind point": {
+			config:    map[string]string{"destination": "bind"},
+			bind:      "temple",
+			wantRoom:  "temple",
+			wantActor: "Temple",
+		},
+		"recall without a bind point": {
+			config:   map[string]string{"destination": "bind"},
+			expErr:   "no bind point",
+			wantRoom: "gate",
+		},
+		"named room": {
+			config:   map[string]string{"destination": "room", "room": "square"},
+			wantRoom: "square",
+		},
+		"missing named room fizzles": {
+			config:   map[string]string{"destination": "room", "room": "nowhere"},
+			expErr:   "fizzles",
+			wantRoom: "gate",
+		},
+		"random room avoids deadly rooms": {
+			config:   map[string]string{"destination": "random"},
+			start:    "pit",
+			wantRoom: "gate",
+		},
+		"summon to the caster": {
+			config:   map[string]string{"destination": "actor"},
+			summon:   true,
+			wantRoom: "gate",
+		},
+		"nosummon resists": {
+			config:    map[string]string{"destination": "actor"},
+			summon:    true,
+			nosummon:  true,
+			wantRoom:  "square",
+			wantActor: "resists",
+		},
+		"out of combat refuses in a fight": {
+			config:   map[string]string{"destination": "bind", "out_of_combat": "true"},
+			bind:     "temple",
+			fighting: true,
+			expErr:   "fighting",
+			wantRoom: "gate",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			town := storage.NewResolvedSmartIdentifier("town", &assets.Zone{ResetMode: assets.ZoneResetNever})
+			flag := func(f assets.RoomFlag) []assets.Perk {
+				return []assets.Perk{{Type: assets.PerkTypeGrant, Key: string(f)}}
+			}
+			// Random teleports only land in safe rooms, so leave the gate as
+			// the only one when starting elsewhere.
+			var squarePerks []assets.Perk
+			if tc.start != "" {
+				squarePerks = flag(assets.RoomFlagDeath)
+			}
+			world, err := newTestWorldWithRooms(map[string]*assets.Room{
+				"gate":   {Name: "Gate", Zone: town},
+				"square": {Name: "Square", Zone: town, Perks: squarePerks},
+				"temple": {Name: "Temple", Zone: storage.NewResolvedSmartIdentifier("holy", &assets.Zone{ResetMode: assets.ZoneResetNever})},
+				"pit":    {Name: "Pit", Zone: town, Perks: flag(assets.RoomFlagDeath)},
+				"pool":   {Name: "Pool", Zone: town, Perks: flag(assets.RoomFlagWater)},
+			})
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			zone := world.GetZone("town")
+			start := "gate"
+			if tc.start != "" {
+				start = tc.start
+			}
+			caster := newTestPlayer("caster", "Caster", zone.GetRoom(start))
+			if tc.bind != "" {
+				caster.SetBindPoint(world.GetRoom("holy", tc.bind))
+			}
+			if tc.fighting {
+				setCombatReady(caster)
+				foe := newCombatMob("foe", "foe")
+				caster.EnsureThreat(foe.Id(), foe)
+			}
+
+			target := caster
+			if tc.summon {
+				target = newTestPlayer("bob", "Bob", zone.GetRoom("square"))
+				if tc.nosummon {
+					target.SetOwn([]assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantNoSummon}})
+				}
+			}
+
+			result := &AbilityResult{}
+			targets := map[string][]*TargetRef{
+				"target": {{Type: targetTypeActor, Actor: actorRefFromPlayer(target)}},
+			}
+			err = (&teleportEffect{}).Create("teleport:0", tc.config, nil)(context.Background(), caster, targets, result)
+			if tc.expErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := target.Room().Room.Id(); got != tc.wantRoom {
+				t.Errorf("target in %q, want %q", got, tc.wantRoom)
+			}
+			if tc.wantActor != "" && !strings.Contains(strings.Join(result.ActorLines, "\n"), tc.wantActor) {
+				t.Errorf("actor lines %q, want to contain %q", result.ActorLines, tc.wantActor)
+			}
+		})
+	}
+}
+
+func TestTeleportEffect_ValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]string
+		expErr bool
+	}{
+		"bind":            {config: map[string]string{"destination": "bind"}},
+		"room with id":    {config: map[string]string{"destination": "room", "room": "temple"}},
+		"room without id": {config: map[string]string{"destination": "room"}, expErr: true},
+		"unknown":         {config: map[string]string{"destination": "moon"}, expErr: true},
+		"missing":         {config: map[string]string{}, expErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := (&teleportEffect{}).ValidateConfig(tc.config)
+			if tc.expErr != (err != nil) {
+				t.Errorf("err = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
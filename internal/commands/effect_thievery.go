@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/combat"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// disarmBotchMargin is how far below the difficulty a disarm roll must fall
+// for the trap to go off in the thief's hands.
+const disarmBotchMargin = 5
+
+// pickEffect picks the lock on an exit or container. The check is d20 + DEX
+// modifier + core.skill.pick against the lock's difficulty. Pickproof locks
+// refuse outright, and an armed trap on the closure fires before the attempt.
+type pickEffect struct{}
+
+func (e *pickEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject | targetTypeExit, Required: true},
+		},
+	}
+}
+
+func (e *pickEffect) ValidateConfig(_ map[string]string) error { return nil }
+
+func (e *pickEffect) Create(_ string, _ map[string]string, _ []assets.TargetSpec) EffectFunc {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		for _, ref := range resolved["target"] {
+			ct := resolveClosureTarget(ref)
+			if ct == nil || ct.closure.Lock == nil {
+				return NewUserError("There's no lock there to pick.")
+			}
+			if !ct.isLocked() {
+				return NewUserError(fmt.Sprintf("%s is not locked.", ct.capitalizedLabel()))
+			}
+			if ct.closure.Lock.Pickproof {
+				return NewUserError(fmt.Sprintf("The lock on %s can't be picked.", ct.label))
+			}
+
+			ct.triggerTrap(actor, actor.Inventory())
+
+			roll := combat.RollCheck(skillBonus(actor, assets.StatDEX, assets.SkillPickPrefix))
+			if roll < ct.closure.Lock.PickDifficulty() {
+				result.ActorLines = append(result.ActorLines, "You fail to pick the lock.")
+				continue
+			}
+			ct.unlock(actor.Room())
+			result.ActorLines = append(result.ActorLines, fmt.Sprintf("You pick the lock on %s.", ct.label))
+			result.RoomLines = append(result.RoomLines, fmt.Sprintf("%s picks the lock on %s.", actor.Name(), ct.label))
+		}
+		return nil
+	}
+}
+
+// disarmEffect disarms a trap the actor has already found. The check is
+// d20 + DEX modifier + core.skill.disarm against the trap's difficulty;
+// missing by disarmBotchMargin or more sets the trap off.
+type disarmEffect struct{}
+
+func (e *disarmEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject | targetTypeExit, Required: true},
+		},
+	}
+}
+
+func (e *disarmEffect) ValidateConfig(_ map[string]string) error { return nil }
+
+func (e *disarmEffect) Create(_ string, _ map[string]string, _ []assets.TargetSpec) EffectFunc {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		for _, ref := range resolved["target"] {
+			ct := resolveClosureTarget(ref)
+			if ct == nil || ct.closure.Trap == nil || !ct.trap.Armed || !ct.trap.Found {
+				return NewUserError("You don't know of any trap there.")
+			}
+
+			roll := combat.RollCheck(skillBonus(actor, assets.StatDEX, assets.SkillDisarmPrefix))
+			dc := ct.closure.Trap.CheckDifficulty()
+			switch {
+			case roll >= dc:
+				ct.trap.Armed = false
+				result.ActorLines = append(result.ActorLines, fmt.Sprintf("You disarm the trap on %s.", ct.label))
+				result.RoomLines = append(result.RoomLines, fmt.Sprintf("%s disarms a trap on %s.", actor.Name(), ct.label))
+			case roll <= dc-disarmBotchMargin:
+				springTrap(actor, ct.closure.Trap, ct.trap, ct.label)
+			default:
+				result.ActorLines = append(result.ActorLines, "You fail to disarm the trap.")
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newTrappedDoorRoom creates a room with a closed door to the north using
+// the given lock and trap, and a player with average stats standing in it.
+func newTrappedDoorRoom(t *testing.T, lock *assets.Lock, trap *assets.Trap) (*game.CharacterInstance, *game.ResolvedExit) {
+	t.Helper()
+	zone := &assets.Zone{ResetMode: assets.ZoneResetNever}
+	room := &assets.Room{
+		Name: "Hall",
+		Zone: storage.NewResolvedSmartIdentifier("z", zone),
+		Exits: map[string]assets.Exit{
+			"north": {Closure: &assets.Closure{Name: "door", Closed: true, Lock: lock, Trap: trap}},
+		},
+	}
+	ri, err := game.NewRoomInstance(storage.NewResolvedSmartIdentifier("hall", room))
+	if err != nil {
+		t.Fatalf("NewRoomInstance: %v", err)
+	}
+	ci := newTestPlayer("thief", "Thief", ri)
+	ci.Character.Get().BaseStats = map[assets.StatKey]int{assets.StatDEX: 10, assets.StatWIS: 10}
+	_, re := ri.FindExit("north")
+	return ci, re
+}
+
+func northTarget(re *game.ResolvedExit) map[string][]*TargetRef {
+	return map[string][]*TargetRef{
+		"target": {{Type: targetTypeExit, Exit: exitRefFrom("north", re)}},
+	}
+}
+
+func TestPickEffect(t *testing.T) {
+	tests := map[string]struct {
+		lock       *assets.Lock
+		trap       *assets.Trap
+		expErr     bool
+		wantLocked bool
+		wantSprung bool
+	}{
+		"easy lock opens": {
+			lock:       &assets.Lock{Locked: true, Difficulty: 1},
+			wantLocked: false,
+		},
+		"impossible lock holds": {
+			lock:       &assets.Lock{Locked: true, Difficulty: 100},
+			wantLocked: true,
+		},
+		"pickproof lock refuses": {
+			lock:       &assets.Lock{Locked: true, Pickproof: true, Difficulty: 1},
+			expErr:     true,
+			wantLocked: true,
+		},
+		"unlocked door": {
+			lock:   &assets.Lock{Difficulty: 1},
+			expErr: true,
+		},
+		"no lock": {
+			expErr: true,
+		},
+		"trap fires on the attempt": {
+			lock:       &assets.Lock{Locked: true, Difficulty: 1},
+			trap:       &assets.Trap{Type: assets.TrapTypeAlarm},
+			wantSprung: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.lock != nil {
+				tc.lock.KeyId = storage.NewSmartIdentifier[*assets.Object]("key")
+			}
+			ci, re := newTrappedDoorRoom(t, tc.lock, tc.trap)
+
+			err := (&pickEffect{}).Create("pick:0", nil, nil)(context.Background(), ci, northTarget(re), &AbilityResult{})
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if re.IsLocked() != tc.wantLocked {
+				t.Errorf("locked = %v, want %v", re.IsLocked(), tc.wantLocked)
+			}
+			if tc.trap != nil && re.Trap().Armed == tc.wantSprung {
+				t.Errorf("trap armed = %v, want sprung %v", re.Trap().Armed, tc.wantSprung)
+			}
+		})
+	}
+}
+
+func TestDisarmEffect(t *testing.T) {
+	tests := map[string]struct {
+		difficulty int
+		found      bool
+		expErr     bool
+		wantArmed  bool
+	}{
+		"easy trap disarmed": {
+			difficulty: 1, found: true, wantArmed: false,
+		},
+		"botched trap goes off": {
+			difficulty: 100, found: true, wantArmed: false,
+		},
+		"unfound trap": {
+			difficulty: 1, expErr: true, wantArmed: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci, re := newTrappedDoorRoom(t, nil, &assets.Trap{Type: assets.TrapTypeAlarm, Difficulty: tc.difficulty})
+			re.Trap().Found = tc.found
+
+			result := &AbilityResult{}
+			err := (&disarmEffect{}).Create("disarm:0", nil, nil)(context.Background(), ci, northTarget(re), result)
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if re.Trap().Armed != tc.wantArmed {
+				t.Errorf("armed = %v, want %v", re.Trap().Armed, tc.wantArmed)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -60,7 +61,7 @@ func (e *threatEffect) Create(_ string, config map[string]string, targets []asse
 	amount, _ := strconv.Atoi(config["amount"])
 	inCombatOnly := config["in_combat_only"] == "true"
 
-	return func(actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, _ *AbilityResult) error {
 		for _, ref := range resolved["target"] {
 			if ref.Actor == nil {
 				continue
@@ -71,6 +72,9 @@ func (e *threatEffect) Create(_ string, config map[string]string, targets []asse
 				continue
 			}
 
+			if game.CheckDuel(actor, target) != nil || game.CheckPvP(actor, target) != nil {
+				continue
+			}
 			if err := combat.StartCombat(actor, target); err != nil {
 				continue
 			}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/combat"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+const (
+	// trackDepth is how many rooms away a trail can still be picked up.
+	trackDepth = 30
+	// trackDifficulty is the check a track roll must meet.
+	trackDifficulty = 10
+)
+
+// trackEffect senses the first step of the shortest path toward the target.
+// The check is d20 + WIS modifier + core.skill.track against trackDifficulty;
+// targets holding the notrack grant leave no trail at all.
+type trackEffect struct{}
+
+func (e *trackEffect) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeActor, Required: true},
+		},
+	}
+}
+
+func (e *trackEffect) ValidateConfig(_ map[string]string) error { return nil }
+
+func (e *trackEffect) Create(_ string, _ map[string]string, _ []assets.TargetSpec) EffectFunc {
+	return func(_ context.Context, actor game.Actor, resolved map[string][]*TargetRef, result *AbilityResult) error {
+		for _, ref := range resolved["target"] {
+			if ref.Actor == nil {
+				continue
+			}
+			target := ref.Actor.Actor()
+			from, to := actor.Room(), target.Room()
+			if from == nil || to == nil {
+				continue
+			}
+			if from == to {
+				return NewUserError("You're already in the same room!")
+			}
+
+			var path []string
+			found := false
+			if !target.HasGrant(assets.PerkGrantNoTrack, "") &&
+				combat.RollCheck(skillBonus(actor, assets.StatWIS, assets.SkillTrackPrefix)) >= trackDifficulty {
+				path, found = game.FindPath(from, actor, trackDepth, func(ri *game.RoomInstance) bool { return ri == to })
+			}
+			if !found {
+				result.ActorLines = append(result.ActorLines, "You sense no trail.")
+				continue
+			}
+			result.ActorLines = append(result.ActorLines, fmt.Sprintf("You sense a trail %s from here!", path[0]))
+		}
+		return nil
+	}
+}
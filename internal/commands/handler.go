@@ -100,10 +100,12 @@ type compiledCommand struct {
 // Template-expanded messages and effect-appended lines are both collected
 // into the slices; callers join them with "\n" at publish time.
 type AbilityResult struct {
-	ActorLines  []string
-	TargetLines []string
-	Target      game.Actor // the target player, if any
-	RoomLines   []string
+	ActorLines       []string
+	TargetLines      []string
+	Target           game.Actor // the target player, if any
+	InterceptorLines []string
+	Interceptor      game.Actor // a guard who stepped in to take the target's attacks, if any
+	RoomLines        []string
 }
 
 // ExecAbilityOpts controls ability execution behavior.
@@ -132,13 +134,22 @@ func NewHandler(cmds storage.Storer[*assets.Command], dict *game.Dictionary, wor
 	h.effects["attack"] = &attackEffect{}
 	h.effects["damage"] = &damageEffect{}
 	h.effects["actor_buff"] = &buffEffect{scope: buffScopeActor}
+	h.effects["self_buff"] = &buffEffect{scope: buffScopeSelf}
 	h.effects["room_buff"] = &buffEffect{scope: buffScopeRoom}
 	h.effects["zone_buff"] = &buffEffect{scope: buffScopeZone}
 	h.effects["world_buff"] = &buffEffect{scope: buffScopeWorld}
 	h.effects["threat"] = &threatEffect{}
+	h.effects["guard"] = &guardEffect{}
 	h.effects["heal"] = &healEffect{}
 	h.effects["spawn_obj"] = &spawnObjEffect{objects: dict.Objects}
 	h.effects["spawn_mob"] = &spawnMobEffect{mobiles: dict.Mobiles}
+	h.effects["pick"] = &pickEffect{}
+	h.effects["disarm"] = &disarmEffect{}
+	h.effects["track"] = &trackEffect{}
+	h.effects["teleport"] = &teleportEffect{}
+	h.effects["script"] = &scriptEffect{}
+	h.effects["enchant"] = &enchantEffect{}
+	h.effects["repair"] = &repairEffect{}
 
 	// Register built-in handlers
 	for _, reg := range []struct {
@@ -146,8 +157,14 @@ func NewHandler(cmds storage.Storer[*assets.Command], dict *game.Dictionary, wor
 		factory HandlerFactory
 	}{
 		{"assist", NewAssistHandlerFactory(world)},
+		{"bind", NewBindHandlerFactory()},
 		{"closure", NewClosureHandlerFactory()},
+		{"duel", NewDuelHandlerFactory()},
+		{"eat", NewEatHandlerFactory()},
 		{"equipment", NewEquipmentHandlerFactory()},
+		{"enter", NewEnterHandlerFactory()},
+		{"factions", NewFactionsHandlerFactory(dict.Factions)},
+		{"flee", NewFleeHandlerFactory()},
 		{"follow", NewFollowHandlerFactory()},
 		{"gain", NewGainHandlerFactory()},
 		{"group", NewGroupHandlerFactory()},
@@ -155,14 +172,28 @@ func NewHandler(cmds storage.Storer[*assets.Command], dict *game.Dictionary, wor
 		{"help", NewHelpHandlerFactory(cmds, dict.Abilities)},
 		{"inventory", NewInventoryHandlerFactory()},
 		{"look", NewLookHandlerFactory()},
+		{"loot", NewLootHandlerFactory()},
+		{"map", NewMapHandlerFactory()},
+		{"lever", NewLeverHandlerFactory()},
+		{"light", NewLightHandlerFactory()},
+		{"liquid", NewLiquidHandlerFactory()},
 		{"message", NewMessageHandlerFactory()},
 		{"move", NewMoveHandlerFactory()},
 		{"move_obj", NewMoveObjHandlerFactory()},
+		{"pvp", NewPvPHandlerFactory()},
+		{"quest", NewQuestHandlerFactory()},
 		{"quit", NewQuitHandlerFactory()},
+		{"rank", NewRankHandlerFactory()},
+		{"rest", NewRestHandlerFactory()},
 		{"save", NewSaveHandlerFactory(dict.Characters)},
+		{"scan", NewScanHandlerFactory()},
 		{"score", NewScoreHandlerFactory()},
+		{"search", NewSearchHandlerFactory()},
+		{"talk", NewTalkHandlerFactory()},
+		{"time", NewTimeHandlerFactory()},
 		{"title", NewTitleHandlerFactory()},
 		{"trees", NewTreesHandlerFactory(dict.Trees)},
+		{"walk", NewWalkHandlerFactory()},
 		{"wear", NewWearHandlerFactory()},
 		{"who", NewWhoHandlerFactory(world)},
 	} {
@@ -214,7 +245,7 @@ func (h *Handler) registerAbility(id string, ability *assets.Ability) error {
 
 // ExecAbility executes a compiled ability with a pre-resolved target, bypassing
 // command dispatch and AP costs. Used by the combat tick for auto_use abilities.
-func (h *Handler) ExecAbility(abilityId string, actor, target game.Actor) error {
+func (h *Handler) ExecAbility(ctx context.Context, abilityId string, actor, target game.Actor) error {
 	ca, ok := h.abilities[abilityId]
 	if !ok {
 		return fmt.Errorf("unknown ability %q", abilityId)
@@ -222,7 +253,7 @@ func (h *Handler) ExecAbility(abilityId string, actor, target game.Actor) error
 	targets := map[string][]*TargetRef{
 		"target": {{Type: targetTypeActor, Actor: actorRefFromActor(target)}},
 	}
-	result, err := ca.exec(actor, targets, ExecAbilityOpts{SkipAP: true})
+	result, err := ca.exec(ctx, actor, targets, ExecAbilityOpts{SkipAP: true})
 	if err != nil {
 		return err
 	}
@@ -230,7 +261,8 @@ func (h *Handler) ExecAbility(abilityId string, actor, target game.Actor) error
 	return nil
 }
 
-// publishAbilityResult delivers ability messages to actor, target, and room.
+// publishAbilityResult delivers ability messages to actor, target, any
+// interceptor, and room.
 func publishAbilityResult(result *AbilityResult, actor, target game.Actor) {
 	if len(result.ActorLines) > 0 {
 		actor.QueueTickMsg(strings.Join(result.ActorLines, "\n"))
@@ -238,12 +270,19 @@ func publishAbilityResult(result *AbilityResult, actor, target game.Actor) {
 	if len(result.TargetLines) > 0 {
 		target.QueueTickMsg(strings.Join(result.TargetLines, "\n"))
 	}
+	var interceptorId string
+	if result.Interceptor != nil {
+		interceptorId = result.Interceptor.Id()
+		if len(result.InterceptorLines) > 0 {
+			result.Interceptor.QueueTickMsg(strings.Join(result.InterceptorLines, "\n"))
+		}
+	}
 	if len(result.RoomLines) > 0 {
 		msg := strings.Join(result.RoomLines, "\n")
 		actorId := actor.Id()
 		targetId := target.Id()
 		actor.Room().ForEachPlayer(func(charId string, ci *game.CharacterInstance) {
-			if charId == actorId || charId == targetId {
+			if charId == actorId || charId == targetId || charId == interceptorId {
 				return
 			}
 			ci.QueueTickMsg(msg)
@@ -464,10 +503,23 @@ func hasRequiredInput(cmd *assets.Command) bool {
 	return false
 }
 
-// Exec executes a command with the given arguments.
+// Exec executes a command with the given arguments. Command triggers around
+// the actor get the first look and may swallow it.
 func (h *Handler) Exec(ctx context.Context, actor game.Actor, cmdName string, rawArgs ...string) error {
+	if game.InterceptCommand(ctx, actor, cmdName, rawArgs...) {
+		return nil
+	}
+
 	compiled, err := h.resolve(cmdName, len(rawArgs) > 0)
 	if err != nil {
+		// A word that isn't a command but reads as a speedwalk ("3n2e") walks
+		// it. It must have a count, so a mistyped word made of direction
+		// letters ("news") doesn't send the player off.
+		if _, ok := h.compiled["walk"]; ok && len(rawArgs) == 0 && strings.ContainsAny(cmdName, "0123456789") {
+			if _, ok := parseSpeedwalk(strings.ToLower(cmdName)); ok {
+				return h.Exec(ctx, actor, "walk", cmdName)
+			}
+		}
 		return err
 	}
 
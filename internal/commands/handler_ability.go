@@ -15,7 +15,7 @@ import (
 // EffectFunc is a compiled effect closure with config baked in at registration time.
 // Effects may optionally set fields on the AbilityResult to override the
 // ability's template-based messages (e.g. attackEffect builds hit/miss lines).
-type EffectFunc func(actor game.Actor, targets map[string][]*TargetRef, result *AbilityResult) error
+type EffectFunc func(ctx context.Context, actor game.Actor, targets map[string][]*TargetRef, result *AbilityResult) error
 
 // EffectHandler defines an ability effect (damage, healing, buff, etc.).
 // ValidateConfig checks config at registration time. Create returns a closure
@@ -41,6 +41,7 @@ type compiledAbility struct {
 	resource     string
 	resourceCost int
 	apCost       int
+	melee        bool
 	msgActor     *CompiledTemplate
 	msgTarget    *CompiledTemplate
 	msgRoom      *CompiledTemplate
@@ -81,6 +82,7 @@ func newCompiledAbility(id string, ability *assets.Ability, handlers map[string]
 			{Name: "resource"},
 			{Name: "resource_cost"},
 			{Name: "ap_cost"},
+			{Name: "melee"},
 			{Name: "message_actor"},
 			{Name: "message_target"},
 			{Name: "message_room"},
@@ -113,6 +115,7 @@ func newCompiledAbility(id string, ability *assets.Ability, handlers map[string]
 		resource:     config["resource"],
 		resourceCost: resourceCost,
 		apCost:       apCost,
+		melee:        config["melee"] == "true",
 		msgActor:     msgActor,
 		msgTarget:    msgTarget,
 		msgRoom:      msgRoom,
@@ -123,7 +126,11 @@ func newCompiledAbility(id string, ability *assets.Ability, handlers map[string]
 // AbilityResult without publishing. This is the shared core used by both the
 // command handler (via abilityCommandWrapper.Create) and direct invocation
 // (via Handler.ExecAbility).
-func (ca *compiledAbility) exec(actor game.Actor, targets map[string][]*TargetRef, opts ExecAbilityOpts) (*AbilityResult, error) {
+func (ca *compiledAbility) exec(ctx context.Context, actor game.Actor, targets map[string][]*TargetRef, opts ExecAbilityOpts) (*AbilityResult, error) {
+	if ca.melee && game.InBackRank(actor) {
+		return nil, NewUserError("You can't reach the fight from the back rank.")
+	}
+
 	// Check resource cost before spending any AP.
 	if ca.resourceCost > 0 {
 		cur, _ := actor.Resource(ca.resource)
@@ -205,7 +212,7 @@ func (ca *compiledAbility) exec(actor game.Actor, targets map[string][]*TargetRe
 
 	// Run effects after template expansion — effects may append detail lines.
 	for _, effect := range ca.effectFuncs {
-		if err := effect(actor, targets, result); err != nil {
+		if err := effect(ctx, actor, targets, result); err != nil {
 			return nil, err
 		}
 	}
@@ -226,7 +233,8 @@ func (w *abilityCommandWrapper) Spec() *HandlerSpec {
 	return w.ca.spec
 }
 
-// ValidateConfig checks that resource_cost and ap_cost are non-negative integers.
+// ValidateConfig checks that resource_cost and ap_cost are non-negative
+// integers and that melee, if set, is a boolean.
 func (w *abilityCommandWrapper) ValidateConfig(config map[string]string) error {
 	if cost := config["resource_cost"]; cost != "" {
 		n, err := strconv.Atoi(cost)
@@ -249,6 +257,11 @@ func (w *abilityCommandWrapper) ValidateConfig(config map[string]string) error {
 			return errors.New("ap_cost must not be negative")
 		}
 	}
+	switch config["melee"] {
+	case "", "true", "false":
+	default:
+		return fmt.Errorf("melee must be true or false, got %q", config["melee"])
+	}
 	return nil
 }
 
@@ -260,11 +273,17 @@ func (w *abilityCommandWrapper) Create() (CommandFunc, error) {
 			return NewUserError("You don't know how to do that.")
 		}
 
-		result, err := w.ca.exec(actor, in.Targets, ExecAbilityOpts{})
+		result, err := w.ca.exec(ctx, actor, in.Targets, ExecAbilityOpts{})
 		if err != nil {
 			return err
 		}
-		return w.publishResult(result, actor)
+		if err := w.publishResult(result, actor); err != nil {
+			return err
+		}
+		if qt, ok := actor.(questTracker); ok {
+			qt.QuestUsedAbility(w.id)
+		}
+		return nil
 	}), nil
 }
 
@@ -280,6 +299,12 @@ func (w *abilityCommandWrapper) publishResult(result *AbilityResult, actor game.
 		result.Target.Publish([]byte(strings.Join(result.TargetLines, "\n")), nil)
 		exclude = append(exclude, result.Target.Id())
 	}
+	if result.Interceptor != nil {
+		if len(result.InterceptorLines) > 0 {
+			result.Interceptor.Publish([]byte(strings.Join(result.InterceptorLines, "\n")), nil)
+		}
+		exclude = append(exclude, result.Interceptor.Id())
+	}
 	if len(result.RoomLines) > 0 {
 		actor.Room().Publish([]byte(strings.Join(result.RoomLines, "\n")), exclude)
 	}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -36,7 +37,7 @@ func TestExecuteAbility_APGating(t *testing.T) {
 			actor := &gametest.BaseActor{ActorId: "player", ActorName: "Player", SpendAPFails: tc.spendAPFails}
 
 			ca := &compiledAbility{apCost: tc.apCost}
-			_, err := ca.exec(actor, nil, ExecAbilityOpts{})
+			_, err := ca.exec(context.Background(), actor, nil, ExecAbilityOpts{})
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -108,7 +109,7 @@ func TestExecuteAbility_CostCheckOrdering(t *testing.T) {
 				resource:     "mana",
 				resourceCost: tc.resourceCost,
 			}
-			_, err := ca.exec(actor, nil, ExecAbilityOpts{})
+			_, err := ca.exec(context.Background(), actor, nil, ExecAbilityOpts{})
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -132,6 +133,40 @@ func TestExecuteAbility_CostCheckOrdering(t *testing.T) {
 }
 
 // setCombatReady gives the player AP and HP so combat effects can function.
+func TestExecuteAbility_Melee(t *testing.T) {
+	tests := map[string]struct {
+		melee   bool
+		back    bool
+		wantErr string
+	}{
+		"melee from the front":    {melee: true},
+		"melee from the back":     {melee: true, back: true, wantErr: "You can't reach the fight from the back rank."},
+		"non-melee from the back": {back: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			player := newTestPlayer("player", "Player", room)
+			setCombatReady(player)
+			player.SetBackRank(tc.back)
+
+			ca := &compiledAbility{apCost: 1, melee: tc.melee}
+			_, err := ca.exec(context.Background(), player, nil, ExecAbilityOpts{})
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func setCombatReady(player *game.CharacterInstance) {
 	player.SetOwn([]assets.Perk{
 		{Type: assets.PerkTypeModifier, Key: assets.PerkKeyActionPointsMax, Value: 10},
@@ -202,7 +237,7 @@ func TestRoomBuffEffect(t *testing.T) {
 			}
 
 			fn := effect.Create("test-ability:0", tc.config, nil)
-			err := fn(player, nil, &AbilityResult{})
+			err := fn(context.Background(), player, nil, &AbilityResult{})
 
 			if tc.wantErr != "" {
 				if err == nil {
@@ -240,7 +275,7 @@ func TestRoomBuffEffectExpiry(t *testing.T) {
 	}
 	fn := effect.Create("test-ability:0", config, nil)
 
-	if err := fn(player, nil, &AbilityResult{}); err != nil {
+	if err := fn(context.Background(), player, nil, &AbilityResult{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
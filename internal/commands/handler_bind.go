@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// BindActor provides the character state needed by the bind handler.
+type BindActor interface {
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	SetBindPoint(room *game.RoomInstance)
+}
+
+var _ BindActor = (*game.CharacterInstance)(nil)
+
+// BindHandlerFactory creates handlers that make the actor's current room
+// the bind point recall returns them to.
+type BindHandlerFactory struct{}
+
+// NewBindHandlerFactory creates a new BindHandlerFactory.
+func NewBindHandlerFactory() *BindHandlerFactory {
+	return &BindHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *BindHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *BindHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *BindHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[BindActor](f.handle), nil
+}
+
+func (f *BindHandlerFactory) handle(ctx context.Context, actor BindActor, in *CommandInput) error {
+	room := actor.Room()
+	if room == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+	actor.SetBindPoint(room)
+	actor.Publish([]byte(fmt.Sprintf("You will now recall to %s.", room.Room.Get().Name)), nil)
+	return nil
+}
@@ -17,6 +17,9 @@ type ClosureActor interface {
 	Publish(data []byte, exclude []string)
 	Room() *game.RoomInstance
 	Inventory() *game.Inventory
+	Resource(name string) (current, max int)
+	AdjustResource(name string, delta int, overfill bool)
+	AddTimedPerks(name string, perks []assets.Perk, ticks int)
 }
 
 var _ ClosureActor = (*game.CharacterInstance)(nil)
@@ -97,6 +100,9 @@ func (f *ClosureHandlerFactory) handleExit(action, direction string, re *game.Re
 		if !re.IsClosed() {
 			return NewUserError(fmt.Sprintf("The %s is already open.", name))
 		}
+		if closure.Trap != nil && re.Trap().Armed && !holdsKey(char.Inventory(), closure.Lock) {
+			springTrap(char, closure.Trap, re.Trap(), "the "+name)
+		}
 
 	case assets.ClosureActionClose:
 		if re.IsClosed() {
@@ -156,7 +162,7 @@ func (f *ClosureHandlerFactory) handleContainer(action string, oi *game.ObjectIn
 	closure := oi.Object.Get().Closure
 	name := closure.Name
 	if name == "" {
-		name = oi.Object.Get().ShortDesc
+		name = oi.ShortDesc()
 	}
 	capName := display.Capitalize(name)
 
@@ -168,6 +174,9 @@ func (f *ClosureHandlerFactory) handleContainer(action string, oi *game.ObjectIn
 		if !oi.Closed {
 			return NewUserError(fmt.Sprintf("%s is already open.", capName))
 		}
+		if closure.Trap != nil && oi.Trap.Armed && !holdsKey(char.Inventory(), closure.Lock) {
+			springTrap(char, closure.Trap, &oi.Trap, name)
+		}
 		oi.Closed = false
 		return f.publish(char, fmt.Sprintf("You open %s.", name), fmt.Sprintf("%s opens %s.", char.Name(), name))
 
@@ -212,7 +221,7 @@ func (f *ClosureHandlerFactory) handleContainer(action string, oi *game.ObjectIn
 }
 
 func (f *ClosureHandlerFactory) checkKey(char ClosureActor, lock *assets.Lock) error {
-	if char.Inventory().FindObjByDef(lock.KeyId.Id()) == nil {
+	if !holdsKey(char.Inventory(), lock) {
 		return NewUserError("You don't have the key.")
 	}
 	return nil
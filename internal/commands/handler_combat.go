@@ -93,6 +93,9 @@ func (f *AssistHandlerFactory) handle(ctx context.Context, in *CommandInput) err
 		return NewUserError(fmt.Sprintf("%s isn't fighting anyone.", assistedName))
 	}
 
+	if err := checkAttack(char, target); err != nil {
+		return err
+	}
 	if err := combat.StartCombat(char, target); err != nil {
 		return NewUserError(fmt.Sprintf("%s isn't fighting anything you can assist with.", assistedName))
 	}
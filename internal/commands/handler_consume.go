@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Liquid actions drive the liquid handler's dispatch.
+// Commands declare one of these as the `action` config field.
+const (
+	liquidActionDrink = "drink"
+	liquidActionFill  = "fill"
+	liquidActionPour  = "pour"
+)
+
+// ConsumeActor provides the character state needed by the eat and liquid handlers.
+type ConsumeActor interface {
+	Id() string
+	Name() string
+	Publish(data []byte, exclude []string)
+	Room() *game.RoomInstance
+	Resource(name string) (current, max int)
+	AdjustResource(name string, delta int, overfill bool)
+}
+
+var _ ConsumeActor = (*game.CharacterInstance)(nil)
+
+// isSated reports whether the actor tracks the named survival resource and
+// it is already full.
+func isSated(char ConsumeActor, resource string) bool {
+	cur, mx := char.Resource(resource)
+	return mx > 0 && cur >= mx
+}
+
+// ---------------------------------------------------------------------------
+// EatHandlerFactory
+// ---------------------------------------------------------------------------
+
+// EatHandlerFactory creates handlers that consume food.
+// Targets:
+//   - target (required): the food object to eat
+type EatHandlerFactory struct{}
+
+// NewEatHandlerFactory creates a handler factory for eat commands.
+func NewEatHandlerFactory() *EatHandlerFactory {
+	return &EatHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *EatHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *EatHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *EatHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[ConsumeActor](f.handle), nil
+}
+
+func (f *EatHandlerFactory) handle(ctx context.Context, char ConsumeActor, in *CommandInput) error {
+	target := in.FirstTarget("target")
+	if target == nil || target.Obj == nil {
+		return NewUserError("Eat what?")
+	}
+
+	food := target.Obj.instance.Object.Get().Food
+	if food == nil {
+		return NewUserError(fmt.Sprintf("You can't eat %s.", target.Obj.Name))
+	}
+	if isSated(char, assets.ResourceHunger) {
+		return NewUserError("You are too full to eat more!")
+	}
+
+	if target.Obj.source.RemoveObj(target.Obj.InstanceId) == nil {
+		return NewUserError(fmt.Sprintf("You're not carrying %s.", target.Obj.Name))
+	}
+	char.AdjustResource(assets.ResourceHunger, food.Fill, false)
+	char.Room().EmitObjectEvent(game.ObjectEventDestroy, target.Obj.instance, in.Actor)
+
+	char.Publish([]byte(fmt.Sprintf("You eat %s.", target.Obj.Name)), nil)
+	char.Room().Publish([]byte(fmt.Sprintf("%s eats %s.", char.Name(), target.Obj.Name)), []string{char.Id()})
+	if isSated(char, assets.ResourceHunger) {
+		char.Publish([]byte("You are full."), nil)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// LiquidHandlerFactory
+// ---------------------------------------------------------------------------
+
+// LiquidHandlerFactory creates handlers for drink/fill/pour commands.
+// Config:
+//   - action (required): "drink", "fill", or "pour"
+//
+// Targets:
+//   - target (required): the drink container or fountain acted on
+//   - source (optional): for fill, where the liquid comes from
+//   - destination (optional): for pour, the container poured into; without
+//     one the liquid is poured out onto the ground
+type LiquidHandlerFactory struct{}
+
+// NewLiquidHandlerFactory creates a handler factory for drink/fill/pour commands.
+func NewLiquidHandlerFactory() *LiquidHandlerFactory {
+	return &LiquidHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *LiquidHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+			{Name: "source", Type: targetTypeObject, Required: false},
+			{Name: "destination", Type: targetTypeObject, Required: false},
+		},
+		Config: []ConfigRequirement{
+			{Name: "action", Required: true},
+		},
+	}
+}
+
+// ValidateConfig checks that action is one of drink, fill, or pour.
+func (f *LiquidHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch config["action"] {
+	case liquidActionDrink, liquidActionFill, liquidActionPour:
+		return nil
+	default:
+		return errors.New("action must be drink, fill, or pour")
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *LiquidHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[ConsumeActor](f.handle), nil
+}
+
+func (f *LiquidHandlerFactory) handle(ctx context.Context, char ConsumeActor, in *CommandInput) error {
+	target := in.FirstTarget("target")
+	if target == nil || target.Obj == nil {
+		return NewUserError(fmt.Sprintf("%s what?", display.Capitalize(in.Config["action"])))
+	}
+	if target.Obj.instance.Object.Get().Drink == nil {
+		return NewUserError(fmt.Sprintf("You can't %s %s.", in.Config["action"], target.Obj.Name))
+	}
+
+	switch in.Config["action"] {
+	case liquidActionDrink:
+		return f.drink(char, target.Obj)
+	case liquidActionFill:
+		return f.fill(char, target.Obj, in.FirstTarget("source"))
+	default:
+		return f.pour(char, target.Obj, in.FirstTarget("destination"))
+	}
+}
+
+func (f *LiquidHandlerFactory) drink(char ConsumeActor, obj *ObjectRef) error {
+	if obj.instance.Liquid == "" {
+		return NewUserError(fmt.Sprintf("%s is empty.", display.Capitalize(obj.Name)))
+	}
+	if isSated(char, assets.ResourceThirst) {
+		return NewUserError("Your stomach can't contain any more!")
+	}
+
+	liquid := obj.instance.Sip()
+	char.AdjustResource(assets.ResourceThirst, game.SipQuench, false)
+
+	char.Publish([]byte(fmt.Sprintf("You drink the %s from %s.", liquid, obj.Name)), nil)
+	char.Room().Publish([]byte(fmt.Sprintf("%s drinks %s from %s.", char.Name(), liquid, obj.Name)), []string{char.Id()})
+	if isSated(char, assets.ResourceThirst) {
+		char.Publish([]byte("You don't feel thirsty any more."), nil)
+	}
+	return nil
+}
+
+func (f *LiquidHandlerFactory) fill(char ConsumeActor, obj *ObjectRef, source *TargetRef) error {
+	if obj.instance.Object.Get().Drink.Infinite {
+		return NewUserError(fmt.Sprintf("You can't fill %s.", obj.Name))
+	}
+	if source == nil || source.Obj == nil {
+		return NewUserError(fmt.Sprintf("Fill %s from what?", obj.Name))
+	}
+	if source.Obj.InstanceId == obj.InstanceId {
+		return NewUserError("You can't fill something from itself.")
+	}
+	if err := checkPourable(source.Obj, obj); err != nil {
+		return err
+	}
+
+	if obj.instance.FillFrom(source.Obj.instance) == 0 {
+		return NewUserError(fmt.Sprintf("%s is already full.", display.Capitalize(obj.Name)))
+	}
+
+	char.Publish([]byte(fmt.Sprintf("You fill %s from %s.", obj.Name, source.Obj.Name)), nil)
+	char.Room().Publish([]byte(fmt.Sprintf("%s fills %s from %s.", char.Name(), obj.Name, source.Obj.Name)), []string{char.Id()})
+	return nil
+}
+
+func (f *LiquidHandlerFactory) pour(char ConsumeActor, obj *ObjectRef, dest *TargetRef) error {
+	if obj.instance.Object.Get().Drink.Infinite {
+		return NewUserError(fmt.Sprintf("You can't pour out %s.", obj.Name))
+	}
+	if obj.instance.Liquid == "" {
+		return NewUserError(fmt.Sprintf("%s is empty.", display.Capitalize(obj.Name)))
+	}
+
+	if dest == nil || dest.Obj == nil {
+		liquid := obj.instance.Empty()
+		char.Publish([]byte(fmt.Sprintf("You empty the %s from %s.", liquid, obj.Name)), nil)
+		char.Room().Publish([]byte(fmt.Sprintf("%s empties %s.", char.Name(), obj.Name)), []string{char.Id()})
+		return nil
+	}
+
+	if dest.Obj.InstanceId == obj.InstanceId {
+		return NewUserError("You can't pour something into itself.")
+	}
+	if d := dest.Obj.instance.Object.Get().Drink; d == nil || d.Infinite {
+		return NewUserError(fmt.Sprintf("You can't pour anything into %s.", dest.Obj.Name))
+	}
+	if err := checkPourable(obj, dest.Obj); err != nil {
+		return err
+	}
+	if dest.Obj.instance.FillFrom(obj.instance) == 0 {
+		return NewUserError(fmt.Sprintf("%s is already full.", display.Capitalize(dest.Obj.Name)))
+	}
+
+	char.Publish([]byte(fmt.Sprintf("You pour %s into %s.", obj.Name, dest.Obj.Name)), nil)
+	char.Room().Publish([]byte(fmt.Sprintf("%s pours %s into %s.", char.Name(), obj.Name, dest.Obj.Name)), []string{char.Id()})
+	return nil
+}
+
+// checkPourable returns a user error if liquid can't move from src into dst.
+func checkPourable(src, dst *ObjectRef) error {
+	if src.instance.Object.Get().Drink == nil || src.instance.Liquid == "" {
+		return NewUserError(fmt.Sprintf("There is nothing in %s.", src.Name))
+	}
+	if dst.instance.Liquid != "" && dst.instance.Liquid != src.instance.Liquid {
+		return NewUserError(fmt.Sprintf("There is already another liquid in %s.", dst.Name))
+	}
+	return nil
+}
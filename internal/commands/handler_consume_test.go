@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/gametest"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newConsumable spawns an object into inv and returns a target ref for it.
+func newConsumable(t *testing.T, inv *game.Inventory, id string, obj *assets.Object) *TargetRef {
+	t.Helper()
+	obj.Aliases = []string{id}
+	obj.ShortDesc = "a " + id
+	oi, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier(id, obj))
+	if err != nil {
+		t.Fatalf("NewObjectInstance: %v", err)
+	}
+	inv.AddObj(oi)
+	return &TargetRef{Type: targetTypeObject, Obj: objRefFromInstance(oi, inv)}
+}
+
+func TestEatHandler(t *testing.T) {
+	tests := map[string]struct {
+		obj        *assets.Object
+		hunger     [2]int
+		wantHunger int
+		wantGone   bool
+		expErr     string
+	}{
+		"eating restores hunger and consumes food": {
+			obj:        &assets.Object{Food: &assets.Food{Fill: 5}},
+			hunger:     [2]int{2, 20},
+			wantHunger: 7,
+			wantGone:   true,
+		},
+		"non-food refused": {
+			obj:    &assets.Object{},
+			hunger: [2]int{2, 20},
+			expErr: "You can't eat a bread.",
+		},
+		"too full to eat": {
+			obj:    &assets.Object{Food: &assets.Food{Fill: 5}},
+			hunger: [2]int{20, 20},
+			expErr: "You are too full to eat more!",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoom("r", "Room", "z")
+			inv := game.NewInventory()
+			target := newConsumable(t, inv, "bread", tc.obj)
+			actor := &gametest.BaseActor{
+				ActorId: "alice", ActorName: "Alice", ActorRoom: room,
+				Resources: map[string][2]int{assets.ResourceHunger: tc.hunger},
+			}
+			in := &CommandInput{Actor: actor, Targets: map[string][]*TargetRef{"target": {target}}}
+
+			err := (&EatHandlerFactory{}).handle(context.Background(), actor, in)
+			if tc.expErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+					t.Fatalf("error = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cur, _ := actor.Resource(assets.ResourceHunger); cur != tc.wantHunger {
+				t.Errorf("hunger = %d, want %d", cur, tc.wantHunger)
+			}
+			if gone := inv.Len() == 0; gone != tc.wantGone {
+				t.Errorf("food consumed = %v, want %v", gone, tc.wantGone)
+			}
+		})
+	}
+}
+
+func TestLiquidHandler(t *testing.T) {
+	skin := func(liquid string, sips int) *assets.Object {
+		return &assets.Object{Drink: &assets.Drink{Liquid: liquid, Capacity: 10, Sips: sips}}
+	}
+	fountain := &assets.Object{Flags: []string{"immobile"}, Drink: &assets.Drink{Liquid: "water", Infinite: true}}
+
+	tests := map[string]struct {
+		action     string
+		target     *assets.Object
+		other      *assets.Object // fill source or pour destination
+		wantSips   int
+		wantThirst int
+		expErr     string
+	}{
+		"drink takes a sip": {
+			action: liquidActionDrink, target: skin("water", 3),
+			wantSips: 2, wantThirst: game.SipQuench,
+		},
+		"drink from empty container": {
+			action: liquidActionDrink, target: skin("", 0),
+			expErr: "is empty",
+		},
+		"fill from fountain": {
+			action: liquidActionFill, target: skin("", 0), other: fountain,
+			wantSips: 10,
+		},
+		"fill with mismatched liquid": {
+			action: liquidActionFill, target: skin("ale", 2), other: fountain,
+			expErr: "already another liquid",
+		},
+		"fill without source": {
+			action: liquidActionFill, target: skin("", 0),
+			expErr: "from what?",
+		},
+		"pour out onto ground": {
+			action: liquidActionPour, target: skin("water", 4),
+			wantSips: 0,
+		},
+		"pour into fountain refused": {
+			action: liquidActionPour, target: skin("water", 4), other: fountain,
+			expErr: "can't pour anything into",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoom("r", "Room", "z")
+			inv := game.NewInventory()
+			target := newConsumable(t, inv, "skin", tc.target)
+			targets := map[string][]*TargetRef{"target": {target}}
+			if tc.other != nil {
+				ref := newConsumable(t, inv, "other", tc.other)
+				targets["source"] = []*TargetRef{ref}
+				targets["destination"] = []*TargetRef{ref}
+			}
+			actor := &gametest.BaseActor{
+				ActorId: "alice", ActorName: "Alice", ActorRoom: room,
+				Resources: map[string][2]int{assets.ResourceThirst: {0, 20}},
+			}
+			in := &CommandInput{Actor: actor, Targets: targets, Config: map[string]string{"action": tc.action}}
+
+			err := (&LiquidHandlerFactory{}).handle(context.Background(), actor, in)
+			if tc.expErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+					t.Fatalf("error = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := target.Obj.instance.Sips; got != tc.wantSips {
+				t.Errorf("sips = %d, want %d", got, tc.wantSips)
+			}
+			if cur, _ := actor.Resource(assets.ResourceThirst); cur != tc.wantThirst {
+				t.Errorf("thirst = %d, want %d", cur, tc.wantThirst)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// DuelActor provides the character state needed by the duel handler.
+type DuelActor interface {
+	Id() string
+	Name() string
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	HasGrant(key, arg string) bool
+	ChallengeDuel(target *game.CharacterInstance, peaceful bool) error
+	AcceptDuel() (*game.CharacterInstance, error)
+}
+
+var _ DuelActor = (*game.CharacterInstance)(nil)
+
+// DuelHandlerFactory creates handlers for the duel and accept commands.
+// When a target is resolved (duel command), the player challenges that
+// target to a duel. With action "accept" (accept command), the player
+// accepts the challenge made to them and the duel begins.
+//
+// Config:
+//   - action (optional): "accept"; empty challenges the target
+//   - ignore_peaceful (optional): "true" lets the duel be fought in a peaceful room
+type DuelHandlerFactory struct{}
+
+// NewDuelHandlerFactory creates a handler factory for the duel and accept commands.
+func NewDuelHandlerFactory() *DuelHandlerFactory {
+	return &DuelHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *DuelHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypePlayer, Required: false},
+		},
+		Config: []ConfigRequirement{
+			{Name: "action", Required: false},
+			{Name: "ignore_peaceful", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *DuelHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch strings.ToLower(config["action"]) {
+	case "", "accept":
+		return nil
+	default:
+		return fmt.Errorf("unknown duel action %q", config["action"])
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *DuelHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[DuelActor](f.handle), nil
+}
+
+func (f *DuelHandlerFactory) handle(ctx context.Context, char DuelActor, in *CommandInput) error {
+	if strings.ToLower(in.Config["action"]) == "accept" {
+		return f.accept(char)
+	}
+	target := in.FirstTarget("target")
+	if target == nil {
+		return NewUserError("Duel whom?")
+	}
+	return f.challenge(char, target, in.Config["ignore_peaceful"] == "true")
+}
+
+func (f *DuelHandlerFactory) challenge(char DuelActor, target *TargetRef, peaceful bool) error {
+	opp, ok := target.Actor.Actor().(*game.CharacterInstance)
+	if !ok {
+		return NewUserError("You can only duel other players.")
+	}
+	if !peaceful && char.Room().Restricts(char, assets.RoomFlagPeaceful) {
+		return errPeacefulArea
+	}
+
+	err := char.ChallengeDuel(opp, peaceful)
+	switch {
+	case errors.Is(err, game.ErrDuelSelf):
+		return NewUserError("You can't duel yourself.")
+	case errors.Is(err, game.ErrDuelBusy):
+		return NewUserError("You're already fighting!")
+	case errors.Is(err, game.ErrDuelOpponentBusy):
+		return NewUserError(fmt.Sprintf("%s is already fighting.", target.Actor.Name))
+	case err != nil:
+		return err
+	}
+
+	char.Publish([]byte(fmt.Sprintf("You challenge %s to a duel.", target.Actor.Name)), nil)
+	opp.Publish([]byte(fmt.Sprintf("%s challenges you to a duel! Type accept to fight.", char.Name())), nil)
+	return nil
+}
+
+func (f *DuelHandlerFactory) accept(char DuelActor) error {
+	opp, err := char.AcceptDuel()
+	switch {
+	case errors.Is(err, game.ErrNoDuelChallenge):
+		return NewUserError("No one has challenged you to a duel.")
+	case errors.Is(err, game.ErrDuelBusy):
+		return NewUserError("You're already fighting!")
+	case errors.Is(err, game.ErrDuelOpponentBusy):
+		return NewUserError(fmt.Sprintf("%s is already fighting.", opp.Name()))
+	case err != nil:
+		return err
+	}
+
+	char.Publish([]byte(fmt.Sprintf("You accept %s's challenge. The duel begins!", opp.Name())), nil)
+	opp.Publish([]byte(fmt.Sprintf("%s accepts your challenge. The duel begins!", char.Name())), nil)
+	char.Room().Publish([]byte(fmt.Sprintf("%s and %s begin a duel!", opp.Name(), char.Name())), []string{char.Id(), opp.Id()})
+	return nil
+}
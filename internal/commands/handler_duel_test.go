@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestDuelHandler(t *testing.T) {
+	tests := map[string]struct {
+		peaceful       bool
+		ignorePeaceful bool
+		self           bool
+		accept         bool // Bob accepts after Alice challenges
+		expErr         string
+		wantAliceMsg   string // substring of the last message Alice got
+		wantBobMsg     string // substring of the last message Bob got
+		wantWitnessMsg string // substring of the last message Carol got
+	}{
+		"challenge": {
+			wantAliceMsg: "You challenge Bob to a duel.",
+			wantBobMsg:   "Alice challenges you to a duel! Type accept to fight.",
+		},
+		"accept": {
+			accept:         true,
+			wantAliceMsg:   "Bob accepts your challenge. The duel begins!",
+			wantBobMsg:     "You accept Alice's challenge. The duel begins!",
+			wantWitnessMsg: "Alice and Bob begin a duel!",
+		},
+		"self": {
+			self:   true,
+			expErr: "You can't duel yourself.",
+		},
+		"peaceful room": {
+			peaceful: true,
+			expErr:   errPeacefulArea.Error(),
+		},
+		"peaceful room allowed": {
+			peaceful:       true,
+			ignorePeaceful: true,
+			wantBobMsg:     "Alice challenges you to a duel! Type accept to fight.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("yard", "Yard", "town")
+			alice, aliceMsgs := newRecordingPlayer("alice", "Alice", room)
+			bob, bobMsgs := newRecordingPlayer("bob", "Bob", room)
+			_, carolMsgs := newRecordingPlayer("carol", "Carol", room)
+			if tc.peaceful {
+				room.Perks.SetOwn([]assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagPeaceful)}})
+				alice.AddSource("room", room.Perks)
+			}
+			target := bob
+			if tc.self {
+				target = alice
+			}
+
+			config := map[string]string{}
+			if tc.ignorePeaceful {
+				config["ignore_peaceful"] = "true"
+			}
+			f := NewDuelHandlerFactory()
+			err := f.handle(context.Background(), alice, &CommandInput{
+				Targets: map[string][]*TargetRef{
+					"target": {{Type: targetTypeActor, Actor: actorRefFromPlayer(target)}},
+				},
+				Config: config,
+			})
+			if err == nil && tc.accept {
+				err = f.handle(context.Background(), bob, &CommandInput{
+					Config: map[string]string{"action": "accept"},
+				})
+			}
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for who, c := range map[string]struct {
+				msgs chan []byte
+				want string
+			}{
+				"Alice": {aliceMsgs, tc.wantAliceMsg},
+				"Bob":   {bobMsgs, tc.wantBobMsg},
+				"Carol": {carolMsgs, tc.wantWitnessMsg},
+			} {
+				if c.want == "" {
+					continue
+				}
+				got := drainAll(c.msgs)
+				if len(got) == 0 || !strings.Contains(got[len(got)-1], c.want) {
+					t.Errorf("%s messages = %q, want last to contain %q", who, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDuelHandler_acceptWithoutChallenge(t *testing.T) {
+	room, _ := newTestRoomInZone("yard", "Yard", "town")
+	bob, _ := newRecordingPlayer("bob", "Bob", room)
+
+	err := NewDuelHandlerFactory().handle(context.Background(), bob, &CommandInput{
+		Config: map[string]string{"action": "accept"},
+	})
+
+	want := "No one has challenged you to a duel."
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
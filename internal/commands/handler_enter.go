@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// EnterHandlerFactory creates handlers that step through portal objects to
+// the room they lead to. Followers come along. A portal with charges spends
+// one per trip and fades away once they run out.
+//
+// Targets:
+//   - target (required): the portal object in the room
+type EnterHandlerFactory struct{}
+
+// NewEnterHandlerFactory creates a new EnterHandlerFactory.
+func NewEnterHandlerFactory() *EnterHandlerFactory {
+	return &EnterHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *EnterHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *EnterHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *EnterHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[game.Actor](f.handle), nil
+}
+
+func (f *EnterHandlerFactory) handle(ctx context.Context, actor game.Actor, in *CommandInput) error {
+	target := in.FirstTarget("target")
+	if target == nil || target.Obj == nil {
+		return NewUserError("Enter what?")
+	}
+	oi := target.Obj.instance
+	portal := oi.Object.Get().Portal
+	if portal == nil {
+		return NewUserError(fmt.Sprintf("You can't enter %s.", target.Obj.Name))
+	}
+	if actor.IsInCombat() {
+		return NewUserError("You can't do that while fighting!")
+	}
+
+	from := actor.Room()
+	if from == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+	zoneId := portal.Zone.Id()
+	if zoneId == "" {
+		zoneId = from.Room.Get().Zone.Id()
+	}
+	to := from.Zone().World().GetRoom(zoneId, portal.Room.Id())
+	if to == nil {
+		slog.Warn("portal leads nowhere", "object", oi.Object.Id(), "zone", zoneId, "room", portal.Room.Id())
+		return NewUserError(fmt.Sprintf("%s leads nowhere.", display.Capitalize(target.Obj.Name)))
+	}
+	if to.Restricts(actor, assets.RoomFlagSingleOccupant) && to.PlayerCount() >= 1 {
+		return NewUserError("There isn't enough room for you on the other side.")
+	}
+
+	if oi.Object.Get().Charges > 0 {
+		left, ok := oi.UseCharge()
+		if !ok {
+			return NewUserError(fmt.Sprintf("%s fades away before you can enter it.", display.Capitalize(target.Obj.Name)))
+		}
+		if left == 0 {
+			defer func() {
+				from.RemoveObj(oi.InstanceId)
+				from.Publish([]byte(fmt.Sprintf("%s shimmers and fades away.", display.Capitalize(target.Obj.Name))), nil)
+			}()
+		}
+	}
+
+	name := display.Capitalize(actor.Name())
+	relocate(actor, from, to,
+		fmt.Sprintf("%s steps into %s and vanishes.", name, target.Obj.Name),
+		fmt.Sprintf("%s steps out of thin air.", name))
+	actor.Publish([]byte(fmt.Sprintf("You step into %s.\n%s", target.Obj.Name, DescribeRoom(actor, to))), nil)
+	game.FireArrival(ctx, actor, to)
+	moveFollowers(ctx, actor, from, to, "through "+target.Obj.Name)
+	return nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestEnterHandler(t *testing.T) {
+	tests := map[string]struct {
+		portal    *assets.Portal
+		charges   int
+		expErr    bool
+		wantRoom  string
+		wantFaded bool
+	}{
+		"portal carries the actor across": {
+			portal:   &assets.Portal{Room: storage.NewSmartIdentifier[*assets.Room]("grove")},
+			wantRoom: "grove",
+		},
+		"portal into another zone": {
+			portal: &assets.Portal{
+				Zone: storage.NewSmartIdentifier[*assets.Zone]("wilds"),
+				Room: storage.NewSmartIdentifier[*assets.Room]("cave"),
+			},
+			wantRoom: "cave",
+		},
+		"last charge fades the portal": {
+			portal:    &assets.Portal{Room: storage.NewSmartIdentifier[*assets.Room]("grove")},
+			charges:   1,
+			wantRoom:  "grove",
+			wantFaded: true,
+		},
+		"charges left keep the portal": {
+			portal:   &assets.Portal{Room: storage.NewSmartIdentifier[*assets.Room]("grove")},
+			charges:  2,
+			wantRoom: "grove",
+		},
+		"portal to nowhere": {
+			portal:   &assets.Portal{Room: storage.NewSmartIdentifier[*assets.Room]("void")},
+			expErr:   true,
+			wantRoom: "circle",
+		},
+		"not a portal": {
+			expErr:   true,
+			wantRoom: "circle",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wood := storage.NewResolvedSmartIdentifier("wood", &assets.Zone{ResetMode: assets.ZoneResetNever})
+			wilds := storage.NewResolvedSmartIdentifier("wilds", &assets.Zone{ResetMode: assets.ZoneResetNever})
+			world, err := newTestWorldWithRooms(map[string]*assets.Room{
+				"circle": {Name: "Stone Circle", Zone: wood},
+				"grove":  {Name: "Grove", Zone: wood},
+				"cave":   {Name: "Cave", Zone: wilds},
+			})
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			circle := world.GetRoom("wood", "circle")
+			ci := newTestPlayer("p", "Walker", circle)
+
+			oi, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier("gate", &assets.Object{
+				Aliases: []string{"moongate"}, ShortDesc: "a shimmering moongate",
+				Flags: []string{"immobile"}, Portal: tc.portal, Charges: tc.charges,
+			}))
+			if err != nil {
+				t.Fatalf("NewObjectInstance: %v", err)
+			}
+			circle.AddObj(oi)
+
+			in := &CommandInput{
+				Actor:   ci,
+				Targets: map[string][]*TargetRef{"target": {{Type: targetTypeObject, Obj: objRefFromInstance(oi, circle)}}},
+			}
+			err = (&EnterHandlerFactory{}).handle(context.Background(), ci, in)
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if got := ci.Room().Room.Id(); got != tc.wantRoom {
+				t.Errorf("actor in %q, want %q", got, tc.wantRoom)
+			}
+			left := circle.FindObjs(func(o *game.ObjectInstance) bool { return o == oi })
+			if faded := len(left) == 0; faded != tc.wantFaded {
+				t.Errorf("portal faded = %v, want %v", faded, tc.wantFaded)
+			}
+		})
+	}
+}
@@ -79,7 +79,7 @@ func FormatEquipmentSlots(eq *game.Equipment, slots []string) []string {
 				if item.Slot == slot {
 					count++
 					if count == slotSeen[slot] {
-						desc = item.Obj.Object.Get().ShortDesc
+						desc = item.Obj.ShortDesc()
 					}
 				}
 			})
@@ -97,7 +97,7 @@ func FormatEquippedItems(eq *game.Equipment) []string {
 	}
 	var lines []string
 	eq.ForEachSlot(func(item game.EquipSlot) {
-		lines = append(lines, formatSlotLine(item.Slot, item.Obj.Object.Get().ShortDesc))
+		lines = append(lines, formatSlotLine(item.Slot, item.Obj.ShortDesc()))
 	})
 	return lines
 }
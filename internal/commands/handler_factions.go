@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// FactionsActor provides the character state needed by the factions handler.
+type FactionsActor interface {
+	Publish(data []byte, exclude []string)
+	Standings() map[string]int
+}
+
+var _ FactionsActor = (*game.CharacterInstance)(nil)
+
+// FactionsHandlerFactory creates handlers that list a character's standing
+// with the factions they have had dealings with.
+type FactionsHandlerFactory struct {
+	factions storage.Storer[*assets.Faction]
+}
+
+// NewFactionsHandlerFactory creates a handler factory for the factions command.
+func NewFactionsHandlerFactory(factions storage.Storer[*assets.Faction]) *FactionsHandlerFactory {
+	return &FactionsHandlerFactory{factions: factions}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *FactionsHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *FactionsHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *FactionsHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[FactionsActor](f.handle), nil
+}
+
+func (f *FactionsHandlerFactory) handle(ctx context.Context, char FactionsActor, in *CommandInput) error {
+	type line struct {
+		name     string
+		standing int
+	}
+	var lines []line
+	for id, standing := range char.Standings() {
+		if def := f.factions.Get(id); def != nil {
+			lines = append(lines, line{name: display.Capitalize(def.Name), standing: standing})
+		}
+	}
+	if len(lines) == 0 {
+		return NewUserError("You have had no dealings with any faction.")
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].name < lines[j].name })
+
+	var sb strings.Builder
+	sb.WriteString("Your standing:")
+	for _, l := range lines {
+		fmt.Fprintf(&sb, "\n  %-30s %-10s (%d)", l.name, assets.StandingTier(l.standing), l.standing)
+	}
+	char.Publish([]byte(sb.String()), nil)
+	return nil
+}
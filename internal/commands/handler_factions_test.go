@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestFactionsHandler(t *testing.T) {
+	store := memStore[*assets.Faction]{
+		"town": {Name: "the town"},
+		"band": {Name: "the band"},
+	}
+	tests := map[string]struct {
+		standings map[string]int
+		expErr    string
+		wantMsg   string
+	}{
+		"sorted by name with tiers": {
+			standings: map[string]int{"town": 120, "band": -350},
+			wantMsg:   "Your standing:\n  The band                       hostile    (-350)\n  The town                       friendly   (120)",
+		},
+		"unknown factions are skipped": {
+			standings: map[string]int{"town": 0, "gone": 500},
+			wantMsg:   "Your standing:\n  The town                       neutral    (0)",
+		},
+		"no dealings": {
+			expErr: "You have had no dealings with any faction.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("inn", "Inn", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			for id, v := range tc.standings {
+				bob.AdjustStanding(storage.NewResolvedSmartIdentifier(id, store.Get(id)), v)
+			}
+
+			err = NewFactionsHandlerFactory(store).handle(context.Background(), bob, &CommandInput{})
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := drainAll(msgs)
+			if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantMsg) {
+				t.Errorf("messages = %q, want last to contain %q", got, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// FleeActor provides the actor state needed by the flee handler.
+type FleeActor interface {
+	game.Actor
+	Disengage() []game.Actor
+}
+
+var _ FleeActor = (*game.CharacterInstance)(nil)
+
+// FleeHandlerFactory creates handlers that break off a fight by running out
+// of a random exit. Enemies drop the fight, but mobs with the memory flag
+// hunt the one who fled.
+type FleeHandlerFactory struct{}
+
+// NewFleeHandlerFactory creates a new FleeHandlerFactory.
+func NewFleeHandlerFactory() *FleeHandlerFactory {
+	return &FleeHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *FleeHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *FleeHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *FleeHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[FleeActor](f.handle), nil
+}
+
+func (f *FleeHandlerFactory) handle(ctx context.Context, actor FleeActor, in *CommandInput) error {
+	if !actor.IsInCombat() {
+		return NewUserError("You aren't fighting anyone.")
+	}
+	fromRoom := actor.Room()
+	if fromRoom == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+
+	var exits []string
+	fromRoom.ForEachExit(func(dir string, re *game.ResolvedExit) {
+		if fromRoom.CanWalk(actor, dir) {
+			exits = append(exits, dir)
+		}
+	})
+	if len(exits) == 0 {
+		return NewUserError("PANIC! You couldn't escape!")
+	}
+	direction := exits[rand.IntN(len(exits))]
+	_, re := fromRoom.FindExit(direction)
+	toRoom := re.Dest
+
+	if !spendMove(actor, toRoom) {
+		return NewUserError("You are too exhausted to flee!")
+	}
+
+	for _, enemy := range actor.Disengage() {
+		if mi, ok := enemy.(*game.MobileInstance); ok && mi.Mobile.Get().HasFlag(assets.MobileFlagMemory) {
+			mi.Hunt(actor)
+		}
+	}
+
+	announceToRoom(fromRoom, actor, fmt.Sprintf("%s panics, and flees %s!", display.Capitalize(actor.Name()), direction))
+	actor.Move(fromRoom, toRoom)
+	announceArrive(actor, toRoom)
+	actor.Publish([]byte(fmt.Sprintf("You flee head over heels.\n%s", DescribeRoom(actor, toRoom))), nil)
+	game.FireArrival(ctx, actor, toRoom)
+	return nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestFleeHandler(t *testing.T) {
+	exit := func(room string) assets.Exit {
+		return assets.Exit{Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	town := storage.NewResolvedSmartIdentifier("town", &assets.Zone{ResetMode: assets.ZoneResetNever})
+
+	tests := map[string]struct {
+		exits      map[string]assets.Exit
+		fighting   bool
+		memory     bool
+		expErr     bool
+		wantRoom   string
+		wantHunted bool
+	}{
+		"not fighting": {
+			exits:    map[string]assets.Exit{"north": exit("street")},
+			expErr:   true,
+			wantRoom: "arena",
+		},
+		"nowhere to run": {
+			fighting: true,
+			expErr:   true,
+			wantRoom: "arena",
+		},
+		"flees through the only exit": {
+			exits:    map[string]assets.Exit{"north": exit("street")},
+			fighting: true,
+			wantRoom: "street",
+		},
+		"memory mob hunts the fleer": {
+			exits:      map[string]assets.Exit{"north": exit("street")},
+			fighting:   true,
+			memory:     true,
+			wantRoom:   "street",
+			wantHunted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			world, err := newTestWorldWithRooms(map[string]*assets.Room{
+				"arena":  {Name: "Arena", Zone: town, Exits: tc.exits},
+				"street": {Name: "Street", Zone: town},
+			})
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			arena := world.GetZone("town").GetRoom("arena")
+			ci := newTestPlayer("p", "Runner", arena)
+
+			mob := newCombatMob("ogre", "an ogre")
+			if tc.memory {
+				mob.Mobile.Get().Flags = []string{"memory"}
+			}
+			arena.AddMob(mob)
+			if tc.fighting {
+				ci.EnsureThreat(mob.Id(), mob)
+				mob.EnsureThreat(ci.Id(), ci)
+			}
+
+			err = (&FleeHandlerFactory{}).handle(context.Background(), ci, &CommandInput{Actor: ci})
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if got := ci.Room().Room.Id(); got != tc.wantRoom {
+				t.Errorf("room = %q, want %q", got, tc.wantRoom)
+			}
+			if err == nil {
+				if ci.IsInCombat() || mob.IsInCombat() {
+					t.Errorf("still in combat after fleeing: player %v, mob %v", ci.IsInCombat(), mob.IsInCombat())
+				}
+			}
+			if hunted := mob.Hunting() == game.Actor(ci); hunted != tc.wantHunted {
+				t.Errorf("hunting = %v, want %v", hunted, tc.wantHunted)
+			}
+		})
+	}
+}
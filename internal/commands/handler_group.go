@@ -48,6 +48,8 @@ func unfollowActor(actor game.Actor) {
 func leaveGroup(actor game.Actor, leader game.Actor) {
 	leader.SetFollowerGrouped(actor.Id(), false)
 	actor.SetFollowing(nil)
+	clearRank(actor)
+	clearRank(leader)
 
 	actor.Publish([]byte("You leave the group."), nil)
 	game.GroupPublishTarget(leader).Publish([]byte(fmt.Sprintf("%s has left the group.", actor.Name())), nil)
@@ -64,8 +66,10 @@ func disbandGroup(leader game.Actor) {
 	for _, ft := range leader.GroupedFollowers() {
 		leader.SetFollowerGrouped(ft.Id(), false)
 		ft.SetFollowing(nil)
+		clearRank(ft)
 		ft.Publish(disbandedMsg, nil)
 	}
+	clearRank(leader)
 	leader.Publish([]byte("You have disbanded the group."), nil)
 }
 
@@ -134,7 +138,7 @@ func (f *GroupHandlerFactory) showGroup(char game.Actor) error {
 	var entries []entry
 	var walk func(actor game.Actor, label, prefix, childPrefix string)
 	walk = func(actor game.Actor, label, prefix, childPrefix string) {
-		entries = append(entries, entry{actor: actor, label: label, indent: prefix})
+		entries = append(entries, entry{actor: actor, label: rankLabel(actor, label), indent: prefix})
 		followers := actor.GroupedFollowers()
 		for i, f := range followers {
 			isLast := i == len(followers)-1
@@ -150,7 +154,7 @@ func (f *GroupHandlerFactory) showGroup(char game.Actor) error {
 		}
 	}
 
-	entries = append(entries, entry{actor: leader, label: "(Leader)"})
+	entries = append(entries, entry{actor: leader, label: rankLabel(leader, "(Leader)")})
 	for _, ft := range leader.GroupedFollowers() {
 		walk(ft, "", "", "")
 	}
@@ -200,10 +204,21 @@ func (f *GroupHandlerFactory) showGroup(char game.Actor) error {
 		line := fmt.Sprintf("%-*s  %s", maxNameWidth, name, strings.Join(resParts, " | "))
 		lines = append(lines, line)
 	}
+	if ll, ok := leader.(LootActor); ok {
+		lines = append(lines, fmt.Sprintf("Loot mode: %s.", lootModeNames[ll.LootMode()]))
+	}
 	char.Publish([]byte(strings.Join(lines, "\n")), nil)
 	return nil
 }
 
+// rankLabel adds "(Back)" to label for a member in the back rank.
+func rankLabel(actor game.Actor, label string) string {
+	if !game.InBackRank(actor) {
+		return label
+	}
+	return strings.TrimSpace(label + " (Back)")
+}
+
 func (f *GroupHandlerFactory) toggleMember(char game.Actor, target *TargetRef) error {
 	actorId := char.Id()
 	targetActor := target.Actor.Actor()
@@ -324,6 +339,8 @@ func (f *UngroupHandlerFactory) removeTarget(char game.Actor, target *TargetRef)
 
 	char.SetFollowerGrouped(targetId, false)
 	targetActor.SetFollowing(nil)
+	clearRank(targetActor)
+	clearRank(char)
 
 	targetActor.Publish([]byte(fmt.Sprintf("You have been removed from the group by %s.", char.Name())), nil)
 	game.GroupPublishTarget(char).Publish([]byte(fmt.Sprintf("%s has been removed from the group.", target.Actor.Name)), nil)
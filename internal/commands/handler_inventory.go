@@ -17,6 +17,15 @@ type InventoryActor interface {
 
 var _ InventoryActor = (*game.CharacterInstance)(nil)
 
+// loadActor is implemented by actors that track the weight they carry.
+type loadActor interface {
+	CarriedWeight() int
+	CarryLimit() int
+	IsEncumbered() bool
+}
+
+var _ loadActor = (*game.CharacterInstance)(nil)
+
 // InventoryHandlerFactory creates handlers that list the player's inventory.
 type InventoryHandlerFactory struct{}
 
@@ -43,6 +52,13 @@ func (f *InventoryHandlerFactory) Create() (CommandFunc, error) {
 func (f *InventoryHandlerFactory) handle(ctx context.Context, char InventoryActor, in *CommandInput) error {
 	lines := []string{"You are carrying:"}
 	lines = append(lines, FormatInventoryItems(char.Inventory())...)
+	if load, ok := char.(loadActor); ok {
+		total := fmt.Sprintf("Weight carried: %d/%d", load.CarriedWeight(), load.CarryLimit())
+		if load.IsEncumbered() {
+			total += " (encumbered)"
+		}
+		lines = append(lines, "", total)
+	}
 
 	char.Publish([]byte(strings.Join(lines, "\n")), nil)
 	return nil
@@ -56,7 +72,7 @@ func FormatInventoryItems(inv *game.Inventory) []string {
 	}
 	var lines []string
 	inv.ForEachObj(func(_ string, oi *game.ObjectInstance) {
-		lines = append(lines, fmt.Sprintf("  %s", oi.Object.Get().ShortDesc))
+		lines = append(lines, fmt.Sprintf("  %s", oi.ShortDesc()))
 	})
 	if len(lines) == 0 {
 		return []string{"  Nothing"}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// LeverActor provides the character state needed by the lever handler.
+type LeverActor interface {
+	Id() string
+	Name() string
+	Publish(data []byte, exclude []string)
+	Room() *game.RoomInstance
+}
+
+var _ LeverActor = (*game.CharacterInstance)(nil)
+
+// LeverHandlerFactory creates handlers that pull levers to reveal hidden
+// exits. The exit is revealed to everyone until the zone resets.
+//
+// Targets:
+//   - target (required): the lever object in the room
+type LeverHandlerFactory struct{}
+
+// NewLeverHandlerFactory creates a new LeverHandlerFactory.
+func NewLeverHandlerFactory() *LeverHandlerFactory {
+	return &LeverHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *LeverHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *LeverHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *LeverHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[LeverActor](f.handle), nil
+}
+
+func (f *LeverHandlerFactory) handle(ctx context.Context, char LeverActor, in *CommandInput) error {
+	target := in.FirstTarget("target")
+	if target == nil || target.Obj == nil {
+		return NewUserError("Pull what?")
+	}
+	lever := target.Obj.instance.Object.Get().Lever
+	if lever == nil {
+		return NewUserError(fmt.Sprintf("You can't pull %s.", target.Obj.Name))
+	}
+
+	room := char.Room()
+	char.Publish([]byte(fmt.Sprintf("You pull %s.", target.Obj.Name)), nil)
+	room.Publish([]byte(fmt.Sprintf("%s pulls %s.", char.Name(), target.Obj.Name)), []string{char.Id()})
+
+	switch {
+	case room.ExitVisible(nil, lever.Exit):
+		// Already revealed to everyone.
+		char.Publish([]byte("Nothing happens."), nil)
+		return nil
+	case !room.RevealExit(lever.Exit, nil):
+		slog.Warn("lever names no hidden exit", "object", target.Obj.instance.Object.Id(), "room", room.Room.Id(), "exit", lever.Exit)
+		char.Publish([]byte("Nothing happens."), nil)
+		return nil
+	}
+
+	msg := lever.Message
+	if msg == "" {
+		msg = fmt.Sprintf("With a grinding of stone, a hidden passage opens leading %s!", lever.Exit)
+	}
+	room.Publish([]byte(msg), nil)
+	return nil
+}
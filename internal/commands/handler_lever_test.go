@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestLeverHandler(t *testing.T) {
+	tests := map[string]struct {
+		lever       *assets.Lever
+		expErr      bool
+		wantVisible bool
+	}{
+		"lever reveals the exit": {
+			lever:       &assets.Lever{Exit: "down"},
+			wantVisible: true,
+		},
+		"lever for a missing exit does nothing": {
+			lever: &assets.Lever{Exit: "up"},
+		},
+		"not a lever": {
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := game.NewRoomInstance(storage.NewResolvedSmartIdentifier("cellar", &assets.Room{
+				Name:  "Cellar",
+				Zone:  storage.NewResolvedSmartIdentifier("z", &assets.Zone{ResetMode: assets.ZoneResetNever}),
+				Exits: map[string]assets.Exit{"down": {Hidden: &assets.HiddenExit{Duration: 10}}},
+			}))
+			if err != nil {
+				t.Fatalf("NewRoomInstance: %v", err)
+			}
+			ci := newTestPlayer("p", "Puller", room)
+			oi, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier("knob", &assets.Object{
+				Aliases: []string{"knob"}, ShortDesc: "a stone knob", Flags: []string{"immobile"}, Lever: tc.lever,
+			}))
+			if err != nil {
+				t.Fatalf("NewObjectInstance: %v", err)
+			}
+			room.AddObj(oi)
+
+			in := &CommandInput{
+				Actor:   ci,
+				Targets: map[string][]*TargetRef{"target": {{Type: targetTypeObject, Obj: objRefFromInstance(oi, room)}}},
+			}
+			err = (&LeverHandlerFactory{}).handle(context.Background(), ci, in)
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if got := room.ExitVisible(nil, "down"); got != tc.wantVisible {
+				t.Errorf("exit visible = %v, want %v", got, tc.wantVisible)
+			}
+		})
+	}
+}
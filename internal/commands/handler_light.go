@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Light actions drive the light handler's dispatch.
+// Commands declare one of these as the `action` config field.
+const (
+	lightActionLight      = "light"
+	lightActionExtinguish = "extinguish"
+)
+
+// LightActor provides the character state needed by the light handler.
+type LightActor interface {
+	Id() string
+	Name() string
+	Publish(data []byte, exclude []string)
+	Room() *game.RoomInstance
+}
+
+var _ LightActor = (*game.CharacterInstance)(nil)
+
+// LightHandlerFactory creates handlers that light and extinguish light sources.
+// Config:
+//   - action (required): "light" or "extinguish"
+//
+// Targets:
+//   - target (required): the light source object
+type LightHandlerFactory struct{}
+
+// NewLightHandlerFactory creates a handler factory for light/extinguish commands.
+func NewLightHandlerFactory() *LightHandlerFactory {
+	return &LightHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *LightHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeObject, Required: true},
+		},
+		Config: []ConfigRequirement{
+			{Name: "action", Required: true},
+		},
+	}
+}
+
+// ValidateConfig checks that action is light or extinguish.
+func (f *LightHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch config["action"] {
+	case lightActionLight, lightActionExtinguish:
+		return nil
+	default:
+		return errors.New("action must be light or extinguish")
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *LightHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[LightActor](f.handle), nil
+}
+
+func (f *LightHandlerFactory) handle(ctx context.Context, char LightActor, in *CommandInput) error {
+	action := in.Config["action"]
+	target := in.FirstTarget("target")
+	if target == nil || target.Obj == nil {
+		return NewUserError(fmt.Sprintf("%s what?", display.Capitalize(action)))
+	}
+	oi := target.Obj.instance
+	if oi.Object.Get().Light == nil {
+		return NewUserError(fmt.Sprintf("You can't %s %s.", action, target.Obj.Name))
+	}
+
+	switch action {
+	case lightActionLight:
+		if oi.BurnedOut() {
+			return NewUserError(fmt.Sprintf("%s is burned out.", display.Capitalize(target.Obj.Name)))
+		}
+		if !oi.Ignite() {
+			return NewUserError(fmt.Sprintf("%s is already lit.", display.Capitalize(target.Obj.Name)))
+		}
+	case lightActionExtinguish:
+		if !oi.Extinguish() {
+			return NewUserError(fmt.Sprintf("%s isn't lit.", display.Capitalize(target.Obj.Name)))
+		}
+	}
+
+	room := char.Room()
+	room.RefreshLight()
+
+	verb := action + "s"
+	if action == lightActionExtinguish {
+		verb = "extinguishes"
+	}
+	char.Publish([]byte(fmt.Sprintf("You %s %s.", action, target.Obj.Name)), nil)
+	room.Publish([]byte(fmt.Sprintf("%s %s %s.", char.Name(), verb, target.Obj.Name)), []string{char.Id()})
+	return nil
+}
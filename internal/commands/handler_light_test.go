@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/gametest"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestLightHandler(t *testing.T) {
+	tests := map[string]struct {
+		action  string
+		obj     *assets.Object
+		setup   func(oi *game.ObjectInstance)
+		wantLit bool
+		expErr  string
+	}{
+		"light a torch": {
+			action:  lightActionLight,
+			obj:     &assets.Object{Light: &assets.Light{Burn: 10}},
+			wantLit: true,
+		},
+		"extinguish a lit torch": {
+			action:  lightActionExtinguish,
+			obj:     &assets.Object{Light: &assets.Light{Burn: 10}},
+			setup:   func(oi *game.ObjectInstance) { oi.Ignite() },
+			wantLit: false,
+		},
+		"non-light refused": {
+			action: lightActionLight,
+			obj:    &assets.Object{},
+			expErr: "You can't light a torch.",
+		},
+		"already lit": {
+			action: lightActionLight,
+			obj:    &assets.Object{Light: &assets.Light{}},
+			setup:  func(oi *game.ObjectInstance) { oi.Ignite() },
+			expErr: "A torch is already lit.",
+		},
+		"burned out": {
+			action: lightActionLight,
+			obj:    &assets.Object{Light: &assets.Light{Burn: 1}},
+			setup: func(oi *game.ObjectInstance) {
+				oi.Ignite()
+				oi.Tick()
+			},
+			expErr: "A torch is burned out.",
+		},
+		"extinguish unlit": {
+			action: lightActionExtinguish,
+			obj:    &assets.Object{Light: &assets.Light{Burn: 10}},
+			expErr: "A torch isn't lit.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoom("r", "Room", "z")
+			tc.obj.Aliases = []string{"torch"}
+			tc.obj.ShortDesc = "a torch"
+			oi, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier("torch", tc.obj))
+			if err != nil {
+				t.Fatalf("NewObjectInstance: %v", err)
+			}
+			if tc.setup != nil {
+				tc.setup(oi)
+			}
+			room.AddObj(oi)
+			room.RefreshLight()
+
+			actor := &gametest.BaseActor{ActorId: "alice", ActorName: "Alice", ActorRoom: room}
+			target := &TargetRef{Type: targetTypeObject, Obj: objRefFromInstance(oi, room)}
+			in := &CommandInput{
+				Actor:   actor,
+				Config:  map[string]string{"action": tc.action},
+				Targets: map[string][]*TargetRef{"target": {target}},
+			}
+
+			err = (&LightHandlerFactory{}).handle(context.Background(), actor, in)
+			if tc.expErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expErr) {
+					t.Fatalf("error = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if oi.Lit != tc.wantLit {
+				t.Errorf("Lit = %v, want %v", oi.Lit, tc.wantLit)
+			}
+			if room.IsLit() != tc.wantLit {
+				t.Errorf("room IsLit() = %v, want %v", room.IsLit(), tc.wantLit)
+			}
+		})
+	}
+}
@@ -52,14 +52,11 @@ func (f *LookHandlerFactory) Create() (CommandFunc, error) {
 const darkRoomDesc = "It is pitch black..."
 
 // DescribeRoom returns a visibility-aware room description for the actor.
-func DescribeRoom(actor interface {
-	Name() string
-	HasGrant(key, arg string) bool
-}, room *game.RoomInstance) string {
+func DescribeRoom(actor game.Viewer, room *game.RoomInstance) string {
 	if room.Restricts(actor, assets.RoomFlagDark) {
 		return darkRoomDesc
 	}
-	return room.Describe(actor.Name())
+	return room.Describe(actor)
 }
 
 func (f *LookHandlerFactory) handle(ctx context.Context, actor LookActor, in *CommandInput) error {
@@ -81,7 +78,7 @@ func (f *LookHandlerFactory) handle(ctx context.Context, actor LookActor, in *Co
 		return f.showExtraDesc(actor, ri, input)
 	}
 
-	actor.Publish([]byte(ri.Describe(actor.Name())), nil)
+	actor.Publish([]byte(ri.Describe(actor)), nil)
 	return nil
 }
 
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// LootActor provides the character state needed by the loot handler.
+type LootActor interface {
+	Id() string
+	Name() string
+	Publish(data []byte, exclude []string)
+	LootMode() assets.LootMode
+	SetLootMode(mode assets.LootMode)
+	AutoLoot() bool
+	SetAutoLoot(on bool)
+	AutoSplit() bool
+	SetAutoSplit(on bool)
+}
+
+var _ LootActor = (*game.CharacterInstance)(nil)
+
+// lootModeNames are the loot modes as players see them.
+var lootModeNames = map[assets.LootMode]string{
+	assets.LootModeFreeForAll: "free-for-all",
+	assets.LootModeRoundRobin: "round-robin",
+	assets.LootModeLeader:     "leader only",
+}
+
+// LootHandlerFactory creates handlers for the loot, autoloot and autosplit
+// commands. With no pref (loot command), the player shows their loot
+// settings or, as a group leader, sets the group's loot mode. With a pref
+// (autoloot, autosplit commands), the player turns that preference on or
+// off.
+//
+// Config:
+//   - pref (optional): "autoloot" or "autosplit"
+//   - mode (optional): loot mode to set, for the loot command
+//   - setting (optional): "on" or "off" for a pref; empty toggles it
+type LootHandlerFactory struct{}
+
+// NewLootHandlerFactory creates a handler factory for the loot commands.
+func NewLootHandlerFactory() *LootHandlerFactory {
+	return &LootHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *LootHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "pref", Required: false},
+			{Name: "mode", Required: false},
+			{Name: "setting", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *LootHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch config["pref"] {
+	case "", "autoloot", "autosplit":
+		return nil
+	default:
+		return fmt.Errorf("unknown loot pref %q", config["pref"])
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *LootHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[LootActor](f.handle), nil
+}
+
+func (f *LootHandlerFactory) handle(ctx context.Context, char LootActor, in *CommandInput) error {
+	switch in.Config["pref"] {
+	case "autoloot":
+		return f.toggle(char, "Auto-loot", char.AutoLoot(), char.SetAutoLoot, in.Config["setting"])
+	case "autosplit":
+		return f.toggle(char, "Auto-split", char.AutoSplit(), char.SetAutoSplit, in.Config["setting"])
+	}
+
+	arg := strings.ReplaceAll(strings.ToLower(in.Config["mode"]), "-", "")
+	if arg == "" {
+		char.Publish([]byte(fmt.Sprintf("Loot mode: %s. Auto-loot: %s. Auto-split: %s.",
+			lootModeNames[char.LootMode()], onOff(char.AutoLoot()), onOff(char.AutoSplit()))), nil)
+		return nil
+	}
+	mode := assets.LootMode(arg)
+	if !slices.Contains(assets.LootModes, mode) {
+		return NewUserError("Usage: loot [ffa | roundrobin | leader]")
+	}
+	leader := game.GroupLeader(in.Actor)
+	if leader != nil && leader.Id() != char.Id() {
+		return NewUserError("Only the group leader can set the loot mode.")
+	}
+
+	char.SetLootMode(mode)
+	char.Publish([]byte(fmt.Sprintf("You set the loot mode to %s.", lootModeNames[mode])), nil)
+	if leader != nil {
+		game.GroupPublishTarget(leader).Publish([]byte(fmt.Sprintf("%s sets the loot mode to %s.", char.Name(), lootModeNames[mode])), []string{char.Id()})
+	}
+	return nil
+}
+
+func (f *LootHandlerFactory) toggle(char LootActor, label string, cur bool, set func(bool), setting string) error {
+	on := !cur
+	switch strings.ToLower(setting) {
+	case "":
+	case "on":
+		on = true
+	case "off":
+		on = false
+	default:
+		return NewUserError(fmt.Sprintf("Usage: %s [on | off]", strings.ToLower(strings.ReplaceAll(label, "-", ""))))
+	}
+	set(on)
+	char.Publish([]byte(fmt.Sprintf("%s is now %s.", label, onOff(on))), nil)
+	return nil
+}
+
+// onOff renders a setting as "on" or "off".
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
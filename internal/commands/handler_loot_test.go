@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestLootHandler(t *testing.T) {
+	tests := map[string]struct {
+		config        map[string]string
+		grouped       bool
+		autoLoot      bool
+		expErr        string
+		wantMsg       string // substring of the last message Bob got
+		wantMode      assets.LootMode
+		wantAutoLoot  bool
+		wantAutoSplit bool
+	}{
+		"status": {
+			config:   map[string]string{},
+			wantMsg:  "Loot mode: free-for-all. Auto-loot: off. Auto-split: off.",
+			wantMode: assets.LootModeFreeForAll,
+		},
+		"set mode": {
+			config:   map[string]string{"mode": "Round-Robin"},
+			wantMsg:  "You set the loot mode to round-robin.",
+			wantMode: assets.LootModeRoundRobin,
+		},
+		"unknown mode": {
+			config:   map[string]string{"mode": "dice"},
+			expErr:   "Usage: loot [ffa | roundrobin | leader]",
+			wantMode: assets.LootModeFreeForAll,
+		},
+		"not the leader": {
+			config:   map[string]string{"mode": "leader"},
+			grouped:  true,
+			expErr:   "Only the group leader can set the loot mode.",
+			wantMode: assets.LootModeFreeForAll,
+		},
+		"toggle auto-loot": {
+			config:       map[string]string{"pref": "autoloot"},
+			wantMsg:      "Auto-loot is now on.",
+			wantMode:     assets.LootModeFreeForAll,
+			wantAutoLoot: true,
+		},
+		"auto-loot off": {
+			config:   map[string]string{"pref": "autoloot", "setting": "off"},
+			autoLoot: true,
+			wantMsg:  "Auto-loot is now off.",
+			wantMode: assets.LootModeFreeForAll,
+		},
+		"auto-split on": {
+			config:        map[string]string{"pref": "autosplit", "setting": "on"},
+			wantMsg:       "Auto-split is now on.",
+			wantMode:      assets.LootModeFreeForAll,
+			wantAutoSplit: true,
+		},
+		"bad setting": {
+			config:   map[string]string{"pref": "autosplit", "setting": "maybe"},
+			expErr:   "Usage: autosplit [on | off]",
+			wantMode: assets.LootModeFreeForAll,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("yard", "Yard", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			bob.SetAutoLoot(tc.autoLoot)
+			if tc.grouped {
+				alice, _ := newRecordingPlayer("alice", "Alice", room)
+				bob.SetFollowing(alice)
+				alice.AddFollower(bob)
+				alice.SetFollowerGrouped(bob.Id(), true)
+			}
+
+			err = NewLootHandlerFactory().handle(context.Background(), bob, &CommandInput{
+				Actor:  bob,
+				Config: tc.config,
+			})
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got := drainAll(msgs)
+				if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantMsg) {
+					t.Errorf("messages = %q, want last to contain %q", got, tc.wantMsg)
+				}
+			}
+			if got := bob.LootMode(); got != tc.wantMode {
+				t.Errorf("LootMode() = %q, want %q", got, tc.wantMode)
+			}
+			if got := bob.AutoLoot(); got != tc.wantAutoLoot {
+				t.Errorf("AutoLoot() = %v, want %v", got, tc.wantAutoLoot)
+			}
+			if got := bob.AutoSplit(); got != tc.wantAutoSplit {
+				t.Errorf("AutoSplit() = %v, want %v", got, tc.wantAutoSplit)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/worldmap"
+)
+
+// Map radius bounds, in rooms from the actor.
+const (
+	defaultMapRadius = 3
+	maxMapRadius     = 10
+)
+
+// MapActor provides the character state needed by the map handler.
+type MapActor interface {
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	HasGrant(key, arg string) bool
+	HasVisited(roomId string) bool
+}
+
+var _ MapActor = (*game.CharacterInstance)(nil)
+
+// MapHandlerFactory creates handlers that draw an ASCII map of the rooms
+// around the actor. Only rooms the actor has visited are drawn, and rooms
+// too dark to see are shown without detail.
+// Config:
+//   - radius (optional): how many rooms out to map; defaults to 3
+type MapHandlerFactory struct{}
+
+// NewMapHandlerFactory creates a new MapHandlerFactory.
+func NewMapHandlerFactory() *MapHandlerFactory {
+	return &MapHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *MapHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "radius", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *MapHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *MapHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[MapActor](f.handle), nil
+}
+
+func (f *MapHandlerFactory) handle(ctx context.Context, actor MapActor, in *CommandInput) error {
+	ri := actor.Room()
+	if ri == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+
+	radius := defaultMapRadius
+	if s := in.Config["radius"]; s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return NewUserError("The map radius must be a positive number.")
+		}
+		radius = min(n, maxMapRadius)
+	}
+
+	g := &roomGraph{viewer: actor, rooms: map[string]*game.RoomInstance{ri.Room.Id(): ri}}
+	grid := worldmap.Layout(g, ri.Room.Id(), radius, actor.HasVisited)
+	out := grid.Render(worldmap.RenderOptions{
+		Here: ri.Room.Id(),
+		Dim: func(id string) bool {
+			return g.rooms[id].Restricts(actor, assets.RoomFlagDark)
+		},
+	})
+
+	var sb strings.Builder
+	sb.WriteString(out)
+	sb.WriteString("\n\n")
+	sb.WriteString(worldmap.Legend)
+	actor.Publish([]byte(sb.String()), nil)
+	return nil
+}
+
+// roomGraph presents live rooms to the map layout as the viewer sees them:
+// hidden exits stay hidden until the viewer can see them. Rooms are indexed
+// as their exits are walked, so lookups only cover rooms reached so far.
+type roomGraph struct {
+	viewer game.GrantHolder
+	rooms  map[string]*game.RoomInstance
+}
+
+func (g *roomGraph) Exits(roomId string) []worldmap.Exit {
+	ri := g.rooms[roomId]
+	if ri == nil {
+		return nil
+	}
+	var exits []worldmap.Exit
+	ri.ForEachExit(func(dir string, re *game.ResolvedExit) {
+		if !ri.ExitVisible(g.viewer, dir) {
+			return
+		}
+		ex := worldmap.Exit{Direction: dir, Closed: re.IsClosed()}
+		if re.Dest != nil {
+			ex.To = re.Dest.Room.Id()
+			g.rooms[ex.To] = re.Dest
+		}
+		exits = append(exits, ex)
+	})
+	return exits
+}
+
+func (g *roomGraph) Zone(roomId string) string {
+	if ri := g.rooms[roomId]; ri != nil && ri.Zone() != nil {
+		return ri.Zone().Zone.Id()
+	}
+	return ""
+}
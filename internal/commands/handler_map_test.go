@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestMapHandler(t *testing.T) {
+	exit := func(zone, room string) assets.Exit {
+		return assets.Exit{Zone: storage.NewSmartIdentifier[*assets.Zone](zone), Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	door := func(zone, room string) assets.Exit {
+		ex := exit(zone, room)
+		ex.Closure = &assets.Closure{Name: "door", Closed: true}
+		return ex
+	}
+	inZone := func(zone string) storage.SmartIdentifier[*assets.Zone] {
+		return storage.NewResolvedSmartIdentifier(zone, &assets.Zone{ResetMode: assets.ZoneResetNever})
+	}
+	rooms := map[string]*assets.Room{
+		"hall": {Name: "Hall", Zone: inZone("house"), Exits: map[string]assets.Exit{
+			"north": exit("house", "attic"),
+			"south": exit("house", "cellar"),
+			"east":  door("house", "kitchen"),
+			"west":  exit("garden", "lawn"),
+		}},
+		"attic":   {Name: "Attic", Zone: inZone("house"), Exits: map[string]assets.Exit{"south": exit("house", "hall")}},
+		"kitchen": {Name: "Kitchen", Zone: inZone("house"), Exits: map[string]assets.Exit{"west": door("house", "hall")}},
+		"cellar": {Name: "Cellar", Zone: inZone("house"),
+			Perks: []assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagDark)}},
+			Exits: map[string]assets.Exit{"north": exit("house", "hall")}},
+		"lawn": {Name: "Lawn", Zone: inZone("garden"), Exits: map[string]assets.Exit{"east": exit("house", "hall")}},
+	}
+
+	tests := map[string]struct {
+		radius  string
+		expErr  bool
+		wantMap string
+	}{
+		"visited rooms around the actor": {
+			wantMap: strings.Join([]string{
+				"      |",
+				" { }-[*]+[ ]",
+				"      |",
+				"     [?]",
+			}, "\n"),
+		},
+		"radius is capped": {
+			radius: "50",
+			wantMap: strings.Join([]string{
+				"      |",
+				" { }-[*]+[ ]",
+				"      |",
+				"     [?]",
+			}, "\n"),
+		},
+		"invalid radius": {
+			radius: "none",
+			expErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			world, err := newTestWorldWithRooms(rooms)
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			hall := world.GetZone("house").GetRoom("hall")
+			msgs := make(chan []byte, 10)
+			ci, err := game.NewCharacterInstance(storage.NewResolvedSmartIdentifier("p", &assets.Character{
+				Name:         "Mapper",
+				VisitedRooms: []string{"kitchen", "cellar", "lawn"},
+			}), msgs, hall)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+
+			in := &CommandInput{Actor: ci, Config: map[string]string{"radius": tc.radius}}
+			err = (&MapHandlerFactory{}).handle(context.Background(), ci, in)
+			if tc.expErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handle: %v", err)
+			}
+
+			got := string(<-msgs)
+			if !strings.HasPrefix(got, tc.wantMap+"\n\n") {
+				t.Errorf("map =\n%s\nwant prefix\n%s", got, tc.wantMap)
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 //   - scope (required): "room", "zone", "world", or "player"
 //   - recipient_message (required): template for message sent to scope targets
 //   - sender_message (optional): template for 2nd-person message sent to actor
+//   - speech (optional): what was said aloud, offered to speech triggers in
+//     the room when scope is "room"
 type MessageHandlerFactory struct{}
 
 // NewMessageHandlerFactory creates a new MessageHandlerFactory.
@@ -26,6 +28,7 @@ func (f *MessageHandlerFactory) Spec() *HandlerSpec {
 			{Name: "scope", Required: true},
 			{Name: "recipient_message", Required: true},
 			{Name: "sender_message", Required: false},
+			{Name: "speech", Required: false},
 		},
 		Targets: []TargetRequirement{
 			{Name: "target", Type: targetTypePlayer, Required: false},
@@ -72,6 +75,9 @@ func (f *MessageHandlerFactory) handle(ctx context.Context, in *CommandInput) er
 	switch scope {
 	case "room":
 		actor.Room().Publish(data, exclude)
+		if speech := in.Config["speech"]; speech != "" {
+			game.FireSpeech(ctx, actor, actor.Room(), speech)
+		}
 
 	case "zone":
 		actor.Room().Zone().Publish(data, exclude)
@@ -63,9 +63,9 @@ func (f *MoveHandlerFactory) handle(ctx context.Context, in *CommandInput) error
 		return NewUserError("You are in an invalid location.")
 	}
 
-	// Check if exit exists
-	_, re := fromRoom.FindExit(direction)
-	if re == nil {
+	// Check if exit exists and, if hidden, has been discovered
+	dir, re := fromRoom.FindExit(direction)
+	if re == nil || !fromRoom.ExitVisible(char, dir) {
 		return NewUserError(fmt.Sprintf("You cannot go %s from here.", direction))
 	}
 
@@ -89,6 +89,10 @@ func (f *MoveHandlerFactory) handle(ctx context.Context, in *CommandInput) error
 		return NewUserError("There isn't enough room for you to enter.")
 	}
 
+	if !spendMove(char, toRoom) {
+		return NewUserError("You are too exhausted.")
+	}
+
 	// Announce departure
 	announceDepart(char, fromRoom, direction)
 
@@ -100,13 +104,30 @@ func (f *MoveHandlerFactory) handle(ctx context.Context, in *CommandInput) error
 
 	// Send room description to player
 	char.Publish([]byte(DescribeRoom(char, toRoom)), nil)
+	game.FireArrival(ctx, char, toRoom)
 
 	// Move any followers in the old room
-	moveFollowers(char, fromRoom, toRoom, direction)
+	moveFollowers(ctx, char, fromRoom, toRoom, direction)
 
 	return nil
 }
 
+// spendMove deducts the movement points needed to enter room. Actors without
+// a move resource walk for free. Returns false, spending nothing, when the
+// actor is too exhausted to make the trip.
+func spendMove(actor game.Actor, room *game.RoomInstance) bool {
+	cur, mx := actor.Resource(assets.ResourceMove)
+	if mx <= 0 {
+		return true
+	}
+	cost := room.MoveCost(actor)
+	if cur < cost {
+		return false
+	}
+	actor.AdjustResource(assets.ResourceMove, -cost, false)
+	return true
+}
+
 // announceDepart notifies players in the room that an actor is leaving.
 func announceDepart(actor interface {
 	Name() string
@@ -149,7 +170,7 @@ func announceToRoom(room *game.RoomInstance, actor interface{ Name() string }, m
 // moveFollowers walks the leader's follower tree and moves each follower from
 // fromRoom to toRoom. Followers not in the same room or in combat are skipped
 // along with their entire subtree.
-func moveFollowers(leader game.Actor, fromRoom, toRoom *game.RoomInstance, direction string) {
+func moveFollowers(ctx context.Context, leader game.Actor, fromRoom, toRoom *game.RoomInstance, direction string) {
 	for _, fl := range leader.Followers() {
 		if fl.Room() != fromRoom {
 			continue
@@ -159,10 +180,16 @@ func moveFollowers(leader game.Actor, fromRoom, toRoom *game.RoomInstance, direc
 			continue
 		}
 
+		if !spendMove(fl, toRoom) {
+			fl.Publish([]byte(fmt.Sprintf("You are too exhausted to follow %s.", leader.Name())), nil)
+			continue
+		}
+
 		announceDepart(fl, fromRoom, direction)
 		fl.Move(fromRoom, toRoom)
 		announceArrive(fl, toRoom)
 		fl.Publish([]byte(fmt.Sprintf("You follow %s.\n%s", leader.Name(), DescribeRoom(fl, toRoom))), nil)
-		moveFollowers(fl, fromRoom, toRoom, direction)
+		game.FireArrival(ctx, fl, toRoom)
+		moveFollowers(ctx, fl, fromRoom, toRoom, direction)
 	}
 }
@@ -20,6 +20,15 @@ type MoveObjActor interface {
 
 var _ MoveObjActor = (*game.CharacterInstance)(nil)
 
+// carrier is implemented by actors with a carry limit. Actors without one
+// (mobs) accept any weight.
+type carrier interface {
+	CanCarry(weight int) bool
+	Carries(oi *game.ObjectInstance) bool
+}
+
+var _ carrier = (*game.CharacterInstance)(nil)
+
 // ObjectHolder can have objects added and removed.
 type ObjectHolder interface {
 	ObjectRemover
@@ -89,12 +98,20 @@ func (f *MoveObjHandlerFactory) handle(ctx context.Context, char MoveObjActor, i
 		return err
 	}
 
-	var moved int
+	var moved []*game.ObjectInstance
 	for _, item := range items {
 		if item.Obj.instance.Object.Get().HasFlag(assets.ObjectFlagImmobile) {
 			char.Publish([]byte(fmt.Sprintf("You can't seem to move %s.", item.Obj.Name)), nil)
 			continue
 		}
+		if !f.lootable(in, item.Obj) {
+			char.Publish([]byte(fmt.Sprintf("You may not loot %s yet.", item.Obj.Name)), nil)
+			continue
+		}
+		if err := f.checkCapacity(in, item.Obj); err != nil {
+			char.Publish([]byte(err.Error()), nil)
+			continue
+		}
 
 		oi := item.Obj.source.RemoveObj(item.Obj.InstanceId)
 		if oi == nil {
@@ -102,10 +119,17 @@ func (f *MoveObjHandlerFactory) handle(ctx context.Context, char MoveObjActor, i
 		}
 		oi.ActivateDecay()
 		dest.AddObj(oi)
-		moved++
+		moved = append(moved, oi)
+
+		switch in.Config["destination"] {
+		case "inventory":
+			char.Room().EmitObjectEvent(game.ObjectEventPickup, oi, in.Actor)
+		case "room":
+			char.Room().EmitObjectEvent(game.ObjectEventDrop, oi, in.Actor)
+		}
 	}
 
-	if moved == 0 {
+	if len(moved) == 0 {
 		return nil
 	}
 
@@ -128,6 +152,25 @@ func (f *MoveObjHandlerFactory) handle(ctx context.Context, char MoveObjActor, i
 		char.Room().Publish([]byte(roomMsg), exclude)
 	}
 
+	// A mob handed items gets to react once the exchange has been told, and
+	// the items count toward the giver's quests.
+	qt, tracked := in.Actor.(questTracker)
+	if ref := in.FirstTarget(in.Config["destination"]); ref != nil && ref.Actor != nil {
+		if mi, ok := ref.Actor.actor.(*game.MobileInstance); ok {
+			for _, oi := range moved {
+				game.FireReceive(ctx, in.Actor, mi, oi)
+				if tracked {
+					qt.QuestDelivered(mi, oi)
+				}
+			}
+		}
+	}
+	if tracked && in.Config["destination"] == "inventory" {
+		for _, oi := range moved {
+			qt.QuestCollected(oi)
+		}
+	}
+
 	return nil
 }
 
@@ -149,6 +192,48 @@ func (f *MoveObjHandlerFactory) resolveDestination(char MoveObjActor, in *Comman
 	}
 }
 
+// checkCapacity returns a user error if the destination can't take the
+// item's weight: a carrier over its carry limit or a full container.
+func (f *MoveObjHandlerFactory) checkCapacity(in *CommandInput, obj *ObjectRef) error {
+	weight := obj.instance.TotalWeight()
+
+	switch dest := in.Config["destination"]; dest {
+	case "inventory":
+		// Taking from a container the actor already carries adds no weight.
+		if c, ok := in.Actor.(carrier); ok && !c.Carries(obj.instance) && !c.CanCarry(weight) {
+			return NewUserError(fmt.Sprintf("%s: you can't carry that much weight.", display.Capitalize(obj.Name)))
+		}
+	case "room":
+	default:
+		ref := in.FirstTarget(dest)
+		if ref == nil {
+			return nil
+		}
+		if ref.Actor != nil {
+			if c, ok := ref.Actor.actor.(carrier); ok && !c.CanCarry(weight) {
+				return NewUserError(fmt.Sprintf("%s can't carry that much weight.", display.Capitalize(ref.Actor.Name)))
+			}
+		}
+		if ref.Obj != nil && ref.Obj.instance.Contents != nil && !ref.Obj.instance.CanHold(weight) {
+			return NewUserError(fmt.Sprintf("%s won't fit in %s.", display.Capitalize(obj.Name), ref.Obj.Name))
+		}
+	}
+	return nil
+}
+
+// lootable reports whether the actor may take obj under the loot rights on
+// it, or on the container it is being taken from.
+func (f *MoveObjHandlerFactory) lootable(in *CommandInput, obj *ObjectRef) bool {
+	if !obj.instance.MayLoot(in.Actor) {
+		return false
+	}
+	if in.Config["destination"] != "inventory" {
+		return true
+	}
+	from := in.FirstTarget("destination")
+	return from == nil || from.Obj == nil || from.Obj.instance.MayLoot(in.Actor)
+}
+
 // holderForTarget returns an ObjectHolder for a resolved target.
 // For actor targets (player/mob), returns their inventory.
 // For object targets, validates the container flag and returns contents.
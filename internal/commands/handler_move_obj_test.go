@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newWeighted creates an object instance with the given weight.
+func newWeighted(t *testing.T, id string, weight int, flags ...string) *game.ObjectInstance {
+	t.Helper()
+	obj := &assets.Object{Aliases: []string{id}, ShortDesc: "a " + id, Weight: weight, Flags: flags}
+	if len(flags) > 0 {
+		obj.Capacity = 10
+	}
+	oi, err := game.NewObjectInstance(storage.NewResolvedSmartIdentifier(id, obj))
+	if err != nil {
+		t.Fatalf("NewObjectInstance: %v", err)
+	}
+	return oi
+}
+
+func TestMoveObjHandler_Capacity(t *testing.T) {
+	tests := map[string]struct {
+		str       int
+		weight    int
+		dest      string
+		fromBag   bool // the item starts in the carried bag
+		wantMoved bool
+	}{
+		"pick up within carry limit": {
+			str: 10, weight: 20, dest: "inventory", wantMoved: true,
+		},
+		"pick up over carry limit": {
+			str: 1, weight: 20, dest: "inventory", wantMoved: false,
+		},
+		"take from a carried bag over carry limit": {
+			str: 1, weight: 10, dest: "inventory", fromBag: true, wantMoved: true,
+		},
+		"container with room": {
+			str: 10, weight: 10, dest: "destination", wantMoved: true,
+		},
+		"container too full": {
+			str: 10, weight: 11, dest: "destination", wantMoved: false,
+		},
+		"drop ignores weight": {
+			str: 1, weight: 500, dest: "room", wantMoved: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoom("r", "Room", "z")
+			charRef := storage.NewResolvedSmartIdentifier("alice", &assets.Character{
+				Name: "Alice", BaseStats: map[assets.StatKey]int{assets.StatSTR: tc.str},
+			})
+			ci, err := game.NewCharacterInstance(charRef, make(chan []byte, 10), room)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+
+			item := newWeighted(t, "anvil", tc.weight)
+			bag := newWeighted(t, "bag", 1, "container")
+			ci.Inventory().AddObj(bag)
+			var source ObjectRemover = room
+			switch {
+			case tc.fromBag:
+				bag.Contents.AddObj(item)
+				source = bag.Contents
+			case tc.dest == "inventory":
+				room.AddObj(item)
+			default:
+				ci.Inventory().AddObj(item)
+				source = ci.Inventory()
+			}
+
+			in := &CommandInput{
+				Actor:  ci,
+				Config: map[string]string{"destination": tc.dest},
+				Targets: map[string][]*TargetRef{
+					"item":        {{Type: targetTypeObject, Obj: objRefFromInstance(item, source)}},
+					"destination": {{Type: targetTypeObject, Obj: objRefFromInstance(bag, ci.Inventory())}},
+				},
+			}
+			if err := (&MoveObjHandlerFactory{}).handle(context.Background(), ci, in); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var moved bool
+			switch tc.dest {
+			case "inventory":
+				moved = ci.Inventory().FindObjByDef("anvil") != nil
+			case "room":
+				moved = len(room.FindObjs(func(oi *game.ObjectInstance) bool { return oi == item })) == 1
+			default:
+				moved = bag.Contents.Len() == 1
+			}
+			if moved != tc.wantMoved {
+				t.Errorf("moved = %v, want %v", moved, tc.wantMoved)
+			}
+		})
+	}
+}
+
+func TestGetTargets_container(t *testing.T) {
+	// The targets of assets/commands/get.json: "get <item> from <container>".
+	specs := []assets.TargetSpec{
+		{Name: "destination", Types: []string{"object"}, Scopes: []string{"room", "inventory", "equipment"}, Input: "from", Optional: true},
+		{Name: "item", Types: []string{"object"}, Scopes: []string{"room", "contents"}, Input: "item", ScopeTarget: "destination"},
+	}
+	tests := map[string]struct {
+		bagIn  string // where the bag holding the coin is: room or inventory
+		expErr bool
+	}{
+		"bag on the floor": {bagIn: "room"},
+		"bag carried":      {bagIn: "inventory"},
+		"no such bag":      {expErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			ci := newTestPlayer("p", "Player", room)
+			bag := newWeighted(t, "bag", 1, "container")
+			coin := newWeighted(t, "coin", 1)
+			bag.Contents.AddObj(coin)
+			switch tc.bagIn {
+			case "room":
+				room.AddObj(bag)
+			case "inventory":
+				ci.Inventory().AddObj(bag)
+			}
+
+			targets, err := NewTargetResolver(NewWorldScopes()).ResolveSpecs(specs, map[string]any{"item": "coin", "from": "bag"}, ci)
+
+			if tc.expErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := targets["item"]; len(got) != 1 || got[0].Obj == nil || got[0].Obj.instance != coin {
+				t.Errorf("item = %v, want the coin in the bag", got)
+			}
+		})
+	}
+}
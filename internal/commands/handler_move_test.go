@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -101,6 +102,19 @@ func TestMoveFollowers(t *testing.T) {
 			expStayed:      []string{"follower"},
 			expMsgContains: map[string]string{"follower": "Leader leaves north without you."},
 		},
+		"exhausted follower stays behind": {
+			setup: func(fromRoom *game.RoomInstance) (game.Actor, map[string]*gametest.BaseActor) {
+				leader := &gametest.BaseActor{ActorId: "leader", ActorName: "Leader", ActorRoom: fromRoom}
+				follower := &gametest.BaseActor{
+					ActorId: "follower", ActorName: "Follower", ActorRoom: fromRoom,
+					Resources: map[string][2]int{assets.ResourceMove: {0, 10}},
+				}
+				leader.ActorFollowers = []game.Actor{follower}
+				return leader, map[string]*gametest.BaseActor{"follower": follower}
+			},
+			expStayed:      []string{"follower"},
+			expMsgContains: map[string]string{"follower": "You are too exhausted to follow Leader."},
+		},
 		"recursive following": {
 			setup: func(fromRoom *game.RoomInstance) (game.Actor, map[string]*gametest.BaseActor) {
 				leader := &gametest.BaseActor{ActorId: "leader", ActorName: "Leader", ActorRoom: fromRoom}
@@ -150,7 +164,7 @@ func TestMoveFollowers(t *testing.T) {
 			}
 			leader, actors := tt.setup(fromRoom)
 
-			moveFollowers(leader, fromRoom, toRoom, "north")
+			moveFollowers(context.Background(), leader, fromRoom, toRoom, "north")
 
 			for _, id := range tt.expMoved {
 				if !actors[id].Moved {
@@ -177,3 +191,55 @@ func TestMoveFollowers(t *testing.T) {
 		})
 	}
 }
+
+func TestSpendMove(t *testing.T) {
+	tests := map[string]struct {
+		sector  assets.Sector
+		move    [2]int
+		grants  []string
+		expOk   bool
+		expMove int
+	}{
+		"no move resource walks free": {
+			sector: assets.SectorMountain, expOk: true,
+		},
+		"sector cost deducted": {
+			sector: assets.SectorForest, move: [2]int{10, 10}, expOk: true, expMove: 7,
+		},
+		"too exhausted": {
+			sector: assets.SectorMountain, move: [2]int{5, 10}, expOk: false, expMove: 5,
+		},
+		"mount lowers the cost": {
+			sector: assets.SectorMountain, move: [2]int{5, 10}, grants: []string{"mountain:2"}, expOk: true, expMove: 3,
+		},
+		"flight covers every sector": {
+			sector: assets.SectorHills, move: [2]int{5, 10}, grants: []string{"all:0"}, expOk: true, expMove: 5,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := game.NewRoomInstance(storage.NewResolvedSmartIdentifier("peak", &assets.Room{
+				Name:   "Peak",
+				Zone:   storage.NewResolvedSmartIdentifier("z", &assets.Zone{ResetMode: assets.ZoneResetNever}),
+				Sector: tc.sector,
+			}))
+			if err != nil {
+				t.Fatalf("NewRoomInstance: %v", err)
+			}
+			actor := &gametest.BaseActor{
+				Resources: map[string][2]int{},
+				Grants:    map[string][]string{assets.PerkGrantMoveCost: tc.grants},
+			}
+			if tc.move[1] > 0 {
+				actor.Resources[assets.ResourceMove] = tc.move
+			}
+
+			if got := spendMove(actor, room); got != tc.expOk {
+				t.Errorf("spendMove = %v, want %v", got, tc.expOk)
+			}
+			if cur, _ := actor.Resource(assets.ResourceMove); cur != tc.expMove {
+				t.Errorf("move = %d, want %d", cur, tc.expMove)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// PvPActor provides the character state needed by the pvp handler.
+type PvPActor interface {
+	Publish(data []byte, exclude []string)
+	PvP() bool
+	SetPvP(on bool) error
+	PvPCooldown() time.Duration
+	PvPRecord() (kills, deaths int)
+}
+
+var _ PvPActor = (*game.CharacterInstance)(nil)
+
+// PvPHandlerFactory creates handlers that show or change whether a character
+// fights other players outside arenas.
+//
+// Config:
+//   - action (optional): "on" or "off"; empty shows the flag and record
+type PvPHandlerFactory struct{}
+
+// NewPvPHandlerFactory creates a handler factory for the pvp command.
+func NewPvPHandlerFactory() *PvPHandlerFactory {
+	return &PvPHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *PvPHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "action", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *PvPHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *PvPHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[PvPActor](f.handle), nil
+}
+
+func (f *PvPHandlerFactory) handle(ctx context.Context, char PvPActor, in *CommandInput) error {
+	var on bool
+	switch strings.ToLower(in.Config["action"]) {
+	case "":
+		state := "off"
+		if char.PvP() {
+			state = "on"
+		}
+		kills, deaths := char.PvPRecord()
+		char.Publish([]byte(fmt.Sprintf("PvP is %s. Player kills: %d. Deaths to players: %d.", state, kills, deaths)), nil)
+		return nil
+	case "on":
+		on = true
+	case "off":
+	default:
+		return NewUserError("Usage: pvp [on | off]")
+	}
+
+	err := char.SetPvP(on)
+	switch {
+	case errors.Is(err, game.ErrPvPInCombat):
+		return NewUserError("You can't back out of player fights in the middle of one!")
+	case errors.Is(err, game.ErrPvPCooldown):
+		wait := max(char.PvPCooldown().Round(time.Minute), time.Minute)
+		return NewUserError(fmt.Sprintf("You changed your mind too recently. Try again in %d minutes.", int(wait.Minutes())))
+	case err != nil:
+		return err
+	}
+
+	if on {
+		char.Publish([]byte("You are now open to fights with other players."), nil)
+	} else {
+		char.Publish([]byte("You are no longer open to fights with other players outside arenas."), nil)
+	}
+	return nil
+}
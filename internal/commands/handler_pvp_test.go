@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPvPHandler(t *testing.T) {
+	tests := map[string]struct {
+		action  string
+		pvp     bool
+		toggled time.Duration // how long ago the flag last changed; zero for never
+		expErr  string
+		wantMsg string // substring of the last message Bob got
+		wantPvP bool
+	}{
+		"status": {
+			pvp:     true,
+			wantMsg: "PvP is on. Player kills: 2. Deaths to players: 1.",
+			wantPvP: true,
+		},
+		"turn on": {
+			action:  "on",
+			wantMsg: "You are now open to fights with other players.",
+			wantPvP: true,
+		},
+		"turn off": {
+			action:  "off",
+			pvp:     true,
+			toggled: -time.Hour,
+			wantMsg: "You are no longer open to fights with other players outside arenas.",
+		},
+		"too soon": {
+			action:  "off",
+			pvp:     true,
+			toggled: -time.Minute,
+			expErr:  "You changed your mind too recently. Try again in 9 minutes.",
+			wantPvP: true,
+		},
+		"unknown action": {
+			action: "maybe",
+			expErr: "Usage: pvp [on | off]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("yard", "Yard", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			c := bob.Asset()
+			c.PvP, c.PvPKills, c.PvPDeaths = tc.pvp, 2, 1
+			if tc.toggled != 0 {
+				c.PvPToggledAt = time.Now().Add(tc.toggled)
+			}
+
+			err = NewPvPHandlerFactory().handle(context.Background(), bob, &CommandInput{
+				Config: map[string]string{"action": tc.action},
+			})
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got := drainAll(msgs)
+				if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantMsg) {
+					t.Errorf("messages = %q, want last to contain %q", got, tc.wantMsg)
+				}
+			}
+			if bob.PvP() != tc.wantPvP {
+				t.Errorf("PvP() = %v, want %v", bob.PvP(), tc.wantPvP)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// QuestActor provides the character state needed by the quest handler.
+type QuestActor interface {
+	Publish(data []byte, exclude []string)
+	Quests() []game.QuestStatus
+	AbandonQuest(questId string) error
+}
+
+var _ QuestActor = (*game.CharacterInstance)(nil)
+
+// questTracker is implemented by actors who keep a quest journal. Handlers
+// report the things quest objectives count through it.
+type questTracker interface {
+	QuestCollected(oi *game.ObjectInstance)
+	QuestDelivered(mi *game.MobileInstance, oi *game.ObjectInstance)
+	QuestUsedAbility(abilityId string)
+}
+
+var _ questTracker = (*game.CharacterInstance)(nil)
+
+// QuestHandlerFactory creates handlers for the quest journal.
+//
+// Config:
+//   - action (optional): "list" (the default), "info" or "abandon"
+//   - quest (optional): the name of the quest to show or abandon
+type QuestHandlerFactory struct{}
+
+// NewQuestHandlerFactory creates a handler factory for the quest command.
+func NewQuestHandlerFactory() *QuestHandlerFactory {
+	return &QuestHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *QuestHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "action", Required: false},
+			{Name: "quest", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *QuestHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *QuestHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[QuestActor](f.handle), nil
+}
+
+func (f *QuestHandlerFactory) handle(ctx context.Context, char QuestActor, in *CommandInput) error {
+	quests := char.Quests()
+	name := strings.TrimSpace(in.Config["quest"])
+
+	switch action := strings.ToLower(in.Config["action"]); action {
+	case "", "list":
+		char.Publish([]byte(questList(quests)), nil)
+		return nil
+
+	case "info", "abandon":
+		if name == "" {
+			return NewUserError(fmt.Sprintf("Which quest do you want to %s?", action))
+		}
+		st := findQuest(quests, name)
+		if st == nil {
+			return NewUserError("You have no quest by that name.")
+		}
+		if action == "info" {
+			char.Publish([]byte(questInfo(st)), nil)
+			return nil
+		}
+		if errors.Is(char.AbandonQuest(st.Id), game.ErrQuestNotActive) {
+			return NewUserError(fmt.Sprintf("You have already completed %s.", st.Quest.Name))
+		}
+		char.Publish([]byte(fmt.Sprintf("You abandon %s.", st.Quest.Name)), nil)
+		return nil
+
+	default:
+		return NewUserError("Usage: quest [list | info <quest> | abandon <quest>]")
+	}
+}
+
+// findQuest returns the journal entry whose name or ID starts with name,
+// preferring quests still under way.
+func findQuest(quests []game.QuestStatus, name string) *game.QuestStatus {
+	name = strings.ToLower(name)
+	var found *game.QuestStatus
+	for i := range quests {
+		st := &quests[i]
+		if !strings.HasPrefix(strings.ToLower(st.Quest.Name), name) && !strings.HasPrefix(st.Id, name) {
+			continue
+		}
+		if st.State != assets.QuestStateCompleted {
+			return st
+		}
+		if found == nil {
+			found = st
+		}
+	}
+	return found
+}
+
+// questList renders the journal: quests under way, then a count of those
+// completed.
+func questList(quests []game.QuestStatus) string {
+	var sb strings.Builder
+	sb.WriteString("Your quests:")
+	completed := 0
+	for _, st := range quests {
+		switch st.State {
+		case assets.QuestStateCompleted:
+			completed++
+		case assets.QuestStateReady:
+			fmt.Fprintf(&sb, "\n  %s (ready to hand in)", st.Quest.Name)
+		default:
+			done := 0
+			for _, o := range st.Objectives {
+				if o.Done() {
+					done++
+				}
+			}
+			fmt.Fprintf(&sb, "\n  %s (%d/%d)", st.Quest.Name, done, len(st.Objectives))
+		}
+	}
+	if completed == len(quests) {
+		sb.WriteString("\n  None.")
+	}
+	if completed > 0 {
+		fmt.Fprintf(&sb, "\nCompleted: %d", completed)
+	}
+	return sb.String()
+}
+
+// questInfo renders one quest's description and objectives.
+func questInfo(st *game.QuestStatus) string {
+	var sb strings.Builder
+	sb.WriteString(st.Quest.Name)
+	switch st.State {
+	case assets.QuestStateCompleted:
+		sb.WriteString(" (completed)")
+	case assets.QuestStateReady:
+		sb.WriteString(" (ready to hand in)")
+	}
+	if st.Quest.Description != "" {
+		sb.WriteString("\n" + st.Quest.Description)
+	}
+	for _, o := range st.Objectives {
+		mark := " "
+		if o.Done() {
+			mark = "x"
+		}
+		fmt.Fprintf(&sb, "\n  [%s] %s", mark, o.Text)
+		if o.Need > 1 {
+			fmt.Fprintf(&sb, " (%d/%d)", o.Have, o.Need)
+		}
+	}
+	return sb.String()
+}
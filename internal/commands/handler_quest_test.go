@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestQuestHandler(t *testing.T) {
+	newQuest := func(id, name string) storage.SmartIdentifier[*assets.Quest] {
+		return storage.NewResolvedSmartIdentifier(id, &assets.Quest{
+			Name:        name,
+			Description: "Thin out the wolves.",
+			Objectives: []assets.QuestObjective{
+				{Type: assets.QuestObjectiveKill, Mobile: storage.NewSmartIdentifier[*assets.Mobile]("wolf"), Count: 3, Text: "Kill wolves"},
+				{Type: assets.QuestObjectiveVisit, Room: storage.NewSmartIdentifier[*assets.Room]("den"), Text: "Find the den"},
+			},
+		})
+	}
+	tests := map[string]struct {
+		action   string
+		quest    string
+		expErr   string
+		wantMsg  string // substring of the last message Bob got
+		wantGone bool   // the cull is no longer in the journal
+	}{
+		"list by default": {
+			wantMsg: "Your quests:\n  The Cull (0/2)\nCompleted: 1",
+		},
+		"info by name": {
+			action:  "info",
+			quest:   "the cull",
+			wantMsg: "The Cull\nThin out the wolves.\n  [ ] Kill wolves (0/3)\n  [ ] Find the den",
+		},
+		"info on a completed quest": {
+			action:  "info",
+			quest:   "first",
+			wantMsg: "First Steps (completed)",
+		},
+		"abandon": {
+			action:   "abandon",
+			quest:    "cull",
+			wantMsg:  "You abandon The Cull.",
+			wantGone: true,
+		},
+		"abandon a completed quest": {
+			action: "abandon",
+			quest:  "first",
+			expErr: "You have already completed First Steps.",
+		},
+		"unknown quest": {
+			action: "info",
+			quest:  "dragon",
+			expErr: "You have no quest by that name.",
+		},
+		"no quest named": {
+			action: "abandon",
+			expErr: "Which quest do you want to abandon?",
+		},
+		"unknown action": {
+			action: "share",
+			expErr: "Usage: quest [list | info <quest> | abandon <quest>]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("den", "Den", "woods")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			bob.Asset().Quests = []assets.QuestProgress{{Quest: newQuest("first", "First Steps"), State: assets.QuestStateCompleted}}
+			if err := bob.StartQuest(newQuest("cull", "The Cull")); err != nil {
+				t.Fatalf("StartQuest: %v", err)
+			}
+
+			err = (&QuestHandlerFactory{}).handle(context.Background(), bob, &CommandInput{
+				Config: map[string]string{"action": tc.action, "quest": tc.quest},
+			})
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := drainAll(msgs)
+			if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantMsg) {
+				t.Errorf("messages = %q, want last to contain %q", got, tc.wantMsg)
+			}
+			if gone := bob.QuestState("cull") == assets.QuestStateNone; gone != tc.wantGone {
+				t.Errorf("cull gone = %v, want %v", gone, tc.wantGone)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// RankActor provides the character state needed by the rank handler.
+type RankActor interface {
+	Id() string
+	Name() string
+	Publish(data []byte, exclude []string)
+	BackRank() bool
+	SetBackRank(on bool)
+}
+
+var _ RankActor = (*game.CharacterInstance)(nil)
+
+// RankHandlerFactory creates handlers for the rank command. With no rank,
+// the player sees which rank of their group they stand in. Otherwise they
+// move to the front or back rank. Enemies go after the front rank first,
+// and the back rank takes less melee damage but can't use melee abilities.
+//
+// Config:
+//   - rank (optional): "front" or "back"
+type RankHandlerFactory struct{}
+
+// NewRankHandlerFactory creates a handler factory for the rank command.
+func NewRankHandlerFactory() *RankHandlerFactory {
+	return &RankHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *RankHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "rank", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *RankHandlerFactory) ValidateConfig(config map[string]string) error { return nil }
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *RankHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[RankActor](f.handle), nil
+}
+
+func (f *RankHandlerFactory) handle(ctx context.Context, char RankActor, in *CommandInput) error {
+	var back bool
+	switch strings.ToLower(in.Config["rank"]) {
+	case "":
+		char.Publish([]byte(fmt.Sprintf("You are in the %s rank.", rankName(char.BackRank()))), nil)
+		return nil
+	case "front":
+	case "back":
+		back = true
+	default:
+		return NewUserError("Usage: rank [front | back]")
+	}
+
+	leader := game.GroupLeader(in.Actor)
+	if back && leader == nil {
+		return NewUserError("You must be in a group to take the back rank.")
+	}
+	if back == char.BackRank() {
+		return NewUserError(fmt.Sprintf("You are already in the %s rank.", rankName(back)))
+	}
+
+	char.SetBackRank(back)
+	char.Publish([]byte(fmt.Sprintf("You move to the %s rank.", rankName(back))), nil)
+	if leader != nil {
+		game.GroupPublishTarget(leader).Publish([]byte(fmt.Sprintf("%s moves to the %s rank.", char.Name(), rankName(back))), []string{char.Id()})
+	}
+	return nil
+}
+
+// rankName names the back rank or the front rank.
+func rankName(back bool) string {
+	if back {
+		return "back"
+	}
+	return "front"
+}
+
+// clearRank returns actor to the front rank once they are no longer in a
+// group.
+func clearRank(actor game.Actor) {
+	if game.GroupLeader(actor) != nil {
+		return
+	}
+	if r, ok := actor.(RankActor); ok {
+		r.SetBackRank(false)
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRankHandler(t *testing.T) {
+	tests := map[string]struct {
+		rank     string
+		grouped  bool
+		back     bool
+		expErr   string
+		wantMsg  string // substring of the last message Bob got
+		wantBack bool
+	}{
+		"status": {
+			wantMsg: "You are in the front rank.",
+		},
+		"move back": {
+			rank:     "Back",
+			grouped:  true,
+			wantMsg:  "You move to the back rank.",
+			wantBack: true,
+		},
+		"move front": {
+			rank:    "front",
+			grouped: true,
+			back:    true,
+			wantMsg: "You move to the front rank.",
+		},
+		"back without a group": {
+			rank:   "back",
+			expErr: "You must be in a group to take the back rank.",
+		},
+		"already there": {
+			rank:     "back",
+			grouped:  true,
+			back:     true,
+			expErr:   "You are already in the back rank.",
+			wantBack: true,
+		},
+		"unknown rank": {
+			rank:   "middle",
+			expErr: "Usage: rank [front | back]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("yard", "Yard", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			bob.SetBackRank(tc.back)
+			if tc.grouped {
+				alice, _ := newRecordingPlayer("alice", "Alice", room)
+				bob.SetFollowing(alice)
+				alice.AddFollower(bob)
+				alice.SetFollowerGrouped(bob.Id(), true)
+			}
+
+			err = NewRankHandlerFactory().handle(context.Background(), bob, &CommandInput{
+				Actor:  bob,
+				Config: map[string]string{"rank": tc.rank},
+			})
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got := drainAll(msgs)
+				if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantMsg) {
+					t.Errorf("messages = %q, want last to contain %q", got, tc.wantMsg)
+				}
+			}
+			if got := bob.BackRank(); got != tc.wantBack {
+				t.Errorf("BackRank() = %v, want %v", got, tc.wantBack)
+			}
+		})
+	}
+}
+
+func TestLeaveGroup_clearsRank(t *testing.T) {
+	room, _ := newTestRoom("yard", "Yard", "town")
+	alice := newTestPlayer("alice", "Alice", room)
+	bob := newTestPlayer("bob", "Bob", room)
+	bob.SetFollowing(alice)
+	alice.AddFollower(bob)
+	alice.SetFollowerGrouped(bob.Id(), true)
+	alice.SetBackRank(true)
+	bob.SetBackRank(true)
+
+	leaveGroup(bob, alice)
+
+	if alice.BackRank() || bob.BackRank() {
+		t.Errorf("BackRank() = %v, %v after the group broke up, want both false", alice.BackRank(), bob.BackRank())
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Rest actions drive the rest handler's dispatch.
+// Commands declare one of these as the `action` config field.
+const (
+	restActionRest  = "rest"
+	restActionStand = "stand"
+)
+
+// RestActor provides the character state needed by the rest handler.
+type RestActor interface {
+	Id() string
+	Name() string
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	IsInCombat() bool
+	IsResting() bool
+	SetResting(resting bool)
+}
+
+var _ RestActor = (*game.CharacterInstance)(nil)
+
+// RestHandlerFactory creates handlers that sit a character down to rest or
+// stand them back up. Resting adds each resource's rest_regen to its regen;
+// moving or entering combat ends the rest.
+// Config:
+//   - action (required): "rest" or "stand"
+type RestHandlerFactory struct{}
+
+// NewRestHandlerFactory creates a handler factory for rest/stand commands.
+func NewRestHandlerFactory() *RestHandlerFactory {
+	return &RestHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *RestHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "action", Required: true},
+		},
+	}
+}
+
+// ValidateConfig checks that action is rest or stand.
+func (f *RestHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch config["action"] {
+	case restActionRest, restActionStand:
+		return nil
+	default:
+		return errors.New("action must be rest or stand")
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *RestHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[RestActor](f.handle), nil
+}
+
+func (f *RestHandlerFactory) handle(ctx context.Context, char RestActor, in *CommandInput) error {
+	var self, others string
+	switch in.Config["action"] {
+	case restActionRest:
+		if char.IsInCombat() {
+			return NewUserError("You can't rest while fighting!")
+		}
+		if char.IsResting() {
+			return NewUserError("You are already resting.")
+		}
+		char.SetResting(true)
+		self, others = "You sit down and rest your tired bones.", "%s sits down and rests."
+	case restActionStand:
+		if !char.IsResting() {
+			return NewUserError("You are already standing.")
+		}
+		char.SetResting(false)
+		self, others = "You stop resting and stand up.", "%s clambers to their feet."
+	}
+
+	char.Publish([]byte(self), nil)
+	if room := char.Room(); room != nil {
+		room.Publish([]byte(fmt.Sprintf(others, display.Capitalize(char.Name()))), []string{char.Id()})
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRestHandler(t *testing.T) {
+	tests := map[string]struct {
+		action     string
+		resting    bool
+		expErr     bool
+		expResting bool
+	}{
+		"rest":                 {action: restActionRest, expResting: true},
+		"rest while resting":   {action: restActionRest, resting: true, expErr: true, expResting: true},
+		"stand":                {action: restActionStand, resting: true},
+		"stand while standing": {action: restActionStand, expErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("camp", "Camp", "z")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			ci := newTestPlayer("p", "Walker", room)
+			ci.SetResting(tc.resting)
+
+			in := &CommandInput{Actor: ci, Config: map[string]string{"action": tc.action}}
+			err = (&RestHandlerFactory{}).handle(context.Background(), ci, in)
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if ci.IsResting() != tc.expResting {
+				t.Errorf("resting = %v, want %v", ci.IsResting(), tc.expResting)
+			}
+		})
+	}
+}
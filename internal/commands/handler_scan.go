@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Scan range bounds, in rooms from the actor.
+const (
+	defaultScanRange = 3
+	maxScanRange     = 5
+)
+
+// ScanActor provides the character state needed by the scan handler.
+type ScanActor interface {
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	HasGrant(key, arg string) bool
+}
+
+var _ ScanActor = (*game.CharacterInstance)(nil)
+
+// ScanHandlerFactory creates handlers that look out through each open exit
+// and list who can be seen in the rooms beyond. Sight stops at closed doors,
+// dark rooms show no one, and invisible or hiding actors are only listed for
+// those able to spot them.
+// Config:
+//   - range (optional): how many rooms out to look; defaults to 3
+type ScanHandlerFactory struct{}
+
+// NewScanHandlerFactory creates a new ScanHandlerFactory.
+func NewScanHandlerFactory() *ScanHandlerFactory {
+	return &ScanHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *ScanHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "range", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *ScanHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *ScanHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[ScanActor](f.handle), nil
+}
+
+func (f *ScanHandlerFactory) handle(ctx context.Context, actor ScanActor, in *CommandInput) error {
+	ri := actor.Room()
+	if ri == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+	if ri.Restricts(actor, assets.RoomFlagDark) {
+		return NewUserError("It's too dark to see anything.")
+	}
+
+	reach := defaultScanRange
+	if s := in.Config["range"]; s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return NewUserError("The scan range must be a positive number.")
+		}
+		reach = min(n, maxScanRange)
+	}
+
+	var lines []string
+	ri.ForEachExit(func(dir string, _ *game.ResolvedExit) {
+		for i, room := range ri.SightLine(actor, dir, reach) {
+			if names := visibleActors(actor, room); len(names) > 0 {
+				lines = append(lines, fmt.Sprintf("%s, %s: %s", display.Capitalize(dir), scanDistance(i+1), strings.Join(names, ", ")))
+			}
+		}
+	})
+	if len(lines) == 0 {
+		actor.Publish([]byte("You scan your surroundings, but see no one."), nil)
+		return nil
+	}
+
+	actor.Publish([]byte("You scan your surroundings.\n"+strings.Join(lines, "\n")), nil)
+	return nil
+}
+
+// visibleActors returns the sorted names of the actors viewer can make out
+// in room.
+func visibleActors(viewer game.GrantHolder, room *game.RoomInstance) []string {
+	if room.Restricts(viewer, assets.RoomFlagDark) {
+		return nil
+	}
+	var names []string
+	room.ForEachActor(func(a game.Actor) {
+		if game.CanSee(viewer, a) {
+			names = append(names, a.Name())
+		}
+	})
+	slices.Sort(names)
+	return names
+}
+
+// scanDistance describes how far off a room dist rooms away is.
+func scanDistance(dist int) string {
+	switch dist {
+	case 1:
+		return "nearby"
+	case 2:
+		return "not far off"
+	default:
+		return "far off"
+	}
+}
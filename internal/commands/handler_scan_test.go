@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestScanHandler(t *testing.T) {
+	exit := func(room string) assets.Exit {
+		return assets.Exit{Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	dark := []assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagDark)}}
+
+	tests := map[string]struct {
+		scanRange string
+		hereDark  bool
+		farDark   bool
+		expErr    bool
+		wantMsg   string
+	}{
+		"lists who is seen in each direction": {
+			wantMsg: "You scan your surroundings.\nEast, nearby: a rat\nNorth, nearby: Bob\nNorth, not far off: a goblin, an orc",
+		},
+		"range limits how far is seen": {
+			scanRange: "1",
+			wantMsg:   "You scan your surroundings.\nEast, nearby: a rat\nNorth, nearby: Bob",
+		},
+		"dark rooms show no one": {
+			farDark: true,
+			wantMsg: "You scan your surroundings.\nEast, nearby: a rat\nNorth, nearby: Bob",
+		},
+		"too dark to scan": {
+			hereDark: true,
+			expErr:   true,
+		},
+		"bad range": {
+			scanRange: "zero",
+			expErr:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			town := storage.NewResolvedSmartIdentifier("z", &assets.Zone{ResetMode: assets.ZoneResetNever})
+			camp := &assets.Room{Name: "Camp", Zone: town, Exits: map[string]assets.Exit{"north": exit("trail"), "east": exit("pen")}}
+			ridge := &assets.Room{Name: "Ridge", Zone: town}
+			if tc.hereDark {
+				camp.Perks = dark
+			}
+			if tc.farDark {
+				ridge.Perks = dark
+			}
+			world, err := newTestWorldWithRooms(map[string]*assets.Room{
+				"camp":  camp,
+				"trail": {Name: "Trail", Zone: town, Exits: map[string]assets.Exit{"north": exit("ridge")}},
+				"ridge": ridge,
+				"pen":   {Name: "Pen", Zone: town},
+			})
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			zone := world.GetZone("z")
+
+			actor, msgs := newRecordingPlayer("p", "Scout", zone.GetRoom("camp"))
+			newTestPlayer("bob", "Bob", zone.GetRoom("trail"))
+			for _, mob := range []struct{ id, name, room string }{
+				{"goblin", "a goblin", "ridge"},
+				{"orc", "an orc", "ridge"},
+				{"rat", "a rat", "pen"},
+			} {
+				zone.GetRoom(mob.room).AddMob(newCombatMob(mob.id, mob.name))
+			}
+
+			err = (&ScanHandlerFactory{}).handle(context.Background(), actor, &CommandInput{
+				Actor:  actor,
+				Config: map[string]string{"range": tc.scanRange},
+			})
+			if tc.expErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(<-msgs); got != tc.wantMsg {
+				t.Errorf("message = %q, want %q", got, tc.wantMsg)
+			}
+		})
+	}
+}
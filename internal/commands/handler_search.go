@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/combat"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// SearchActor provides the character state needed by the search handler.
+type SearchActor interface {
+	Id() string
+	Name() string
+	Room() *game.RoomInstance
+	Inventory() *game.Inventory
+	Publish(data []byte, exclude []string)
+	ModifierValue(key string) int
+	HasGrant(key, arg string) bool
+	AddTimedPerks(name string, perks []assets.Perk, ticks int)
+}
+
+var _ SearchActor = (*game.CharacterInstance)(nil)
+
+// SearchHandlerFactory creates handlers that search the room for hidden exits
+// and for traps on exits and on containers in the room or the actor's
+// inventory. Each hidden thing is a separate check: d20 + WIS modifier +
+// core.skill.search against its difficulty. Found traps stay found until the
+// zone resets; hidden exits are revealed as their definition says.
+type SearchHandlerFactory struct{}
+
+// NewSearchHandlerFactory creates a new SearchHandlerFactory.
+func NewSearchHandlerFactory() *SearchHandlerFactory {
+	return &SearchHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *SearchHandlerFactory) Spec() *HandlerSpec {
+	return nil
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *SearchHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *SearchHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[SearchActor](f.handle), nil
+}
+
+func (f *SearchHandlerFactory) handle(ctx context.Context, char SearchActor, in *CommandInput) error {
+	room := char.Room()
+	bonus := skillBonus(char, assets.StatWIS, assets.SkillSearchPrefix)
+
+	var found []string
+	check := func(trap *assets.Trap, state *game.TrapState, label string) {
+		if trap == nil || !state.Armed || state.Found {
+			return
+		}
+		if combat.RollCheck(bonus) >= trap.CheckDifficulty() {
+			state.Found = true
+			found = append(found, fmt.Sprintf("You discover a trap on %s!", label))
+		}
+	}
+
+	room.ForEachExit(func(dir string, re *game.ResolvedExit) {
+		if h := re.Exit.Hidden; h != nil && !room.ExitVisible(char, dir) {
+			if combat.RollCheck(bonus) < h.SearchDifficulty() {
+				return
+			}
+			room.RevealExit(dir, char)
+			found = append(found, fmt.Sprintf("You discover a hidden exit leading %s!", dir))
+		}
+		if c := re.Exit.Closure; c != nil {
+			check(c.Trap, re.Trap(), fmt.Sprintf("the %s leading %s", c.Name, dir))
+		}
+	})
+	checkObj := func(oi *game.ObjectInstance) bool {
+		if c := oi.Object.Get().Closure; c != nil {
+			check(c.Trap, &oi.Trap, oi.ShortDesc())
+		}
+		return false
+	}
+	room.FindObjs(checkObj)
+	char.Inventory().FindObjs(checkObj)
+
+	room.Publish([]byte(fmt.Sprintf("%s searches the area carefully.", char.Name())), []string{char.Id()})
+	if len(found) == 0 {
+		char.Publish([]byte("You search carefully but find nothing unusual."), nil)
+		return nil
+	}
+	char.Publish([]byte(strings.Join(found, "\n")), nil)
+	return nil
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestSearchHandler(t *testing.T) {
+	tests := map[string]struct {
+		difficulty int
+		armed      bool
+		wantFound  bool
+	}{
+		"easy trap found": {
+			difficulty: 1, armed: true, wantFound: true,
+		},
+		"well hidden trap missed": {
+			difficulty: 100, armed: true, wantFound: false,
+		},
+		"sprung trap ignored": {
+			difficulty: 1, armed: false, wantFound: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci, re := newTrappedDoorRoom(t, nil, &assets.Trap{Type: assets.TrapTypeAlarm, Difficulty: tc.difficulty})
+			re.Trap().Armed = tc.armed
+
+			if err := (&SearchHandlerFactory{}).handle(context.Background(), ci, &CommandInput{Actor: ci}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if re.Trap().Found != tc.wantFound {
+				t.Errorf("found = %v, want %v", re.Trap().Found, tc.wantFound)
+			}
+		})
+	}
+}
+
+func TestSearchHandler_HiddenExit(t *testing.T) {
+	tests := map[string]struct {
+		hidden       assets.HiddenExit
+		wantSearcher bool
+		wantOthers   bool
+	}{
+		"easy exit revealed to everyone": {
+			hidden: assets.HiddenExit{Difficulty: 1}, wantSearcher: true, wantOthers: true,
+		},
+		"timed discovery is personal": {
+			hidden: assets.HiddenExit{Difficulty: 1, Duration: 10}, wantSearcher: true,
+		},
+		"well hidden exit missed": {
+			hidden: assets.HiddenExit{Difficulty: 100},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := game.NewRoomInstance(storage.NewResolvedSmartIdentifier("cellar", &assets.Room{
+				Name:  "Cellar",
+				Zone:  storage.NewResolvedSmartIdentifier("z", &assets.Zone{ResetMode: assets.ZoneResetNever}),
+				Exits: map[string]assets.Exit{"down": {Hidden: &tc.hidden}},
+			}))
+			if err != nil {
+				t.Fatalf("NewRoomInstance: %v", err)
+			}
+			searcher := newTestPlayer("s", "Searcher", room)
+			searcher.Character.Get().BaseStats = map[assets.StatKey]int{assets.StatWIS: 10}
+			other := newTestPlayer("o", "Other", room)
+
+			if err := (&SearchHandlerFactory{}).handle(context.Background(), searcher, &CommandInput{Actor: searcher}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := room.ExitVisible(searcher, "down"); got != tc.wantSearcher {
+				t.Errorf("visible to searcher = %v, want %v", got, tc.wantSearcher)
+			}
+			if got := room.ExitVisible(other, "down"); got != tc.wantOthers {
+				t.Errorf("visible to others = %v, want %v", got, tc.wantOthers)
+			}
+		})
+	}
+}
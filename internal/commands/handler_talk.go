@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// TalkActor provides the character state needed by the talk handler.
+type TalkActor interface {
+	Talk(mi *game.MobileInstance) error
+	Reply(n int) error
+	ShowReplies() error
+}
+
+var _ TalkActor = (*game.CharacterInstance)(nil)
+
+// TalkHandlerFactory creates handlers for conversations with mobiles. With a
+// target, the player starts talking to that mobile; with a reply config, the
+// player answers with that numbered reply; with neither, the mobile's last
+// words and the replies on offer are shown again.
+//
+// Targets:
+//   - target (optional): the mobile to talk to
+//
+// Config:
+//   - reply (optional): the number of the reply to give
+type TalkHandlerFactory struct{}
+
+// NewTalkHandlerFactory creates a handler factory for talk and reply commands.
+func NewTalkHandlerFactory() *TalkHandlerFactory {
+	return &TalkHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *TalkHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Targets: []TargetRequirement{
+			{Name: "target", Type: targetTypeMobile, Required: false},
+		},
+		Config: []ConfigRequirement{
+			{Name: "reply", Required: false},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *TalkHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *TalkHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[TalkActor](f.handle), nil
+}
+
+func (f *TalkHandlerFactory) handle(ctx context.Context, char TalkActor, in *CommandInput) error {
+	if target := in.FirstTarget("target"); target != nil && target.Actor != nil {
+		mi, ok := target.Actor.Actor().(*game.MobileInstance)
+		if !ok || errors.Is(char.Talk(mi), game.ErrNoDialogue) {
+			return NewUserError(fmt.Sprintf("%s has nothing to say to you.", display.Capitalize(target.Actor.Name)))
+		}
+		return nil
+	}
+
+	choice := in.Config["reply"]
+	if choice == "" {
+		if errors.Is(char.ShowReplies(), game.ErrNotTalking) {
+			return NewUserError("Talk to whom?")
+		}
+		return nil
+	}
+	n, err := strconv.Atoi(choice)
+	if err != nil {
+		return NewUserError("Reply with the number of what you want to say.")
+	}
+	switch err := char.Reply(n); {
+	case errors.Is(err, game.ErrNotTalking):
+		return NewUserError("You aren't talking to anyone.")
+	case errors.Is(err, game.ErrNoSuchReply):
+		return NewUserError("That isn't one of your choices.")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestTalkHandler(t *testing.T) {
+	dlg := &assets.Dialogue{Start: "hi", Nodes: map[string]*assets.DialogueNode{
+		"hi":  {Text: "Hello.", Replies: []assets.DialogueReply{{Text: "Hello yourself.", Next: "bye"}}},
+		"bye": {Text: "Off you go."},
+	}}
+	tests := map[string]struct {
+		talkTo   string // mob to talk to first; empty skips talking
+		reply    string
+		expErr   string
+		wantLast string // substring of the last message Bob got
+	}{
+		"talk opens the dialogue": {
+			talkTo:   "guard",
+			wantLast: "1) Hello yourself.",
+		},
+		"reply moves on": {
+			talkTo:   "guard",
+			reply:    "1",
+			wantLast: "Off you go.",
+		},
+		"mob with nothing to say": {
+			talkTo: "dog",
+			expErr: "The dog has nothing to say to you.",
+		},
+		"reply out of range": {
+			talkTo: "guard",
+			reply:  "4",
+			expErr: "That isn't one of your choices.",
+		},
+		"reply without talking": {
+			reply:  "1",
+			expErr: "You aren't talking to anyone.",
+		},
+		"talk without a target": {
+			expErr: "Talk to whom?",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, err := newTestRoom("gate", "Gate", "town")
+			if err != nil {
+				t.Fatalf("newTestRoom: %v", err)
+			}
+			bob, msgs := newRecordingPlayer("bob", "Bob", room)
+			guard := newCombatMob("guard", "the guard")
+			room.AddMob(guard)
+			guard.Mobile.Get().Dialogue = storage.NewResolvedSmartIdentifier("guard-chat", dlg)
+			dog := mobInRoom(t, room, "dog", "the dog")
+			mobs := map[string]*TargetRef{
+				"guard": {Type: targetTypeMobile, Actor: actorRefFromMob(guard)},
+				"dog":   {Type: targetTypeMobile, Actor: actorRefFromMob(dog)},
+			}
+			h := (&TalkHandlerFactory{}).handle
+
+			if tc.talkTo != "" {
+				err = h(context.Background(), bob, &CommandInput{Targets: map[string][]*TargetRef{"target": {mobs[tc.talkTo]}}})
+			}
+			if err == nil && (tc.reply != "" || tc.talkTo == "") {
+				err = h(context.Background(), bob, &CommandInput{Config: map[string]string{"reply": tc.reply}})
+			}
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := drainAll(msgs)
+			if len(got) == 0 || !strings.Contains(got[len(got)-1], tc.wantLast) {
+				t.Errorf("messages = %q, want last to contain %q", got, tc.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// Time actions drive the time handler's dispatch.
+// Commands declare one of these as the `action` config field.
+const (
+	timeActionTime    = "time"
+	timeActionWeather = "weather"
+)
+
+// TimeActor provides the character state needed by the time handler.
+type TimeActor interface {
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+}
+
+var _ TimeActor = (*game.CharacterInstance)(nil)
+
+// TimeHandlerFactory creates handlers that report the world calendar or the
+// weather in the actor's zone.
+// Config:
+//   - action (required): "time" or "weather"
+type TimeHandlerFactory struct{}
+
+// NewTimeHandlerFactory creates a handler factory for time/weather commands.
+func NewTimeHandlerFactory() *TimeHandlerFactory {
+	return &TimeHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *TimeHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "action", Required: true},
+		},
+	}
+}
+
+// ValidateConfig checks that action is time or weather.
+func (f *TimeHandlerFactory) ValidateConfig(config map[string]string) error {
+	switch config["action"] {
+	case timeActionTime, timeActionWeather:
+		return nil
+	default:
+		return errors.New("action must be time or weather")
+	}
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *TimeHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[TimeActor](f.handle), nil
+}
+
+func (f *TimeHandlerFactory) handle(ctx context.Context, char TimeActor, in *CommandInput) error {
+	room := char.Room()
+	if room == nil || room.Zone() == nil || room.Zone().World() == nil {
+		return NewUserError("You have lost all track of time.")
+	}
+	zone := room.Zone()
+
+	msg := zone.World().Calendar().String()
+	if in.Config["action"] == timeActionWeather {
+		msg = zone.Weather().String()
+		if room.IsIndoors() {
+			msg = "You have no feeling about the weather at all."
+		}
+	}
+	char.Publish([]byte(msg), nil)
+	return nil
+}
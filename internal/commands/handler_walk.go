@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// maxWalkSteps is the longest route a single walk can queue.
+const maxWalkSteps = 50
+
+// speedwalkDirs maps speedwalk letters to directions.
+var speedwalkDirs = map[byte]string{
+	'n': "north",
+	'e': "east",
+	's': "south",
+	'w': "west",
+	'u': "up",
+	'd': "down",
+}
+
+// WalkActor provides the character state needed by the walk handler.
+type WalkActor interface {
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	HasGrant(key, arg string) bool
+	HasVisited(roomId string) bool
+	IsInCombat() bool
+	QueueWalk(dirs []string)
+	StopWalking()
+	IsWalking() bool
+}
+
+var _ WalkActor = (*game.CharacterInstance)(nil)
+
+// WalkHandlerFactory creates handlers that queue a walk, taken one room per
+// tick. The route is either a speedwalk string such as "3n2e", "to <place>"
+// for the shortest way to the nearest visited room whose name contains
+// place, or "stop" to abandon the walk.
+// Config:
+//   - route (required): the speedwalk, "to <place>", or "stop"
+type WalkHandlerFactory struct{}
+
+// NewWalkHandlerFactory creates a new WalkHandlerFactory.
+func NewWalkHandlerFactory() *WalkHandlerFactory {
+	return &WalkHandlerFactory{}
+}
+
+// Spec returns the handler's target and config requirements.
+func (f *WalkHandlerFactory) Spec() *HandlerSpec {
+	return &HandlerSpec{
+		Config: []ConfigRequirement{
+			{Name: "route", Required: true},
+		},
+	}
+}
+
+// ValidateConfig performs custom validation on the command config.
+func (f *WalkHandlerFactory) ValidateConfig(config map[string]string) error {
+	return nil
+}
+
+// Create returns a compiled CommandFunc for this handler.
+func (f *WalkHandlerFactory) Create() (CommandFunc, error) {
+	return Adapt[WalkActor](f.handle), nil
+}
+
+func (f *WalkHandlerFactory) handle(ctx context.Context, actor WalkActor, in *CommandInput) error {
+	route := strings.TrimSpace(in.Config["route"])
+	lower := strings.ToLower(route)
+
+	if lower == "stop" {
+		if !actor.IsWalking() {
+			return NewUserError("You aren't walking anywhere.")
+		}
+		actor.StopWalking()
+		actor.Publish([]byte("You stop walking."), nil)
+		return nil
+	}
+
+	if actor.IsInCombat() {
+		return NewUserError("You can't walk away while fighting!")
+	}
+
+	if place, ok := strings.CutPrefix(lower, "to "); ok {
+		return f.walkTo(actor, strings.TrimSpace(place))
+	}
+
+	dirs, ok := parseSpeedwalk(lower)
+	if !ok {
+		return NewUserError(fmt.Sprintf("%q isn't a route. Try directions like 3n2e, or walk to a place you've been.", route))
+	}
+	actor.QueueWalk(dirs)
+	actor.Publish([]byte("You set off."), nil)
+	return nil
+}
+
+// walkTo queues the shortest walk to the nearest visited room whose name
+// contains place.
+func (f *WalkHandlerFactory) walkTo(actor WalkActor, place string) error {
+	from := actor.Room()
+	if from == nil {
+		return NewUserError("You are in an invalid location.")
+	}
+	if place == "" {
+		return NewUserError("Walk to where?")
+	}
+
+	var dest *game.RoomInstance
+	path, ok := game.FindPath(from, actor, maxWalkSteps, func(ri *game.RoomInstance) bool {
+		if !actor.HasVisited(ri.Room.Id()) || !strings.Contains(strings.ToLower(ri.Room.Get().Name), place) {
+			return false
+		}
+		dest = ri
+		return true
+	})
+	if !ok {
+		return NewUserError(fmt.Sprintf("You don't know the way to '%s'.", place))
+	}
+	if len(path) == 0 {
+		return NewUserError("You're already there.")
+	}
+
+	actor.QueueWalk(path)
+	actor.Publish([]byte(fmt.Sprintf("You set off toward %s.", dest.Room.Get().Name)), nil)
+	return nil
+}
+
+// parseSpeedwalk expands a speedwalk string such as "3n2e" or "nnw" into
+// single steps; each direction letter may be preceded by a repeat count.
+// Returns false if s isn't a speedwalk or would walk more than maxWalkSteps.
+func parseSpeedwalk(s string) ([]string, bool) {
+	var dirs []string
+	count, counted := 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			count = count*10 + int(c-'0')
+			counted = true
+			if count > maxWalkSteps {
+				return nil, false
+			}
+			continue
+		}
+		dir, ok := speedwalkDirs[c]
+		if !ok {
+			return nil, false
+		}
+		n := 1
+		if counted {
+			n = count
+		}
+		for range n {
+			dirs = append(dirs, dir)
+		}
+		count, counted = 0, false
+	}
+	if counted || len(dirs) == 0 || len(dirs) > maxWalkSteps {
+		return nil, false
+	}
+	return dirs, true
+}
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestParseSpeedwalk(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		want   []string
+		wantOk bool
+	}{
+		"single letter":      {in: "n", want: []string{"north"}, wantOk: true},
+		"counts and letters": {in: "3n2e", want: []string{"north", "north", "north", "east", "east"}, wantOk: true},
+		"letters run on":     {in: "nud", want: []string{"north", "up", "down"}, wantOk: true},
+		"multi-digit count":  {in: "12w", want: slices.Repeat([]string{"west"}, 12), wantOk: true},
+		"trailing count":     {in: "2n3"},
+		"unknown letter":     {in: "3x"},
+		"zero count":         {in: "0n"},
+		"too long":           {in: "51n"},
+		"empty":              {in: ""},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseSpeedwalk(tc.in)
+			if ok != tc.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOk)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("dirs = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWalkHandler(t *testing.T) {
+	exit := func(room string) assets.Exit {
+		return assets.Exit{Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	town := storage.NewResolvedSmartIdentifier("town", &assets.Zone{ResetMode: assets.ZoneResetNever})
+	rooms := map[string]*assets.Room{
+		"square": {Name: "Town Square", Zone: town, Exits: map[string]assets.Exit{"north": exit("market")}},
+		"market": {Name: "Market", Zone: town, Exits: map[string]assets.Exit{"north": exit("temple"), "south": exit("square")}},
+		"temple": {Name: "Temple of Light", Zone: town, Exits: map[string]assets.Exit{"south": exit("market")}},
+	}
+
+	tests := map[string]struct {
+		route       string
+		walking     bool
+		inCombat    bool
+		expErr      bool
+		wantMsg     string
+		wantWalking bool
+	}{
+		"speedwalk is queued": {
+			route:       "2n",
+			wantMsg:     "You set off.",
+			wantWalking: true,
+		},
+		"walk to a visited room": {
+			route:       "to temple",
+			wantMsg:     "You set off toward Temple of Light.",
+			wantWalking: true,
+		},
+		"walk to an unvisited room": {
+			route:  "to market",
+			expErr: true,
+		},
+		"walk to where you stand": {
+			route:  "to square",
+			expErr: true,
+		},
+		"not a route": {
+			route:  "everywhere",
+			expErr: true,
+		},
+		"stop walking": {
+			route:   "stop",
+			walking: true,
+			wantMsg: "You stop walking.",
+		},
+		"stop when not walking": {
+			route:  "stop",
+			expErr: true,
+		},
+		"no walking away from a fight": {
+			route:    "2n",
+			inCombat: true,
+			expErr:   true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			world, err := newTestWorldWithRooms(rooms)
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			square := world.GetZone("town").GetRoom("square")
+			msgs := make(chan []byte, 10)
+			ci, err := game.NewCharacterInstance(storage.NewResolvedSmartIdentifier("p", &assets.Character{
+				Name:         "Walker",
+				VisitedRooms: []string{"temple"},
+			}), msgs, square)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+			if tc.walking {
+				ci.QueueWalk([]string{"north"})
+			}
+			if tc.inCombat {
+				mob := newCombatMob("rat", "a rat")
+				ci.EnsureThreat(mob.Id(), mob)
+			}
+
+			err = (&WalkHandlerFactory{}).handle(context.Background(), ci, &CommandInput{
+				Actor:  ci,
+				Config: map[string]string{"route": tc.route},
+			})
+			if tc.expErr != (err != nil) {
+				t.Fatalf("err = %v, expErr %v", err, tc.expErr)
+			}
+			if got := ci.IsWalking(); got != tc.wantWalking {
+				t.Errorf("IsWalking() = %v, want %v", got, tc.wantWalking)
+			}
+			if tc.wantMsg != "" {
+				if got := string(<-msgs); got != tc.wantMsg {
+					t.Errorf("message = %q, want %q", got, tc.wantMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Exec_speedwalk(t *testing.T) {
+	exit := func(room string) assets.Exit {
+		return assets.Exit{Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	town := storage.NewResolvedSmartIdentifier("town", &assets.Zone{ResetMode: assets.ZoneResetNever})
+	rooms := map[string]*assets.Room{
+		"square": {Name: "Town Square", Zone: town, Exits: map[string]assets.Exit{"north": exit("market")}},
+		"market": {Name: "Market", Zone: town, Exits: map[string]assets.Exit{"south": exit("square")}},
+	}
+
+	tests := map[string]struct {
+		word        string
+		expErr      string
+		wantWalking bool
+	}{
+		"speedwalk with a count": {
+			word:        "1n",
+			wantWalking: true,
+		},
+		"word of direction letters": {
+			word:   "news",
+			expErr: `Command "news" is unknown.`,
+		},
+		"not a speedwalk": {
+			word:   "3x",
+			expErr: `Command "3x" is unknown.`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			world, err := newTestWorldWithRooms(rooms)
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			square := world.GetZone("town").GetRoom("square")
+			ci, err := game.NewCharacterInstance(storage.NewResolvedSmartIdentifier("p", &assets.Character{Name: "Walker"}), make(chan []byte, 10), square)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+			h := &Handler{
+				factories: map[string]HandlerFactory{"walk": NewWalkHandlerFactory()},
+				compiled:  make(map[string]*compiledCommand),
+			}
+			if err := h.compile("walk", &assets.Command{
+				Handler: "walk",
+				Config:  map[string]string{"route": "{{ .Inputs.route }}"},
+				Inputs:  []assets.InputSpec{{Name: "route", Type: assets.InputTypeString, Required: true, Rest: true}},
+			}); err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+
+			err = h.Exec(context.Background(), ci, tc.word)
+
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Fatalf("err = %v, want %q", err, tc.expErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ci.IsWalking(); got != tc.wantWalking {
+				t.Errorf("IsWalking() = %v, want %v", got, tc.wantWalking)
+			}
+		})
+	}
+}
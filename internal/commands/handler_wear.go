@@ -73,7 +73,7 @@ func (f *WearHandlerFactory) wearOne(actor WearActor, target *TargetRef) error {
 	obj := target.Obj.instance.Object.Get()
 
 	if !obj.HasFlag(assets.ObjectFlagWearable) {
-		return NewUserError(fmt.Sprintf("You can't equip %s.", obj.ShortDesc))
+		return NewUserError(fmt.Sprintf("You can't equip %s.", target.Obj.Name))
 	}
 
 	oi := target.Obj.source.RemoveObj(target.Obj.InstanceId)
@@ -98,10 +98,16 @@ func (f *WearHandlerFactory) wearOne(actor WearActor, target *TargetRef) error {
 		if slotFull {
 			return NewUserError("You already have something equipped in that slot.")
 		}
-		return NewUserError(fmt.Sprintf("You have nowhere to equip %s.", obj.ShortDesc))
+		return NewUserError(fmt.Sprintf("You have nowhere to equip %s.", target.Obj.Name))
 	}
 
-	actor.Publish([]byte(fmt.Sprintf("You equip %s.", obj.ShortDesc)), nil)
-	actor.Room().Publish([]byte(fmt.Sprintf("%s equips %s.", actor.Name(), obj.ShortDesc)), []string{actor.Id()})
+	actor.Publish([]byte(fmt.Sprintf("You equip %s.", target.Obj.Name)), nil)
+	actor.Room().Publish([]byte(fmt.Sprintf("%s equips %s.", actor.Name(), target.Obj.Name)), []string{actor.Id()})
+
+	// Holding a light source in the light slot lights it.
+	if slot == assets.WearSlotLight && oi.Ignite() {
+		actor.Room().RefreshLight()
+		actor.Publish([]byte(fmt.Sprintf("You light %s.", target.Obj.Name)), nil)
+	}
 	return nil
 }
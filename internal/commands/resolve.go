@@ -202,7 +202,7 @@ func objRefFromInstance(oi *game.ObjectInstance, source ObjectRemover) *ObjectRe
 	}
 	return &ObjectRef{
 		InstanceId:  oi.InstanceId,
-		Name:        oi.Object.Get().ShortDesc,
+		Name:        oi.ShortDesc(),
 		Description: oi.Object.Get().DetailedDesc,
 		source:      source,
 		instance:    oi,
@@ -355,7 +355,7 @@ func FindTarget(raw string, tt targetType, spaces []SearchSpace) ([]*TargetRef,
 // Implementations decide where to look (room, zone, world, inventory, etc.)
 // without coupling the resolver to any particular game state type.
 type TargetScopes interface {
-	SpacesFor(s scope, actor game.Actor) ([]SearchSpace, error)
+	SpacesFor(s scope, actor game.Actor, sight sightRange) ([]SearchSpace, error)
 }
 
 // --- TargetResolver ---
@@ -427,7 +427,12 @@ func (r *TargetResolver) ResolveSpecs(specs []assets.TargetSpec, inputs map[stri
 			spaces = cs
 		} else {
 			s := parseScope(spec.Scopes)
-			spaces, err = r.scopes.SpacesFor(s, actor)
+			sight := sightRange{Range: spec.Range}
+			if spec.Direction != "" {
+				dir, _ := inputs[spec.Direction].(string)
+				sight.Direction = expandDirection(dir)
+			}
+			spaces, err = r.scopes.SpacesFor(s, actor, sight)
 			if err != nil {
 				return nil, err
 			}
@@ -466,7 +471,10 @@ func findWithNotFound(name string, spec assets.TargetSpec, spaces []SearchSpace,
 }
 
 // resolveRoomEnemies returns all actors in the room on the opposite side of the
-// caster, excluding the caster. Side is determined by game.IsPlayerSide.
+// caster, excluding the caster and anyone in a duel it isn't part of. Side is
+// determined by game.IsPlayerSide. A player-side caster also takes in the
+// other players, and their followers, it is already fighting and may attack
+// under the PvP rules.
 func resolveRoomEnemies(actor game.Actor) []*TargetRef {
 	ri := actor.Room()
 	if ri == nil {
@@ -477,10 +485,10 @@ func resolveRoomEnemies(actor game.Actor) []*TargetRef {
 
 	var refs []*TargetRef
 	ri.ForEachActor(func(a game.Actor) {
-		if a.Id() == actorId {
+		if a.Id() == actorId || game.CheckDuel(actor, a) != nil {
 			return
 		}
-		if game.IsPlayerSide(a) == casterPlayerSide {
+		if game.IsPlayerSide(a) == casterPlayerSide && !isPvPEnemy(actor, a) {
 			return
 		}
 		refs = append(refs, &TargetRef{
@@ -491,6 +499,13 @@ func resolveRoomEnemies(actor game.Actor) []*TargetRef {
 	return refs
 }
 
+// isPvPEnemy reports whether a player-side actor is one the player-side
+// caster is fighting and may attack.
+func isPvPEnemy(caster, a game.Actor) bool {
+	return game.IsPlayerSide(caster) && caster.HasThreatFrom(a.Id()) &&
+		!game.AreAllies(caster, a) && game.CheckPvP(caster, a) == nil
+}
+
 // resolveGroupInRoom returns all group members present in the actor's room,
 // including the actor. A solo actor (not in a group) returns only themselves.
 func resolveGroupInRoom(actor game.Actor) []*TargetRef {
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -73,7 +74,7 @@ type mockScopes struct {
 	spaces []SearchSpace
 }
 
-func (s *mockScopes) SpacesFor(scope, game.Actor) ([]SearchSpace, error) {
+func (s *mockScopes) SpacesFor(scope, game.Actor, sightRange) ([]SearchSpace, error) {
 	return s.spaces, nil
 }
 
@@ -570,3 +571,48 @@ func TestResolveSpecs_ScopeTarget(t *testing.T) {
 	}
 }
 
+func TestResolveRoomEnemies(t *testing.T) {
+	tests := map[string]struct {
+		bobPvP   bool
+		fighting bool // Alice is already fighting Bob
+		want     []string
+	}{
+		"mobs only": {
+			bobPvP: true,
+			want:   []string{"goblin"},
+		},
+		"player being fought": {
+			bobPvP:   true,
+			fighting: true,
+			want:     []string{"bob", "goblin"},
+		},
+		"player who has not agreed": {
+			fighting: true,
+			want:     []string{"goblin"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room, _ := newTestRoomInZone("r", "Room", "z")
+			alice := newTestPlayer("alice", "Alice", room)
+			bob := newTestPlayer("bob", "Bob", room)
+			setCombatReady(alice)
+			setCombatReady(bob)
+			alice.Asset().PvP = true
+			bob.Asset().PvP = tc.bobPvP
+			room.AddMob(newCombatMob("goblin", "a goblin"))
+			if tc.fighting {
+				alice.EnsureThreat(bob.Id(), bob)
+			}
+
+			var got []string
+			for _, ref := range resolveRoomEnemies(alice) {
+				got = append(got, ref.Actor.Actor().Id())
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("enemies = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
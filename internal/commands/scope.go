@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"strings"
+
 	"github.com/pixil98/go-mud/internal/assets"
 	"github.com/pixil98/go-mud/internal/game"
 )
@@ -51,6 +53,91 @@ func (darkRoomFinder) FindMobs(func(*game.MobileInstance) bool) []*game.MobileIn
 func (darkRoomFinder) FindObjs(func(*game.ObjectInstance) bool) []*game.ObjectInstance { return nil }
 func (darkRoomFinder) FindExit(string) (string, *game.ResolvedExit)                    { return "", nil }
 
+// hiddenExitFinder wraps a room so that exit lookups skip hidden exits the
+// viewer hasn't discovered. Everything else is searched as normal.
+type hiddenExitFinder struct {
+	*game.RoomInstance
+	viewer game.GrantHolder
+}
+
+func (f hiddenExitFinder) FindExit(name string) (string, *game.ResolvedExit) {
+	dir, re := f.RoomInstance.FindExit(name)
+	if re == nil || !f.ExitVisible(f.viewer, dir) {
+		return "", nil
+	}
+	return dir, re
+}
+
+// farRoomFinder searches a room seen from afar. Only the actors the viewer
+// can make out are found; nothing there is within reach to take or walk
+// through, and a dark room hides everyone in it.
+type farRoomFinder struct {
+	room   *game.RoomInstance
+	viewer game.Actor
+}
+
+func (f farRoomFinder) FindPlayers(match func(*game.CharacterInstance) bool) []*game.CharacterInstance {
+	if f.room.Restricts(f.viewer, assets.RoomFlagDark) {
+		return nil
+	}
+	return f.room.FindPlayers(func(ci *game.CharacterInstance) bool {
+		return game.CanSee(f.viewer, ci) && match(ci)
+	})
+}
+
+func (f farRoomFinder) FindMobs(match func(*game.MobileInstance) bool) []*game.MobileInstance {
+	if f.room.Restricts(f.viewer, assets.RoomFlagDark) {
+		return nil
+	}
+	return f.room.FindMobs(func(mi *game.MobileInstance) bool {
+		return game.CanSee(f.viewer, mi) && match(mi)
+	})
+}
+
+func (f farRoomFinder) FindObjs(func(*game.ObjectInstance) bool) []*game.ObjectInstance { return nil }
+func (f farRoomFinder) FindExit(string) (string, *game.ResolvedExit)                    { return "", nil }
+
+// sightRange narrows the adjacent_rooms scope to how many rooms out to look
+// and the direction to look along; an empty Direction looks every way.
+type sightRange struct {
+	Direction string
+	Range     int
+}
+
+// expandDirection expands a one-letter direction abbreviation such as "n".
+func expandDirection(s string) string {
+	s = strings.ToLower(s)
+	if len(s) == 1 {
+		if dir, ok := speedwalkDirs[s[0]]; ok {
+			return dir
+		}
+	}
+	return s
+}
+
+// adjacentSpaces returns a search space for each room the actor can see
+// into, nearest rooms first.
+func adjacentSpaces(actor game.Actor, sight sightRange) []SearchSpace {
+	room := actor.Room()
+	reach := max(sight.Range, 1)
+	var lines [][]*game.RoomInstance
+	room.ForEachExit(func(dir string, _ *game.ResolvedExit) {
+		if sight.Direction == "" || dir == sight.Direction {
+			lines = append(lines, room.SightLine(actor, dir, reach))
+		}
+	})
+
+	var spaces []SearchSpace
+	for dist := range reach {
+		for _, line := range lines {
+			if dist < len(line) {
+				spaces = append(spaces, SearchSpace{Finder: farRoomFinder{line[dist], actor}})
+			}
+		}
+	}
+	return spaces
+}
+
 // followerFinder searches the actor's followers list. When groupedOnly is
 // true, only grouped followers are included. Satisfies TargetFinder by
 // checking each follower's type (player or mob) against the matcher.
@@ -102,7 +189,7 @@ func NewWorldScopes() *WorldScopes {
 
 // SpacesFor returns search spaces for the given scope flags, ordered from
 // narrowest (inventory) to broadest (world).
-func (ws *WorldScopes) SpacesFor(s scope, actor game.Actor) ([]SearchSpace, error) {
+func (ws *WorldScopes) SpacesFor(s scope, actor game.Actor, sight sightRange) ([]SearchSpace, error) {
 
 	var spaces []SearchSpace
 
@@ -124,7 +211,7 @@ func (ws *WorldScopes) SpacesFor(s scope, actor game.Actor) ([]SearchSpace, erro
 	}
 	if s&scopeRoom != 0 {
 		room := actor.Room()
-		var finder TargetFinder = room
+		var finder TargetFinder = hiddenExitFinder{room, actor}
 		if room.Restricts(actor, assets.RoomFlagDark) {
 			finder = darkRoomFinder{}
 		}
@@ -133,6 +220,9 @@ func (ws *WorldScopes) SpacesFor(s scope, actor game.Actor) ([]SearchSpace, erro
 			Remover: room,
 		})
 	}
+	if s&scopeAdjacentRooms != 0 {
+		spaces = append(spaces, adjacentSpaces(actor, sight)...)
+	}
 	if s&scopeFollowers != 0 {
 		spaces = append(spaces, SearchSpace{
 			Finder: followerFinder{actor: actor},
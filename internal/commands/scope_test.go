@@ -88,7 +88,7 @@ func TestSpacesForDarkRoom(t *testing.T) {
 			}
 
 			ws := NewWorldScopes()
-			spaces, err := ws.SpacesFor(scopeRoom, actor)
+			spaces, err := ws.SpacesFor(scopeRoom, actor, sightRange{})
 			if err != nil {
 				t.Fatalf("SpacesFor: %v", err)
 			}
@@ -130,3 +130,104 @@ func TestSpacesForDarkRoom(t *testing.T) {
 		})
 	}
 }
+
+func TestSpacesForAdjacentRooms(t *testing.T) {
+	exit := func(room string) assets.Exit {
+		return assets.Exit{Room: storage.NewSmartIdentifier[*assets.Room](room)}
+	}
+	tests := map[string]struct {
+		sight     sightRange
+		ridgeDark bool
+		invisible bool
+		nearer    bool // put a second goblin nearer, on the trail
+		input     string
+		wantMob   string // "" when nothing should be found
+	}{
+		"next room is in reach by default": {
+			input:   "orc",
+			wantMob: "hut-orc",
+		},
+		"two rooms off needs range": {
+			sight:   sightRange{Range: 2},
+			input:   "goblin",
+			wantMob: "ridge-goblin",
+		},
+		"out of range": {
+			sight: sightRange{Range: 1},
+			input: "goblin",
+		},
+		"along the named direction": {
+			sight:   sightRange{Range: 2, Direction: "north"},
+			input:   "goblin",
+			wantMob: "ridge-goblin",
+		},
+		"not along another direction": {
+			sight: sightRange{Range: 2, Direction: "east"},
+			input: "goblin",
+		},
+		"nearest match first": {
+			sight:   sightRange{Range: 2},
+			nearer:  true,
+			input:   "goblin",
+			wantMob: "trail-goblin",
+		},
+		"dark rooms hide their occupants": {
+			sight:     sightRange{Range: 2},
+			ridgeDark: true,
+			input:     "goblin",
+		},
+		"invisible actors are not seen": {
+			sight:     sightRange{Range: 2},
+			invisible: true,
+			input:     "goblin",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			town := storage.NewResolvedSmartIdentifier("z", &assets.Zone{ResetMode: assets.ZoneResetNever})
+			var ridgePerks []assets.Perk
+			if tc.ridgeDark {
+				ridgePerks = []assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagDark)}}
+			}
+			world, err := newTestWorldWithRooms(map[string]*assets.Room{
+				"camp":  {Name: "Camp", Zone: town, Exits: map[string]assets.Exit{"north": exit("trail"), "east": exit("hut")}},
+				"trail": {Name: "Trail", Zone: town, Exits: map[string]assets.Exit{"north": exit("ridge")}},
+				"ridge": {Name: "Ridge", Zone: town, Perks: ridgePerks},
+				"hut":   {Name: "Hut", Zone: town},
+			})
+			if err != nil {
+				t.Fatalf("newTestWorldWithRooms: %v", err)
+			}
+			zone := world.GetZone("z")
+			camp := zone.GetRoom("camp")
+
+			goblin := mobInRoom(t, zone.GetRoom("ridge"), "ridge-goblin", "goblin")
+			if tc.invisible {
+				goblin.PerkCache = *game.NewPerkCache([]assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantInvisible}}, nil)
+			}
+			mobInRoom(t, zone.GetRoom("hut"), "hut-orc", "orc")
+			if tc.nearer {
+				mobInRoom(t, zone.GetRoom("trail"), "trail-goblin", "goblin")
+			}
+			actor := &gametest.BaseActor{ActorId: "archer", ActorName: "Archer", ActorRoom: camp}
+
+			spaces, err := NewWorldScopes().SpacesFor(scopeAdjacentRooms, actor, tc.sight)
+			if err != nil {
+				t.Fatalf("SpacesFor: %v", err)
+			}
+			refs, err := FindTarget(tc.input, targetTypeMobile, spaces)
+			if tc.wantMob == "" {
+				if err == nil {
+					t.Fatalf("expected nothing found, got %v", refs[0].Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindTarget: %v", err)
+			}
+			if got := refs[0].Actor.Actor().Id(); got != tc.wantMob {
+				t.Errorf("found %q, want %q", got, tc.wantMob)
+			}
+		})
+	}
+}
@@ -55,3 +55,20 @@ func newCombatMob(instanceId, name string) *game.MobileInstance {
 	mi.SetResource(assets.ResourceHp, 100)
 	return mi
 }
+
+// memStore is an in-memory Storer for building worlds in tests.
+type memStore[T storage.ValidatingSpec] map[string]T
+
+func (s memStore[T]) Save(id string, v T) error { s[id] = v; return nil }
+func (s memStore[T]) Get(id string) T           { return s[id] }
+func (s memStore[T]) GetAll() map[string]T      { return s }
+
+// newTestWorldWithRooms builds a world from room specs keyed by id. Every
+// zone named by a room is created, and exits are resolved as at startup.
+func newTestWorldWithRooms(rooms map[string]*assets.Room) (*game.WorldState, error) {
+	zones := memStore[*assets.Zone]{}
+	for _, r := range rooms {
+		zones[r.Zone.Id()] = &assets.Zone{ResetMode: assets.ZoneResetNever}
+	}
+	return game.NewWorldState(zones, memStore[*assets.Room](rooms))
+}
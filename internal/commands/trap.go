@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/combat"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/game"
+)
+
+// trapVictim provides the actor state a sprung trap acts on.
+type trapVictim interface {
+	Id() string
+	Name() string
+	Room() *game.RoomInstance
+	Publish(data []byte, exclude []string)
+	Resource(name string) (current, max int)
+	AdjustResource(name string, delta int, overfill bool)
+	AddTimedPerks(name string, perks []assets.Perk, ticks int)
+}
+
+var _ trapVictim = (*game.CharacterInstance)(nil)
+
+// statActor is implemented by actors with ability scores.
+type statActor interface {
+	EffectiveStats() map[assets.StatKey]game.Stat
+}
+
+// skillBonus returns what an actor adds to a d20 skill check: the governing
+// stat's modifier, for actors that have ability scores, plus flat perks
+// under the skill's prefix.
+func skillBonus(actor assets.PerkReader, stat assets.StatKey, prefix string) int {
+	bonus := actor.ModifierValue(assets.BuildKey(prefix, assets.ModSuffixFlat))
+	if sa, ok := actor.(statActor); ok {
+		bonus += sa.EffectiveStats()[stat].Mod()
+	}
+	return bonus
+}
+
+// holdsKey reports whether the inventory contains the lock's key.
+// A closure without a lock has no key, so nobody holds it.
+func holdsKey(inv *game.Inventory, lock *assets.Lock) bool {
+	return lock != nil && inv != nil && inv.FindObjByDef(lock.KeyId.Id()) != nil
+}
+
+// closureTarget is an exit or container closure resolved from a target.
+type closureTarget struct {
+	label   string // how messages refer to it, e.g. "the door" or "a chest"
+	closure *assets.Closure
+	trap    *game.TrapState
+	exit    *game.ResolvedExit   // set for exits
+	obj     *game.ObjectInstance // set for containers
+}
+
+// resolveClosureTarget returns the closure behind an exit or container
+// target, or nil if the target has none.
+func resolveClosureTarget(target *TargetRef) *closureTarget {
+	switch target.Type {
+	case targetTypeExit:
+		re := target.Exit.exit
+		if re.Exit.Closure == nil {
+			return nil
+		}
+		return &closureTarget{label: "the " + re.Exit.Closure.Name, closure: re.Exit.Closure, trap: re.Trap(), exit: re}
+	case targetTypeObject:
+		oi := target.Obj.instance
+		def := oi.Object.Get()
+		if !def.HasFlag(assets.ObjectFlagContainer) || def.Closure == nil {
+			return nil
+		}
+		label := def.Closure.Name
+		if label == "" {
+			label = oi.ShortDesc()
+		}
+		return &closureTarget{label: label, closure: def.Closure, trap: &oi.Trap, obj: oi}
+	}
+	return nil
+}
+
+// isLocked reports whether the closure is currently locked.
+func (ct *closureTarget) isLocked() bool {
+	if ct.exit != nil {
+		return ct.exit.IsLocked()
+	}
+	return ct.obj.Locked
+}
+
+// unlock unlocks the closure, including the far side of a door.
+func (ct *closureTarget) unlock(room *game.RoomInstance) {
+	if ct.obj != nil {
+		ct.obj.Locked = false
+		return
+	}
+	applyExitAction(assets.ClosureActionUnlock, ct.exit)
+	if _, other := ct.exit.OtherSide(room); other != nil {
+		applyExitAction(assets.ClosureActionUnlock, other)
+	}
+}
+
+// springTrap fires an armed trap on the victim and disarms it. Damage traps
+// wound but never kill: the victim is left with at least one hit point.
+func springTrap(victim trapVictim, trap *assets.Trap, state *game.TrapState, label string) {
+	state.Armed = false
+
+	msg := trap.Message
+	switch trap.Type {
+	case assets.TrapTypeDamage:
+		if msg == "" {
+			msg = fmt.Sprintf("A hidden blade springs from %s and cuts you!", label)
+		}
+		dice, err := combat.ParseDice(trap.Damage)
+		if err != nil {
+			slog.Warn("invalid trap damage", "damage", trap.Damage, "error", err)
+			break
+		}
+		cur, _ := victim.Resource(assets.ResourceHp)
+		if dmg := min(dice.Roll(), cur-1); dmg > 0 {
+			victim.AdjustResource(assets.ResourceHp, -dmg, false)
+		}
+	case assets.TrapTypePoison:
+		if msg == "" {
+			msg = fmt.Sprintf("A tiny needle in %s pricks you. You feel sick.", label)
+		}
+		victim.AddTimedPerks("trap:poison", trap.Perks, trap.Duration)
+	case assets.TrapTypeAlarm:
+		if msg == "" {
+			msg = fmt.Sprintf("An alarm rings out as you disturb %s!", label)
+		}
+		if room := victim.Room(); room != nil && room.Zone() != nil {
+			// Those in the room hear it up close via the room message below.
+			var here []string
+			room.ForEachPlayer(func(id string, _ *game.CharacterInstance) { here = append(here, id) })
+			room.Zone().Publish([]byte("You hear an alarm bell ring out nearby!"), here)
+		}
+	}
+
+	victim.Publish([]byte(msg), nil)
+	if room := victim.Room(); room != nil {
+		room.Publish([]byte(fmt.Sprintf("%s sets off a trap on %s!", victim.Name(), label)), []string{victim.Id()})
+	}
+}
+
+// triggerTrap springs the closure's trap if it is armed and the actor
+// doesn't carry the key. Returns true if the trap fired.
+func (ct *closureTarget) triggerTrap(victim trapVictim, inv *game.Inventory) bool {
+	if ct.closure.Trap == nil || !ct.trap.Armed || holdsKey(inv, ct.closure.Lock) {
+		return false
+	}
+	springTrap(victim, ct.closure.Trap, ct.trap, ct.label)
+	return true
+}
+
+// capitalizedLabel returns the closure's label for the start of a sentence.
+func (ct *closureTarget) capitalizedLabel() string {
+	return display.Capitalize(ct.label)
+}
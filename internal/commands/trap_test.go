@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/game"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestSpringTrap(t *testing.T) {
+	poisonKey := assets.BuildKey(assets.ResourcePrefix, assets.ResourceHp, assets.ResourceAspectRegen)
+	tests := map[string]struct {
+		trap      assets.Trap
+		hp        int
+		wantHP    int
+		wantRegen int
+	}{
+		"damage": {
+			trap: assets.Trap{Type: assets.TrapTypeDamage, Damage: "5"}, hp: 50, wantHP: 45,
+		},
+		"damage never kills": {
+			trap: assets.Trap{Type: assets.TrapTypeDamage, Damage: "50"}, hp: 10, wantHP: 1,
+		},
+		"poison applies perks": {
+			trap: assets.Trap{Type: assets.TrapTypePoison, Duration: 5, Perks: []assets.Perk{
+				{Type: assets.PerkTypeModifier, Key: poisonKey, Value: -3},
+			}},
+			hp: 50, wantHP: 50, wantRegen: -3,
+		},
+		"alarm does no harm": {
+			trap: assets.Trap{Type: assets.TrapTypeAlarm}, hp: 50, wantHP: 50,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mi := newCombatMob("rat", "a rat")
+			mi.SetResource(assets.ResourceHp, tc.hp)
+			state := &game.TrapState{Armed: true}
+
+			springTrap(mi, &tc.trap, state, "the door")
+
+			if state.Armed {
+				t.Error("trap still armed after springing")
+			}
+			if hp, _ := mi.Resource(assets.ResourceHp); hp != tc.wantHP {
+				t.Errorf("hp = %d, want %d", hp, tc.wantHP)
+			}
+			if got := mi.ModifierValue(poisonKey); got != tc.wantRegen {
+				t.Errorf("hp regen = %d, want %d", got, tc.wantRegen)
+			}
+		})
+	}
+}
+
+func TestClosureHandler_OpenSpringsTrap(t *testing.T) {
+	tests := map[string]struct {
+		hasKey    bool
+		wantArmed bool
+	}{
+		"opened without the key":   {hasKey: false, wantArmed: false},
+		"opened by the key holder": {hasKey: true, wantArmed: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			lock := &assets.Lock{KeyId: storage.NewSmartIdentifier[*assets.Object]("key")}
+			ci, re := newTrappedDoorRoom(t, lock, &assets.Trap{Type: assets.TrapTypeAlarm})
+			if tc.hasKey {
+				ci.Inventory().AddObj(newWeighted(t, "key", 0))
+			}
+
+			in := &CommandInput{
+				Actor:   ci,
+				Config:  map[string]string{"action": assets.ClosureActionOpen},
+				Targets: northTarget(re),
+			}
+			if err := (&ClosureHandlerFactory{}).handle(context.Background(), ci, in); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if re.IsClosed() {
+				t.Error("door still closed")
+			}
+			if re.Trap().Armed != tc.wantArmed {
+				t.Errorf("armed = %v, want %v", re.Trap().Armed, tc.wantArmed)
+			}
+		})
+	}
+}
@@ -78,6 +78,7 @@ const (
 	scopeGroup
 	scopeFollowers
 	scopeGroupedFollowers
+	scopeAdjacentRooms
 )
 
 // parseScope converts string scope names to a scope bitmask.
@@ -103,6 +104,8 @@ func parseScope(scopes []string) scope {
 			result |= scopeFollowers
 		case assets.ScopeGroupedFollowers:
 			result |= scopeGroupedFollowers
+		case assets.ScopeAdjacentRooms:
+			result |= scopeAdjacentRooms
 		}
 	}
 	return result
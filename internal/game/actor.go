@@ -96,12 +96,15 @@ type ActorInstance struct {
 	equipment  *Equipment
 	resources  map[string]int // current values only; max derived from PerkCache
 	level      int
+	resting    bool
 
 	room           *RoomInstance
 	deathProcessed atomic.Bool
+	backRank       atomic.Bool // stands in the back rank of their group
 	threatTable    ThreatTable
 	cooldown       map[string][]int // auto_use arg → per-duplicate cooldown counters
 	commander      Commander
+	walkQueue      []string // directions still to walk, one per tick
 
 	tickMsgBuf []string // per-tick message buffer, flushed at end of world tick
 
@@ -180,12 +183,35 @@ func (a *ActorInstance) resourceNames() map[string]struct{} {
 	return names
 }
 
+// tickCarried ticks the actor's inventory and equipment and emits the
+// resulting object events. A decayed equipped container spills into the
+// inventory. Caller must not hold the owning type's lock.
+func (a *ActorInstance) tickCarried() {
+	evs := a.inventory.Tick()
+	for _, ev := range a.equipment.Tick() {
+		if ev.Type == ObjectEventDecay {
+			for _, oi := range ev.Obj.Spill() {
+				a.inventory.AddObj(oi)
+			}
+		}
+		evs = append(evs, ev)
+	}
+	emitCarriedEvents(a.self, evs)
+}
+
 // regenTick applies flat regen from perks to all resources.
-// Formula per resource: sum(core.resource.<name>.regen).
+// Formula per resource: sum(core.resource.<name>.regen), plus
+// sum(core.resource.<name>.rest_regen) while resting, halved for each
+// depleted survival resource (hunger, thirst).
 // Caller must hold the owning type's write lock.
 func (a *ActorInstance) regenTick() {
+	penalty := a.depletedSurvival()
 	for name := range a.resources {
 		regen := a.ModifierValue(assets.BuildKey(assets.ResourcePrefix, name, assets.ResourceAspectRegen))
+		if a.resting {
+			regen += a.ModifierValue(assets.BuildKey(assets.ResourcePrefix, name, assets.ResourceAspectRest))
+		}
+		regen >>= penalty
 		if regen > 0 {
 			a.adjustResource(name, regen, false)
 		}
@@ -363,6 +389,51 @@ func (a *ActorInstance) GroupedFollowers() []Actor {
 	return out
 }
 
+// --- Walking ---
+
+// QueueWalk replaces the actor's queued walk with dirs. One step is taken
+// each tick until the queue runs out, a step fails, or combat starts.
+func (a *ActorInstance) QueueWalk(dirs []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.walkQueue = append([]string(nil), dirs...)
+}
+
+// StopWalking abandons any queued walk.
+func (a *ActorInstance) StopWalking() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.walkQueue = nil
+}
+
+// IsWalking reports whether the actor has steps left to walk.
+func (a *ActorInstance) IsWalking() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.walkQueue) > 0
+}
+
+// walkTick takes the next queued step through the actor's commander. A
+// failed step abandons the rest of the walk and tells the actor why.
+// Returns true if a step was attempted.
+func (a *ActorInstance) walkTick(ctx context.Context) bool {
+	a.mu.Lock()
+	if len(a.walkQueue) == 0 || a.commander == nil {
+		a.mu.Unlock()
+		return false
+	}
+	dir := a.walkQueue[0]
+	a.walkQueue = a.walkQueue[1:]
+	commander := a.commander
+	a.mu.Unlock()
+
+	if err := commander.ExecCommand(ctx, dir); err != nil {
+		a.StopWalking()
+		a.self.Publish([]byte(err.Error()), nil)
+	}
+	return true
+}
+
 // --- Commander ---
 
 // SetCommander sets the actor's command executor.
@@ -372,13 +443,37 @@ func (a *ActorInstance) SetCommander(c Commander) {
 	a.commander = c
 }
 
+// boundCommander returns the actor's command executor, or nil.
+func (a *ActorInstance) boundCommander() Commander {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.commander
+}
+
 // --- Threat table ---
 
+// IsResting reports whether the actor is resting.
+func (a *ActorInstance) IsResting() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.resting
+}
+
+// SetResting sits the actor down to rest or stands it back up.
+func (a *ActorInstance) SetResting(resting bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.resting = resting
+}
+
 // EnsureThreat idempotently adds an enemy with an initial threat of 1.
+// Entering combat ends any rest and abandons any queued walk.
 func (a *ActorInstance) EnsureThreat(enemyId string, enemy Actor) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	a.threatTable.ensureEntry(enemyId, enemy)
+	a.resting = false
+	a.walkQueue = nil
 }
 
 // AddThreatFrom increments the threat that sourceId has generated on this actor.
@@ -433,6 +528,19 @@ func (a *ActorInstance) ClearThreatTable() {
 	clear(a.cooldown)
 }
 
+// Disengage ends the actor's fights: it drops every enemy from its threat
+// table and drops itself from theirs. Returns the former enemies.
+func (a *ActorInstance) Disengage() []Actor {
+	enemies := a.ThreatEnemies()
+	for _, enemy := range enemies {
+		if t, ok := enemy.(interface{ RemoveThreatEntry(string) }); ok {
+			t.RemoveThreatEntry(a.InstanceId)
+		}
+	}
+	a.ClearThreatTable()
+	return enemies
+}
+
 // ResolveCombatTarget returns the best target from the threat table.
 // preferredId is checked first; falls back to highest threat.
 // Returns nil if the table is empty.
@@ -461,18 +569,18 @@ func (a *ActorInstance) combatTick(ctx context.Context, preferredId string) {
 	}
 
 	a.autoUseTick(ctx, a.GrantArgs(assets.PerkGrantAutoUse), target)
-	a.sweepDeadEnemies()
+	a.sweepDeadEnemies(ctx)
 }
 
 // sweepDeadEnemies removes dead enemies from the threat table and processes
 // their death exactly once via ClaimDeath.
-func (a *ActorInstance) sweepDeadEnemies() {
+func (a *ActorInstance) sweepDeadEnemies(ctx context.Context) {
 	for _, enemy := range a.ThreatEnemies() {
 		if enemy.IsAlive() {
 			continue
 		}
 		if enemy.ClaimDeath() {
-			processDeath(enemy, a.self.Room())
+			processDeath(ctx, enemy, a.self, a.self.Room())
 		}
 		a.RemoveThreatEntry(enemy.Id())
 	}
@@ -2,6 +2,8 @@ package game
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -780,12 +782,15 @@ func TestActorInstance_regenTick(t *testing.T) {
 	hpPerks := []assets.Perk{
 		{Type: assets.PerkTypeModifier, Key: assets.BuildKey(assets.ResourcePrefix, assets.ResourceHp, assets.ResourceAspectMax), Value: maxHP},
 		{Type: assets.PerkTypeModifier, Key: assets.BuildKey(assets.ResourcePrefix, assets.ResourceHp, assets.ResourceAspectRegen), Value: 3},
+		{Type: assets.PerkTypeModifier, Key: assets.BuildKey(assets.ResourcePrefix, assets.ResourceHp, assets.ResourceAspectRest), Value: 2},
 	}
 	tests := map[string]struct {
 		startHP  int
+		resting  bool
 		wantHP   int
 	}{
 		"regen applied when below max": {startHP: 10, wantHP: 13},
+		"resting adds rest regen":      {startHP: 10, resting: true, wantHP: 15},
 		"regen does not exceed max":    {startHP: 19, wantHP: maxHP},
 		"full HP stays full":           {startHP: maxHP, wantHP: maxHP},
 	}
@@ -794,6 +799,7 @@ func TestActorInstance_regenTick(t *testing.T) {
 			a := &ActorInstance{PerkCache: *NewPerkCache(hpPerks, nil)}
 			a.initResources()
 			a.setResourceCurrent(assets.ResourceHp, tc.startHP)
+			a.SetResting(tc.resting)
 			a.mu.Lock()
 			a.regenTick()
 			a.mu.Unlock()
@@ -857,3 +863,76 @@ func TestActorInstance_autoUseTick(t *testing.T) {
 		})
 	}
 }
+
+func TestActorInstance_walkTick(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		queue        []string
+		fail         bool
+		threat       bool
+		wantStepped  bool
+		wantCommands []string
+		wantLeft     bool
+		wantMsg      string
+	}{
+		"nothing queued": {},
+		"takes the next step": {
+			queue:        []string{"north", "east"},
+			wantStepped:  true,
+			wantCommands: []string{"north"},
+			wantLeft:     true,
+		},
+		"last step empties the queue": {
+			queue:        []string{"north"},
+			wantStepped:  true,
+			wantCommands: []string{"north"},
+		},
+		"failed step abandons the walk": {
+			queue:        []string{"north", "east"},
+			fail:         true,
+			wantStepped:  true,
+			wantCommands: []string{"north"},
+			wantMsg:      "The door is closed.",
+		},
+		"combat abandons the walk": {
+			queue:  []string{"north", "east"},
+			threat: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			fc := &fakeCommander{}
+			if tc.fail {
+				fc.err = errors.New("The door is closed.")
+			}
+			ci := newTestCI("walker", "Walker")
+			msgs := make(chan []byte, 1)
+			ci.msgs = msgs
+			ci.commander = fc
+			ci.QueueWalk(tc.queue)
+			if tc.threat {
+				enemy := newTestCI("enemy", "Enemy")
+				ci.EnsureThreat(enemy.Id(), enemy)
+			}
+
+			if got := ci.walkTick(ctx); got != tc.wantStepped {
+				t.Errorf("walkTick() = %v, want %v", got, tc.wantStepped)
+			}
+			if !slices.Equal(fc.commands, tc.wantCommands) {
+				t.Errorf("commands = %v, want %v", fc.commands, tc.wantCommands)
+			}
+			if got := ci.IsWalking(); got != tc.wantLeft {
+				t.Errorf("IsWalking() = %v, want %v", got, tc.wantLeft)
+			}
+			var msg string
+			select {
+			case m := <-msgs:
+				msg = string(m)
+			default:
+			}
+			if msg != tc.wantMsg {
+				t.Errorf("message = %q, want %q", msg, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// Calendar dimensions, borrowed from CircleMUD.
+const (
+	HoursPerDay   = 24
+	DaysPerMonth  = 35
+	MonthsPerYear = 17
+
+	minutesPerHour  = 60
+	minutesPerDay   = minutesPerHour * HoursPerDay
+	minutesPerMonth = minutesPerDay * DaysPerMonth
+	minutesPerYear  = minutesPerMonth * MonthsPerYear
+)
+
+// DefaultHoursPerTick is the calendar speed when none is configured:
+// one game hour every 20 ticks.
+const DefaultHoursPerTick = 0.05
+
+// Hours at which each phase of the day begins.
+const (
+	hourDawn  = 5
+	hourDay   = 6
+	hourDusk  = 21
+	hourNight = 22
+)
+
+var weekDays = []string{
+	"the Day of the Moon",
+	"the Day of the Bull",
+	"the Day of the Deception",
+	"the Day of Thunder",
+	"the Day of Freedom",
+	"the Day of the Great Gods",
+	"the Day of the Sun",
+}
+
+var monthNames = []string{
+	"Month of Winter",
+	"Month of the Winter Wolf",
+	"Month of the Frost Giant",
+	"Month of the Old Forces",
+	"Month of the Grand Struggle",
+	"Month of the Spring",
+	"Month of Nature",
+	"Month of Futility",
+	"Month of the Dragon",
+	"Month of the Sun",
+	"Month of the Heat",
+	"Month of the Battle",
+	"Month of the Dark Shades",
+	"Month of the Shadows",
+	"Month of the Long Shadows",
+	"Month of the Ancient Darkness",
+	"Month of the Great Evil",
+}
+
+// Calendar is the world clock. It counts game minutes and is advanced once
+// per world tick.
+//
+// Calendar is safe for concurrent use.
+type Calendar struct {
+	mu             sync.RWMutex
+	minute         int // game minutes since the start of year 0
+	minutesPerTick int
+}
+
+// NewCalendar creates a calendar advancing hoursPerTick game hours per tick
+// (at least one game minute). The clock starts at 6am, the start of the day,
+// on the first day of year 1.
+func NewCalendar(hoursPerTick float64) *Calendar {
+	return &Calendar{
+		minute:         minutesPerYear + hourDay*minutesPerHour,
+		minutesPerTick: max(1, int(math.Round(hoursPerTick*minutesPerHour))),
+	}
+}
+
+// Advance moves the clock forward one tick and returns the number of hour
+// boundaries crossed.
+func (c *Calendar) Advance() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before := c.minute / minutesPerHour
+	c.minute += c.minutesPerTick
+	return c.minute/minutesPerHour - before
+}
+
+// Hour returns the hour of the day, 0-23.
+func (c *Calendar) Hour() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.minute % minutesPerDay / minutesPerHour
+}
+
+// Month returns the month of the year, 0-based.
+func (c *Calendar) Month() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.minute % minutesPerYear / minutesPerMonth
+}
+
+// TimeOfDay returns the current phase of the day.
+func (c *Calendar) TimeOfDay() assets.TimeOfDay {
+	return timeOfDay(c.Hour())
+}
+
+func timeOfDay(hour int) assets.TimeOfDay {
+	switch {
+	case hour >= hourNight || hour < hourDawn:
+		return assets.TimeNight
+	case hour < hourDay:
+		return assets.TimeDawn
+	case hour < hourDusk:
+		return assets.TimeDay
+	default:
+		return assets.TimeDusk
+	}
+}
+
+// TicksUntilDawn returns how many ticks remain before the sun next rises.
+func (c *Calendar) TicksUntilDawn() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	into := c.minute % minutesPerDay
+	left := hourDawn*minutesPerHour - into
+	if left <= 0 {
+		left += minutesPerDay
+	}
+	return (left + c.minutesPerTick - 1) / c.minutesPerTick
+}
+
+// String describes the date and time, e.g.
+// "It is 5 o'clock pm, on the Day of the Moon.\nThe 3rd Day of the Month of Winter, Year 1."
+func (c *Calendar) String() string {
+	c.mu.RLock()
+	minute := c.minute
+	c.mu.RUnlock()
+
+	hour := minute % minutesPerDay / minutesPerHour
+	totalDays := minute / minutesPerDay
+	day := totalDays%DaysPerMonth + 1
+	month := minute % minutesPerYear / minutesPerMonth
+	year := minute / minutesPerYear
+
+	clock := hour % 12
+	if clock == 0 {
+		clock = 12
+	}
+	meridiem := "am"
+	if hour >= 12 {
+		meridiem = "pm"
+	}
+	return fmt.Sprintf("It is %d o'clock %s, on %s.\nThe %s Day of the %s, Year %d.",
+		clock, meridiem, weekDays[totalDays%len(weekDays)], ordinal(day), monthNames[month], year)
+}
+
+// ordinal formats n with its English suffix (1st, 2nd, 3rd, 4th, 11th...).
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// calendarAt returns a calendar in year 1 at the given day (0-based), hour
+// and minute, advancing minutesPerTick per tick.
+func calendarAt(day, hour, minute, minutesPerTick int) *Calendar {
+	return &Calendar{
+		minute:         minutesPerYear + day*minutesPerDay + hour*minutesPerHour + minute,
+		minutesPerTick: minutesPerTick,
+	}
+}
+
+func TestCalendar_TimeOfDay(t *testing.T) {
+	tests := map[string]struct {
+		hour int
+		want assets.TimeOfDay
+	}{
+		"midnight":     {hour: 0, want: assets.TimeNight},
+		"before dawn":  {hour: 4, want: assets.TimeNight},
+		"dawn":         {hour: 5, want: assets.TimeDawn},
+		"morning":      {hour: 6, want: assets.TimeDay},
+		"evening":      {hour: 20, want: assets.TimeDay},
+		"dusk":         {hour: 21, want: assets.TimeDusk},
+		"late evening": {hour: 22, want: assets.TimeNight},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := calendarAt(0, tc.hour, 0, 1).TimeOfDay(); got != tc.want {
+				t.Errorf("TimeOfDay() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_Advance(t *testing.T) {
+	tests := map[string]struct {
+		minute         int
+		minutesPerTick int
+		wantHours      int
+		wantHour       int
+	}{
+		"within the hour":      {minute: 10, minutesPerTick: 3, wantHours: 0, wantHour: 8},
+		"crosses an hour":      {minute: 58, minutesPerTick: 3, wantHours: 1, wantHour: 9},
+		"several hours a tick": {minute: 0, minutesPerTick: 180, wantHours: 3, wantHour: 11},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := calendarAt(0, 8, tc.minute, tc.minutesPerTick)
+			if got := c.Advance(); got != tc.wantHours {
+				t.Errorf("Advance() = %d, want %d", got, tc.wantHours)
+			}
+			if got := c.Hour(); got != tc.wantHour {
+				t.Errorf("Hour() = %d, want %d", got, tc.wantHour)
+			}
+		})
+	}
+}
+
+func TestCalendar_TicksUntilDawn(t *testing.T) {
+	tests := map[string]struct {
+		hour, minute   int
+		minutesPerTick int
+		want           int
+	}{
+		"nightfall":            {hour: 22, minutesPerTick: 60, want: 7},
+		"after midnight":       {hour: 2, minute: 30, minutesPerTick: 60, want: 3},
+		"rounds partial ticks": {hour: 4, minute: 59, minutesPerTick: 3, want: 1},
+		"just past dawn":       {hour: 5, minute: 1, minutesPerTick: 60, want: 24},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := calendarAt(0, tc.hour, tc.minute, tc.minutesPerTick).TicksUntilDawn(); got != tc.want {
+				t.Errorf("TicksUntilDawn() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_String(t *testing.T) {
+	tests := map[string]struct {
+		day, hour int
+		want      string
+	}{
+		"first morning": {
+			day: 0, hour: 6,
+			want: "It is 6 o'clock am, on the Day of the Moon.\nThe 1st Day of the Month of Winter, Year 1.",
+		},
+		"noon": {
+			day: 1, hour: 12,
+			want: "It is 12 o'clock pm, on the Day of the Bull.\nThe 2nd Day of the Month of Winter, Year 1.",
+		},
+		"next month": {
+			day: DaysPerMonth + 10, hour: 0,
+			want: "It is 12 o'clock am, on the Day of Thunder.\nThe 11th Day of the Month of the Winter Wolf, Year 1.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := calendarAt(tc.day, tc.hour, 0, 1).String(); got != tc.want {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
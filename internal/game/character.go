@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +39,14 @@ type CharacterInstance struct {
 	combatTargetId string
 	currentAP      int
 	lastActivity   time.Time
+	survivalTicks  int  // ticks since hunger/thirst last drained
+	encumbered     bool // true while the encumbrance perk source is applied
+	visited        map[string]bool
+	conversation   *conversation // nil unless talking to a mobile
+	pvpVictims     map[string]time.Time // players killed, by character ID, and when
+	duel           *duel                // nil unless in a duel
+	challenge      *duelChallenge       // the latest challenge to a duel, if any
+	lootTurn       int                  // whose turn it is to loot, when leading a round-robin group
 
 	done chan struct{}
 
@@ -54,11 +63,7 @@ func NewCharacterInstance(char storage.SmartIdentifier[*assets.Character], msgs
 		return nil, fmt.Errorf("materializing inventory for %q: %w", char.Id(), err)
 	}
 
-	// Build perk cache: race perks (own) + equipment (source).
-	var racePerks []assets.Perk
-	if r := c.Race.Get(); r != nil {
-		racePerks = r.Perks
-	}
+	// Build perk cache: race and learned perks (own) + equipment (source).
 
 	ci := &CharacterInstance{
 		msgs:      msgs,
@@ -69,13 +74,20 @@ func NewCharacterInstance(char storage.SmartIdentifier[*assets.Character], msgs
 			equipment: eq,
 			level:     c.Level,
 			room:      room,
-			PerkCache: *NewPerkCache(racePerks, map[string]PerkSource{"equipment": eq}),
+			PerkCache: *NewPerkCache(ownPerks(c), map[string]PerkSource{"equipment": eq}),
 		},
 		lastActivity: time.Now(),
 		done:         make(chan struct{}),
+		visited:      make(map[string]bool, len(c.VisitedRooms)),
 	}
 
 	ci.self = ci
+	for _, id := range c.VisitedRooms {
+		ci.visited[id] = true
+	}
+	if room != nil {
+		ci.visited[room.Room.Id()] = true
+	}
 
 	// Initialize resource pools from perks, then restore persisted current values.
 	ci.initResources()
@@ -88,6 +100,16 @@ func NewCharacterInstance(char storage.SmartIdentifier[*assets.Character], msgs
 	return ci, nil
 }
 
+// ownPerks returns the perks a character holds in their own right: their
+// race's and those learned in play.
+func ownPerks(c *assets.Character) []assets.Perk {
+	var perks []assets.Perk
+	if r := c.Race.Get(); r != nil {
+		perks = append(perks, r.Perks...)
+	}
+	return append(perks, c.Perks...)
+}
+
 // --- Connection lifecycle ---
 
 // Done returns the channel that is closed when this session is evicted by a reconnection.
@@ -218,12 +240,14 @@ func (ci *CharacterInstance) MarkActive() {
 }
 
 // Tick advances one game tick: expires timed perks, resets action points,
-// and regenerates resources when out of combat.
+// and regenerates resources and takes the next queued walking step when out
+// of combat.
 func (ci *CharacterInstance) Tick(ctx context.Context) {
-	ci.inventory.Tick()
-	ci.equipment.Tick()
+	ci.tickCarried()
+	ci.refreshEncumbrance()
 	ci.PerkCache.Tick()
 	ci.ResetAP()
+	ci.duelTick()
 
 	if ci.IsInCombat() {
 		// Update the sticky target each tick: prefer combatTargetId if it is
@@ -246,6 +270,19 @@ func (ci *CharacterInstance) Tick(ctx context.Context) {
 		ci.mu.Lock()
 		ci.regenTick()
 		ci.mu.Unlock()
+		ci.walkTick(ctx)
+	}
+
+	ci.mu.Lock()
+	var warnings []string
+	ci.survivalTicks++
+	if ci.survivalTicks >= SurvivalInterval {
+		ci.survivalTicks = 0
+		warnings = ci.drainTick()
+	}
+	ci.mu.Unlock()
+	for _, msg := range warnings {
+		ci.QueueTickMsg(msg)
 	}
 }
 
@@ -308,6 +345,21 @@ func (ci *CharacterInstance) GainXP(xp int) bool {
 	return char.Level < MaxLevel && char.Experience >= ExpForLevel(char.Level+1)
 }
 
+// Gold returns how much gold the character has.
+func (ci *CharacterInstance) Gold() int {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.Character.Get().Gold
+}
+
+// AddGold gives the character gold, or takes it away when n is negative.
+func (ci *CharacterInstance) AddGold(n int) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	c := ci.Character.Get()
+	c.Gold = max(c.Gold+n, 0)
+}
+
 // Publish delivers data to the character's client. Non-blocking; drops the
 // message if the channel is full or the character's id appears in exclude.
 func (ci *CharacterInstance) Publish(data []byte, exclude []string) {
@@ -334,9 +386,53 @@ func (ci *CharacterInstance) Move(fromRoom, toRoom *RoomInstance) {
 
 	ci.mu.Lock()
 	ci.room = toRoom
+	ci.resting = false
+	ci.conversation = nil
+	if ci.visited == nil {
+		ci.visited = make(map[string]bool)
+	}
+	ci.visited[toRoom.Room.Id()] = true
 	ci.RemoveSource("room")
 	ci.AddSource("room", toRoom.Perks)
 	ci.mu.Unlock()
+
+	fromRoom.RefreshLight()
+	toRoom.RefreshLight()
+}
+
+// BindPoint returns the zone and room the character recalls to. Both are
+// empty when no bind point has been set.
+func (ci *CharacterInstance) BindPoint() (zoneId, roomId string) {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	c := ci.Character.Get()
+	return c.BindZone, c.BindRoom
+}
+
+// SetBindPoint makes room the place the character recalls to.
+func (ci *CharacterInstance) SetBindPoint(room *RoomInstance) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	c := ci.Character.Get()
+	c.BindZone, c.BindRoom = room.Room.Get().Zone.Id(), room.Room.Id()
+}
+
+// Learn gives the character perks for good. They are saved with the
+// character.
+func (ci *CharacterInstance) Learn(perks ...assets.Perk) {
+	ci.mu.Lock()
+	c := ci.Character.Get()
+	c.Perks = append(c.Perks, perks...)
+	own := ownPerks(c)
+	ci.mu.Unlock()
+	ci.SetOwn(own)
+}
+
+// HasVisited reports whether the character has ever been in the room.
+func (ci *CharacterInstance) HasVisited(roomId string) bool {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.visited[roomId]
 }
 
 // SaveCharacter persists the character's current runtime state to the character store.
@@ -348,7 +444,12 @@ func (ci *CharacterInstance) SaveCharacter(chars storage.Storer[*assets.Characte
 	for name, cur := range ci.resources {
 		c.Resources[name] = cur
 	}
+	c.VisitedRooms = make([]string, 0, len(ci.visited))
+	for id := range ci.visited {
+		c.VisitedRooms = append(c.VisitedRooms, id)
+	}
 	ci.mu.RUnlock()
+	sort.Strings(c.VisitedRooms)
 
 	// Convert runtime inventory to spawn specs, skipping decayable items (Inventory self-locks).
 	c.Inventory = nil
@@ -378,6 +479,7 @@ func (ci *CharacterInstance) SaveCharacter(chars storage.Storer[*assets.Characte
 func objectInstanceToSpawn(oi *ObjectInstance) assets.ObjectSpawn {
 	spawn := assets.ObjectSpawn{
 		Object: oi.Object,
+		State:  oi.state(),
 	}
 	if oi.Contents != nil {
 		oi.Contents.ForEachObj(func(_ string, ci *ObjectInstance) {
@@ -474,7 +576,7 @@ func (ci *CharacterInstance) StatSections() []StatSection {
 		sections = append(sections, StatSection{
 			Header: "Experience",
 			Lines: []StatLine{
-				{Value: fmt.Sprintf("  XP: %d  (MAX LEVEL)", char.Experience)},
+				{Value: fmt.Sprintf("  XP: %d  (MAX LEVEL)  Gold: %d", char.Experience, char.Gold)},
 			},
 		})
 	} else {
@@ -482,7 +584,21 @@ func (ci *CharacterInstance) StatSections() []StatSection {
 		sections = append(sections, StatSection{
 			Header: "Experience",
 			Lines: []StatLine{
-				{Value: fmt.Sprintf("  XP: %d  TNL: %d", char.Experience, tnl)},
+				{Value: fmt.Sprintf("  XP: %d  TNL: %d  Gold: %d", char.Experience, tnl, char.Gold)},
+			},
+		})
+	}
+
+	// PvP section, for characters who have taken part in player fights
+	if char.PvP || char.PvPKills > 0 || char.PvPDeaths > 0 {
+		state := "off"
+		if char.PvP {
+			state = "on"
+		}
+		sections = append(sections, StatSection{
+			Header: "PvP",
+			Lines: []StatLine{
+				{Value: fmt.Sprintf("  PvP: %s  Kills: %d  Deaths: %d", state, char.PvPKills, char.PvPDeaths)},
 			},
 		})
 	}
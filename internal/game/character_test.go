@@ -2,6 +2,7 @@ package game
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
@@ -810,7 +811,7 @@ func TestCharacterInstance_sweepDeadEnemies(t *testing.T) {
 			room.AddMob(enemy)
 
 			ci.EnsureThreat("enemy", enemy)
-			ci.sweepDeadEnemies()
+			ci.sweepDeadEnemies(context.Background())
 
 			if got := ci.HasThreatFrom("enemy"); got != tc.wantInTable {
 				t.Errorf("HasThreatFrom(enemy) = %v, want %v", got, tc.wantInTable)
@@ -1053,3 +1054,105 @@ func TestCharacterInstance_SaveCharacter(t *testing.T) {
 		})
 	}
 }
+
+func TestCharacterInstance_Visited(t *testing.T) {
+	tests := map[string]struct {
+		saved       []string
+		moveTo      string
+		wantVisited []string
+		wantUnknown string
+	}{
+		"starting room is visited": {
+			wantVisited: []string{"start"},
+			wantUnknown: "elsewhere",
+		},
+		"saved rooms are restored": {
+			saved:       []string{"old"},
+			wantVisited: []string{"old", "start"},
+			wantUnknown: "elsewhere",
+		},
+		"moving marks the destination": {
+			saved:       []string{"old"},
+			moveTo:      "next",
+			wantVisited: []string{"next", "old", "start"},
+			wantUnknown: "elsewhere",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := newTestRoom("start")
+			ci, err := NewCharacterInstance(
+				storage.NewResolvedSmartIdentifier("hero", &assets.Character{Name: "Hero", VisitedRooms: tc.saved}),
+				nil,
+				start,
+			)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+			if tc.moveTo != "" {
+				ci.Move(start, newTestRoom(tc.moveTo))
+			}
+
+			for _, id := range tc.wantVisited {
+				if !ci.HasVisited(id) {
+					t.Errorf("HasVisited(%q) = false, want true", id)
+				}
+			}
+			if ci.HasVisited(tc.wantUnknown) {
+				t.Errorf("HasVisited(%q) = true, want false", tc.wantUnknown)
+			}
+
+			store := newFakeStore[*assets.Character](nil)
+			if err := ci.SaveCharacter(store); err != nil {
+				t.Fatalf("SaveCharacter: %v", err)
+			}
+			if got := store.saved["hero"].VisitedRooms; !slices.Equal(got, tc.wantVisited) {
+				t.Errorf("saved VisitedRooms = %v, want %v", got, tc.wantVisited)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_BindPoint(t *testing.T) {
+	tests := map[string]struct {
+		savedZone string
+		savedRoom string
+		bindTo    string
+		wantZone  string
+		wantRoom  string
+	}{
+		"no bind point": {},
+		"saved bind point is kept": {
+			savedZone: "town", savedRoom: "temple",
+			wantZone: "town", wantRoom: "temple",
+		},
+		"binding replaces the old point": {
+			savedZone: "town", savedRoom: "temple",
+			bindTo:   "inn",
+			wantZone: "town", wantRoom: "inn",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci, err := NewCharacterInstance(
+				storage.NewResolvedSmartIdentifier("hero", &assets.Character{Name: "Hero", BindZone: tc.savedZone, BindRoom: tc.savedRoom}),
+				nil,
+				newTestRoom("start"),
+			)
+			if err != nil {
+				t.Fatalf("NewCharacterInstance: %v", err)
+			}
+			if tc.bindTo != "" {
+				town := storage.NewResolvedSmartIdentifier("town", &assets.Zone{ResetMode: assets.ZoneResetNever})
+				ri, err := NewRoomInstance(storage.NewResolvedSmartIdentifier(tc.bindTo, &assets.Room{Name: tc.bindTo, Zone: town}))
+				if err != nil {
+					t.Fatalf("NewRoomInstance: %v", err)
+				}
+				ci.SetBindPoint(ri)
+			}
+			if zone, room := ci.BindPoint(); zone != tc.wantZone || room != tc.wantRoom {
+				t.Errorf("BindPoint() = %q, %q, want %q, %q", zone, room, tc.wantZone, tc.wantRoom)
+			}
+		})
+	}
+}
@@ -1,13 +1,52 @@
 package game
 
-import "fmt"
+import (
+	"context"
+	"fmt"
 
-// processDeath handles an actor's death: creates drops, removes the actor
-// from the room, places drops, and distributes XP to player contributors.
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// processDeath handles an actor's death: runs a mob's death triggers with
+// killer as the actor, creates drops, removes the actor from the room, places
+// drops, and distributes XP, quest kill credit and faction standing changes
+// to player contributors and the members of their groups in the room. XP is
+// split across each group, and the killer's group gets the loot. A player
+// who falls in a duel or an arena is defeated instead, and killing a player
+// elsewhere is recorded rather than rewarded with XP.
 // Caller must have already verified ClaimDeath() returned true.
-func processDeath(dead Actor, room *RoomInstance) {
+func processDeath(ctx context.Context, dead Actor, killer Actor, room *RoomInstance) {
+	victim, _ := dead.(*CharacterInstance)
+	if victim != nil {
+		if d := victim.currentDuel(); d != nil {
+			d.finish(d.opponent(victim), victim)
+			return
+		}
+		if room.IsArena() {
+			victim.defeatInArena(room)
+			return
+		}
+	}
+	if mi, ok := dead.(*MobileInstance); ok {
+		mi.fireDeath(ctx, killer)
+	}
+	if victim != nil {
+		processPvPDeath(victim, room)
+	}
 	drops := dead.OnDeath()
 	room.RemoveMob(dead.Id())
+
+	snap := dead.ThreatSnapshot()
+	mi, _ := dead.(*MobileInstance)
+	var groups [][]*CharacterInstance
+	var looter *CharacterInstance
+	var members []*CharacterInstance
+	if len(snap) > 0 && victim == nil {
+		groups = killGroups(room.Zone().World(), snap, room)
+		if mi != nil {
+			looter, members = claimLoot(killer, groups, drops)
+		}
+	}
 	for _, obj := range drops {
 		room.AddObj(obj)
 	}
@@ -17,24 +56,32 @@ func processDeath(dead Actor, room *RoomInstance) {
 		ci.QueueTickMsg(deathMsg)
 	})
 
-	snap := dead.ThreatSnapshot()
-	if len(snap) == 0 {
+	if len(snap) == 0 || victim != nil {
 		return
 	}
 	mobLevel := dead.Level()
 	baseXP := BaseExpForLevel(mobLevel)
-	world := room.Zone().World()
-	for actorId := range snap {
-		ci := world.GetPlayer(actorId)
-		if ci == nil {
-			continue
+	for _, members := range groups {
+		levels := make([]int, len(members))
+		for i, ci := range members {
+			levels[i] = ci.Level()
 		}
-		xp := int(float64(baseXP) * LevelDiffMultiplier(ci.Character.Get().Level, mobLevel))
-		canAdvance := ci.GainXP(xp)
-		msg := fmt.Sprintf("You receive %d experience points.", xp)
-		if canAdvance {
-			msg += "\nYou feel ready to advance to the next level!"
+		for i, share := range groupExpShares(baseXP, levels) {
+			ci := members[i]
+			xp := int(float64(share) * LevelDiffMultiplier(levels[i], mobLevel))
+			canAdvance := ci.GainXP(xp)
+			msg := fmt.Sprintf("You receive %d experience points.", xp)
+			if canAdvance {
+				msg += "\nYou feel ready to advance to the next level!"
+			}
+			ci.QueueTickMsg(msg)
+			if mi != nil {
+				ci.advanceQuests(questEvent{kind: assets.QuestObjectiveKill, id: mi.Mobile.Id()}, ci.QueueTickMsg)
+				ci.killedMember(mi.Mobile.Get().Faction, ci.QueueTickMsg)
+			}
 		}
-		ci.QueueTickMsg(msg)
+	}
+	if looter != nil {
+		shareLoot(mi, looter, members, drops, room)
 	}
 }
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+)
+
+var (
+	// ErrNoDialogue is returned when talking to a mobile with nothing to say.
+	ErrNoDialogue = errors.New("mobile has no dialogue")
+	// ErrNotTalking is returned when replying outside a conversation.
+	ErrNotTalking = errors.New("not in a conversation")
+	// ErrNoSuchReply is returned for a reply number that isn't on offer.
+	ErrNoSuchReply = errors.New("no such reply")
+)
+
+// conversation is a player's place in a mobile's dialogue. It lasts until
+// the player runs out of replies or either of them leaves the room.
+type conversation struct {
+	mob     *MobileInstance
+	node    string
+	offered []int // indexes of the node's replies on offer, in the order shown
+}
+
+// Talk opens a conversation with mi at the start of its dialogue, ending any
+// conversation the character was already in.
+func (ci *CharacterInstance) Talk(mi *MobileInstance) error {
+	dlg := mi.Mobile.Get().Dialogue.Get()
+	if dlg == nil {
+		return ErrNoDialogue
+	}
+	ci.EndConversation()
+	ci.converse(&conversation{mob: mi}, dlg.Start)
+	return nil
+}
+
+// Reply answers the current conversation with the n-th reply on offer,
+// counting from 1.
+func (ci *CharacterInstance) Reply(n int) error {
+	conv := ci.currentConversation()
+	if conv == nil {
+		return ErrNotTalking
+	}
+	node := conv.mob.Mobile.Get().Dialogue.Get().Nodes[conv.node]
+	if n < 1 || n > len(conv.offered) {
+		return ErrNoSuchReply
+	}
+	reply := &node.Replies[conv.offered[n-1]]
+	// What the player carries may have changed since the reply was offered.
+	if !ci.meetsAll(reply.Conditions) {
+		return ErrNoSuchReply
+	}
+
+	ci.Publish([]byte(fmt.Sprintf("You say, \"%s\"", reply.Text)), nil)
+	if reply.Next == "" {
+		ci.EndConversation()
+		return nil
+	}
+	ci.converse(conv, reply.Next)
+	return nil
+}
+
+// ShowReplies repeats what the mobile last said and the replies on offer.
+func (ci *CharacterInstance) ShowReplies() error {
+	conv := ci.currentConversation()
+	if conv == nil {
+		return ErrNotTalking
+	}
+	node := conv.mob.Mobile.Get().Dialogue.Get().Nodes[conv.node]
+	ci.Publish([]byte(ci.nodeText(conv, node)), nil)
+	return nil
+}
+
+// Conversing reports whether the character is talking to a mobile.
+func (ci *CharacterInstance) Conversing() bool {
+	return ci.currentConversation() != nil
+}
+
+// EndConversation drops the character out of any conversation.
+func (ci *CharacterInstance) EndConversation() {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.conversation = nil
+}
+
+// currentConversation returns the character's conversation, ending it first
+// if the mobile has died or left the room.
+func (ci *CharacterInstance) currentConversation() *conversation {
+	ci.mu.RLock()
+	conv, room := ci.conversation, ci.room
+	ci.mu.RUnlock()
+	if conv == nil || (conv.mob.IsAlive() && conv.mob.Room() == room) {
+		return conv
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if ci.conversation == conv {
+		ci.conversation = nil
+	}
+	return nil
+}
+
+// converse moves the conversation to the named node: the mobile speaks, the
+// node's effects happen, and the replies that apply are offered. With none
+// to offer, the conversation ends.
+func (ci *CharacterInstance) converse(conv *conversation, name string) {
+	node := conv.mob.Mobile.Get().Dialogue.Get().Nodes[name]
+	for i := range node.Effects {
+		ci.dialogueEffect(conv.mob, &node.Effects[i])
+	}
+
+	var offered []int
+	for i := range node.Replies {
+		if ci.meetsAll(node.Replies[i].Conditions) {
+			offered = append(offered, i)
+		}
+	}
+	next := &conversation{mob: conv.mob, node: name, offered: offered}
+	ci.Publish([]byte(ci.nodeText(next, node)), nil)
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if len(offered) == 0 {
+		ci.conversation = nil
+		return
+	}
+	ci.conversation = next
+}
+
+// nodeText renders what the mobile says at node, followed by the numbered
+// replies on offer.
+func (ci *CharacterInstance) nodeText(conv *conversation, node *assets.DialogueNode) string {
+	text, err := renderScript(node.Text, scriptData{Self: conv.mob.Name(), Actor: ci, Vars: conv.mob.triggers.snapshotVars()})
+	if err != nil {
+		slog.Warn("dialogue template failed", "mobile", conv.mob.Mobile.Id(), "node", conv.node, "error", err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s says, \"%s\"", display.Capitalize(conv.mob.Name()), text)
+	for i, idx := range conv.offered {
+		fmt.Fprintf(&sb, "\n  %d) %s", i+1, node.Replies[idx].Text)
+	}
+	return sb.String()
+}
+
+// meetsAll reports whether the character passes every condition.
+func (ci *CharacterInstance) meetsAll(conds []assets.DialogueCondition) bool {
+	for i := range conds {
+		if !ci.meets(&conds[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ci *CharacterInstance) meets(c *assets.DialogueCondition) bool {
+	level := ci.Level()
+	if level < c.MinLevel || (c.MaxLevel > 0 && level > c.MaxLevel) {
+		return false
+	}
+	if c.Object.Id() != "" && !scriptCarries(ci, c.Object.Id()) {
+		return false
+	}
+	if c.Quest.Id() != "" && !ci.InQuestState(c.Quest, c.QuestState) {
+		return false
+	}
+	if c.Alignment != "" && assets.AlignmentBand(ci.ModifierValue(assets.PerkKeyAlignment)) != c.Alignment {
+		return false
+	}
+	return ci.MeetsStanding(&c.StandingRequirement)
+}
+
+// dialogueEffect carries out one thing a mobile does for the character.
+func (ci *CharacterInstance) dialogueEffect(mi *MobileInstance, e *assets.DialogueEffect) {
+	who := display.Capitalize(mi.Name())
+	switch {
+	case e.Object.Id() != "":
+		oi, err := NewObjectInstance(e.Object)
+		if err != nil {
+			slog.Warn("dialogue gift failed", "mobile", mi.Mobile.Id(), "object", e.Object.Id(), "error", err)
+			return
+		}
+		ci.Inventory().AddObj(oi)
+		ci.Publish([]byte(fmt.Sprintf("%s gives you %s.", who, oi.ShortDesc())), nil)
+
+	case e.Quest.Id() != "":
+		// Dialogue offering a quest is expected to check quest_state first;
+		// one the character can't take is passed over quietly.
+		if err := ci.StartQuest(e.Quest); err != nil {
+			return
+		}
+		ci.Publish([]byte(fmt.Sprintf("You have taken on a new quest: %s.", e.Quest.Get().Name)), nil)
+
+	case e.Complete.Id() != "":
+		// Likewise a quest that isn't ready to hand in. CompleteQuest tells
+		// the character of their rewards itself.
+		if err := ci.CompleteQuest(e.Complete.Id()); err != nil {
+			slog.Debug("dialogue quest not handed in", "mobile", mi.Mobile.Id(), "quest", e.Complete.Id(), "error", err)
+		}
+
+	case e.Ability.Id() != "":
+		id := e.Ability.Id()
+		if ci.HasGrant(assets.PerkGrantUnlockAbility, id) {
+			return
+		}
+		ci.Learn(assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantUnlockAbility, Arg: id})
+		ci.Publish([]byte(fmt.Sprintf("%s teaches you to %s.", who, id)), nil)
+
+	case e.Open != "":
+		room := mi.Room()
+		dir, re := room.FindExit(e.Open)
+		if re == nil || re.Exit.Closure == nil {
+			slog.Warn("dialogue names no door", "mobile", mi.Mobile.Id(), "room", room.Room.Id(), "exit", e.Open)
+			return
+		}
+		if !re.IsClosed() && !re.IsLocked() {
+			return
+		}
+		re.SetLocked(false)
+		re.SetClosed(false)
+		if _, other := re.OtherSide(room); other != nil {
+			other.SetLocked(false)
+			other.SetClosed(false)
+		}
+		room.Publish([]byte(fmt.Sprintf("%s opens the %s to the %s.", who, re.Exit.Closure.Name, dir)), nil)
+	}
+}
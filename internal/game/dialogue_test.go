@@ -0,0 +1,262 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newDialogueScene puts Bob and a guard holding dlg in the same room.
+func newDialogueScene(dlg *assets.Dialogue) *scriptScene {
+	s := newScriptScene(nil, nil)
+	s.guard.Mobile.Get().Dialogue = storage.NewResolvedSmartIdentifier("guard-chat", dlg)
+	return s
+}
+
+// lastLine returns the last line of the last message, or "".
+func lastLine(msgs []string) string {
+	if len(msgs) == 0 {
+		return ""
+	}
+	lines := strings.Split(msgs[len(msgs)-1], "\n")
+	return lines[len(lines)-1]
+}
+
+func TestCharacterInstance_Talk(t *testing.T) {
+	pass := storage.NewResolvedSmartIdentifier("pass", &assets.Object{ShortDesc: "a pass"})
+	patrol := storage.NewResolvedSmartIdentifier("patrol", &assets.Quest{Name: "Patrol", Objectives: []assets.QuestObjective{
+		{Type: assets.QuestObjectiveVisit, Room: storage.NewSmartIdentifier[*assets.Room]("wall")},
+	}})
+	town := storage.NewResolvedSmartIdentifier("town", &assets.Faction{Name: "the town"})
+	dlg := &assets.Dialogue{
+		Start: "halt",
+		Nodes: map[string]*assets.DialogueNode{
+			"halt": {
+				Text: "Halt, {{ .Actor.Name }}.",
+				Replies: []assets.DialogueReply{
+					{Text: "I have a pass.", Next: "through", Conditions: []assets.DialogueCondition{{Object: pass}}},
+					{Text: "I'm a veteran.", Next: "through", Conditions: []assets.DialogueCondition{{MinLevel: 10}}},
+					{Text: "Any work?", Next: "work", Conditions: []assets.DialogueCondition{{Quest: patrol, QuestState: assets.QuestStateNone}}},
+					{Text: "Stand aside.", Next: "out", Conditions: []assets.DialogueCondition{{StandingRequirement: assets.StandingRequirement{Faction: town, MaxStanding: assets.StandingHostile}}}},
+					{Text: "Goodbye."},
+				},
+			},
+			"through": {Text: "Go on through."},
+			"out":     {Text: "Get out of my sight."},
+			"work":    {Text: "Walk the wall.", Effects: []assets.DialogueEffect{{Quest: patrol}}, Replies: []assets.DialogueReply{{Text: "Back.", Next: "halt"}}},
+		},
+	}
+
+	tests := map[string]struct {
+		carrying  bool
+		level     int
+		quest     bool // patrol already started
+		standing  int  // standing with the town
+		replies   []int
+		wantLast  string // last line sent to Bob
+		wantTalk  bool   // still in the conversation at the end
+		wantQuest string
+		expErr    error
+	}{
+		"offers the replies that apply": {
+			wantLast:  "  2) Goodbye.",
+			wantTalk:  true,
+			wantQuest: assets.QuestStateNone,
+		},
+		"carried item opens a reply": {
+			carrying: true,
+			replies:  []int{1},
+			wantLast: `The guard says, "Go on through."`,
+		},
+		"level opens a reply": {
+			level:    12,
+			replies:  []int{1},
+			wantLast: `The guard says, "Go on through."`,
+		},
+		"effects run and replies loop back": {
+			replies:   []int{1, 1},
+			wantLast:  "  1) Goodbye.",
+			wantTalk:  true,
+			wantQuest: assets.QuestStateActive,
+		},
+		"quest state hides a reply": {
+			quest:     true,
+			wantLast:  "  1) Goodbye.",
+			wantTalk:  true,
+			wantQuest: assets.QuestStateActive,
+		},
+		"standing opens a reply": {
+			standing: -300,
+			replies:  []int{2},
+			wantLast: `The guard says, "Get out of my sight."`,
+		},
+		"ending reply": {
+			replies:  []int{2},
+			wantLast: `You say, "Goodbye."`,
+		},
+		"number out of range": {
+			replies:  []int{3},
+			wantLast: "  2) Goodbye.",
+			wantTalk: true,
+			expErr:   ErrNoSuchReply,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newDialogueScene(dlg)
+			if tc.carrying {
+				oi, _ := NewObjectInstance(pass)
+				s.player.inventory.AddObj(oi)
+			}
+			s.player.level = tc.level
+			if tc.quest {
+				s.player.StartQuest(patrol)
+			}
+			s.player.AdjustStanding(town, tc.standing)
+
+			if err := s.player.Talk(s.guard); err != nil {
+				t.Fatalf("Talk: %v", err)
+			}
+			var err error
+			for _, n := range tc.replies {
+				if err = s.player.Reply(n); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tc.expErr) {
+				t.Errorf("Reply() error = %v, want %v", err, tc.expErr)
+			}
+			if got := lastLine(s.drain()); got != tc.wantLast {
+				t.Errorf("last line = %q, want %q", got, tc.wantLast)
+			}
+			if got := s.player.Conversing(); got != tc.wantTalk {
+				t.Errorf("Conversing() = %v, want %v", got, tc.wantTalk)
+			}
+			if tc.wantQuest != "" {
+				if got := s.player.QuestState("patrol"); got != tc.wantQuest {
+					t.Errorf("QuestState() = %q, want %q", got, tc.wantQuest)
+				}
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_Talk_noDialogue(t *testing.T) {
+	s := newScriptScene(nil, nil)
+
+	if err := s.player.Talk(s.guard); !errors.Is(err, ErrNoDialogue) {
+		t.Errorf("Talk() error = %v, want %v", err, ErrNoDialogue)
+	}
+}
+
+func TestCharacterInstance_Conversing_leavingEnds(t *testing.T) {
+	dlg := &assets.Dialogue{Start: "hi", Nodes: map[string]*assets.DialogueNode{
+		"hi": {Text: "Hi.", Replies: []assets.DialogueReply{{Text: "Bye."}}},
+	}}
+	tests := map[string]struct {
+		leave func(s *scriptScene)
+	}{
+		"player leaves": {leave: func(s *scriptScene) { s.player.Move(s.room, newTestRoom("yard")) }},
+		"mob leaves": {leave: func(s *scriptScene) {
+			s.room.RemoveMob(s.guard.Id())
+			newTestRoom("yard").AddMob(s.guard)
+		}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newDialogueScene(dlg)
+			if err := s.player.Talk(s.guard); err != nil {
+				t.Fatalf("Talk: %v", err)
+			}
+
+			tc.leave(s)
+
+			if s.player.Conversing() {
+				t.Error("still conversing after leaving")
+			}
+			if err := s.player.Reply(1); !errors.Is(err, ErrNotTalking) {
+				t.Errorf("Reply() error = %v, want %v", err, ErrNotTalking)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_dialogueEffect(t *testing.T) {
+	bread := storage.NewResolvedSmartIdentifier("bread", &assets.Object{Aliases: []string{"bread"}, ShortDesc: "a loaf of bread"})
+	tests := map[string]struct {
+		effect  assets.DialogueEffect
+		known   bool // already knows the ability
+		check   func(t *testing.T, s *scriptScene)
+		wantMsg string
+	}{
+		"gives an object": {
+			effect:  assets.DialogueEffect{Object: bread},
+			wantMsg: "The guard gives you a loaf of bread.",
+			check: func(t *testing.T, s *scriptScene) {
+				if s.player.inventory.FindObjByDef("bread") == nil {
+					t.Error("bread not in inventory")
+				}
+			},
+		},
+		"teaches an ability": {
+			effect:  assets.DialogueEffect{Ability: storage.NewSmartIdentifier[*assets.Ability]("kick")},
+			wantMsg: "The guard teaches you to kick.",
+			check: func(t *testing.T, s *scriptScene) {
+				if !s.player.HasGrant(assets.PerkGrantUnlockAbility, "kick") {
+					t.Error("kick not granted")
+				}
+				if got := len(s.player.Character.Get().Perks); got != 1 {
+					t.Errorf("saved perks = %d, want 1", got)
+				}
+			},
+		},
+		"known ability is not taught again": {
+			effect: assets.DialogueEffect{Ability: storage.NewSmartIdentifier[*assets.Ability]("kick")},
+			known:  true,
+			check: func(t *testing.T, s *scriptScene) {
+				if got := len(s.player.Character.Get().Perks); got != 1 {
+					t.Errorf("saved perks = %d, want 1", got)
+				}
+			},
+		},
+		"opens a door": {
+			effect:  assets.DialogueEffect{Open: "gate"},
+			wantMsg: "The guard opens the gate to the north.",
+			check: func(t *testing.T, s *scriptScene) {
+				_, re := s.room.FindExit("north")
+				if re.IsClosed() || re.IsLocked() {
+					t.Errorf("gate closed = %v, locked = %v", re.IsClosed(), re.IsLocked())
+				}
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(nil, nil)
+			s.room.exits["north"] = &ResolvedExit{
+				Exit:   assets.Exit{Closure: &assets.Closure{Name: "gate"}},
+				closed: true,
+				locked: true,
+			}
+			if tc.known {
+				s.player.Learn(assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantUnlockAbility, Arg: "kick"})
+			}
+
+			s.player.dialogueEffect(s.guard, &tc.effect)
+
+			msgs := s.drain()
+			if tc.wantMsg != "" && !slices.Contains(msgs, tc.wantMsg) {
+				t.Errorf("messages = %q, want %q", msgs, tc.wantMsg)
+			}
+			if tc.wantMsg == "" && len(msgs) > 0 {
+				t.Errorf("messages = %q, want none", msgs)
+			}
+			tc.check(t, s)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pixil98/go-mud/internal/assets"
 	"github.com/pixil98/go-mud/internal/storage"
@@ -20,25 +21,58 @@ type Dictionary struct {
 	Races      storage.Storer[*assets.Race]
 	Trees      storage.Storer[*assets.Tree]
 	Abilities  storage.Storer[*assets.Ability]
+	LootTables storage.Storer[*assets.LootTable]
+	Dialogues  storage.Storer[*assets.Dialogue]
+	Quests     storage.Storer[*assets.Quest]
+	Factions   storage.Storer[*assets.Faction]
 }
 
 // Resolve resolves all foreign key references on non-character asset types.
 // Characters are resolved at login time instead.
 func (d *Dictionary) Resolve() error {
+	for id, table := range d.LootTables.GetAll() {
+		if err := table.Resolve(d.Objects, d.LootTables); err != nil {
+			return fmt.Errorf("loot table %s: %w", id, err)
+		}
+	}
+	for id, table := range d.LootTables.GetAll() {
+		if cycle := table.FindCycle(id); cycle != nil {
+			return fmt.Errorf("loot table %s: nests itself via %s", id, strings.Join(cycle, " -> "))
+		}
+	}
+
+	for id, f := range d.Factions.GetAll() {
+		if err := f.Resolve(d.Factions); err != nil {
+			return fmt.Errorf("faction %s: %w", id, err)
+		}
+	}
+
+	for id, q := range d.Quests.GetAll() {
+		if err := q.Resolve(d.Quests, d.Factions, d.Mobiles, d.Objects, d.Rooms, d.Abilities); err != nil {
+			return fmt.Errorf("quest %s: %w", id, err)
+		}
+	}
+
+	for id, dlg := range d.Dialogues.GetAll() {
+		if err := dlg.Resolve(d.Objects, d.Abilities, d.Quests, d.Factions); err != nil {
+			return fmt.Errorf("dialogue %s: %w", id, err)
+		}
+	}
+
 	for id, mob := range d.Mobiles.GetAll() {
-		if err := mob.Resolve(d.Objects); err != nil {
+		if err := mob.Resolve(d.Objects, d.LootTables, d.Dialogues, d.Factions); err != nil {
 			return fmt.Errorf("mobile %s: %w", id, err)
 		}
 	}
 
 	for id, obj := range d.Objects.GetAll() {
-		if err := obj.Resolve(d.Objects); err != nil {
+		if err := obj.Resolve(d.Zones, d.Rooms, d.Objects); err != nil {
 			return fmt.Errorf("object %s: %w", id, err)
 		}
 	}
 
 	for id, room := range d.Rooms.GetAll() {
-		if err := room.Resolve(d.Zones, d.Rooms, d.Mobiles, d.Objects); err != nil {
+		if err := room.Resolve(d.Zones, d.Rooms, d.Mobiles, d.Objects, d.LootTables); err != nil {
 			return fmt.Errorf("room %s: %w", id, err)
 		}
 	}
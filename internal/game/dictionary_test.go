@@ -4,24 +4,137 @@ import (
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
 )
 
 func TestDictionary_Resolve(t *testing.T) {
 	tests := map[string]struct {
-		name string
+		loot      map[string]*assets.LootTable
+		dialogues map[string]*assets.Dialogue
+		quests    map[string]*assets.Quest
+		factions  map[string]*assets.Faction
+		mobiles   map[string]*assets.Mobile
+		objects   map[string]*assets.Object
+		expErr    bool
 	}{
 		"empty dictionary resolves without error": {},
+		"mobile loot resolves": {
+			loot: map[string]*assets.LootTable{
+				"pockets":  {Entries: []assets.LootEntry{{Table: storage.NewSmartIdentifier[*assets.LootTable]("trinkets")}}},
+				"trinkets": {Entries: []assets.LootEntry{{OneOf: []assets.LootEntry{{Weight: 3}}}}},
+			},
+			mobiles: map[string]*assets.Mobile{
+				"bandit": {Loot: []assets.LootRoll{{Table: storage.NewSmartIdentifier[*assets.LootTable]("pockets")}}},
+			},
+		},
+		"unknown loot table": {
+			mobiles: map[string]*assets.Mobile{
+				"bandit": {Loot: []assets.LootRoll{{Table: storage.NewSmartIdentifier[*assets.LootTable]("pockets")}}},
+			},
+			expErr: true,
+		},
+		"mobile dialogue resolves": {
+			dialogues: map[string]*assets.Dialogue{
+				"chat": {Start: "hi", Nodes: map[string]*assets.DialogueNode{"hi": {
+					Text:    "Hello.",
+					Effects: []assets.DialogueEffect{{Object: storage.NewSmartIdentifier[*assets.Object]("coin")}},
+				}}},
+			},
+			mobiles: map[string]*assets.Mobile{
+				"innkeeper": {Dialogue: storage.NewSmartIdentifier[*assets.Dialogue]("chat")},
+			},
+			objects: map[string]*assets.Object{"coin": {}},
+		},
+		"dialogue giving an unknown object": {
+			dialogues: map[string]*assets.Dialogue{
+				"chat": {Start: "hi", Nodes: map[string]*assets.DialogueNode{"hi": {
+					Text:    "Hello.",
+					Effects: []assets.DialogueEffect{{Object: storage.NewSmartIdentifier[*assets.Object]("coin")}},
+				}}},
+			},
+			expErr: true,
+		},
+		"unknown dialogue": {
+			mobiles: map[string]*assets.Mobile{
+				"innkeeper": {Dialogue: storage.NewSmartIdentifier[*assets.Dialogue]("chat")},
+			},
+			expErr: true,
+		},
+		"quest offered in dialogue resolves": {
+			quests: map[string]*assets.Quest{
+				"cull": {Name: "Cull", Objectives: []assets.QuestObjective{
+					{Type: assets.QuestObjectiveKill, Mobile: storage.NewSmartIdentifier[*assets.Mobile]("wolf"), Count: 3},
+				}},
+			},
+			dialogues: map[string]*assets.Dialogue{
+				"chat": {Start: "hi", Nodes: map[string]*assets.DialogueNode{"hi": {
+					Text:    "Hello.",
+					Effects: []assets.DialogueEffect{{Quest: storage.NewSmartIdentifier[*assets.Quest]("cull")}},
+				}}},
+			},
+			mobiles: map[string]*assets.Mobile{"wolf": {}},
+		},
+		"quest objective naming an unknown mobile": {
+			quests: map[string]*assets.Quest{
+				"cull": {Name: "Cull", Objectives: []assets.QuestObjective{
+					{Type: assets.QuestObjectiveKill, Mobile: storage.NewSmartIdentifier[*assets.Mobile]("wolf")},
+				}},
+			},
+			expErr: true,
+		},
+		"dialogue offering an unknown quest": {
+			dialogues: map[string]*assets.Dialogue{
+				"chat": {Start: "hi", Nodes: map[string]*assets.DialogueNode{"hi": {
+					Text:    "Hello.",
+					Effects: []assets.DialogueEffect{{Quest: storage.NewSmartIdentifier[*assets.Quest]("cull")}},
+				}}},
+			},
+			expErr: true,
+		},
+		"mobile faction resolves": {
+			factions: map[string]*assets.Faction{
+				"watch":   {Name: "the watch", Enemies: []storage.SmartIdentifier[*assets.Faction]{storage.NewSmartIdentifier[*assets.Faction]("bandits")}},
+				"bandits": {Name: "the bandits"},
+			},
+			mobiles: map[string]*assets.Mobile{
+				"guard": {Faction: storage.NewSmartIdentifier[*assets.Faction]("watch")},
+			},
+		},
+		"unknown faction": {
+			mobiles: map[string]*assets.Mobile{
+				"guard": {Faction: storage.NewSmartIdentifier[*assets.Faction]("watch")},
+			},
+			expErr: true,
+		},
+		"faction enemy unknown": {
+			factions: map[string]*assets.Faction{
+				"watch": {Name: "the watch", Enemies: []storage.SmartIdentifier[*assets.Faction]{storage.NewSmartIdentifier[*assets.Faction]("bandits")}},
+			},
+			expErr: true,
+		},
+		"loot tables nesting each other": {
+			loot: map[string]*assets.LootTable{
+				"a": {Entries: []assets.LootEntry{{Table: storage.NewSmartIdentifier[*assets.LootTable]("b")}}},
+				"b": {Entries: []assets.LootEntry{{OneOf: []assets.LootEntry{{Table: storage.NewSmartIdentifier[*assets.LootTable]("a")}}}}},
+			},
+			expErr: true,
+		},
 	}
-	for name := range tests {
+	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			d := &Dictionary{
-				Mobiles: newFakeStore[*assets.Mobile](nil),
-				Objects: newFakeStore[*assets.Object](nil),
-				Rooms:   newFakeStore[*assets.Room](nil),
-				Zones:   newFakeStore[*assets.Zone](nil),
+				Mobiles:    newFakeStore(tc.mobiles),
+				Objects:    newFakeStore(tc.objects),
+				Rooms:      newFakeStore[*assets.Room](nil),
+				Zones:      newFakeStore[*assets.Zone](nil),
+				Abilities:  newFakeStore[*assets.Ability](nil),
+				LootTables: newFakeStore(tc.loot),
+				Dialogues:  newFakeStore(tc.dialogues),
+				Quests:     newFakeStore(tc.quests),
+				Factions:   newFakeStore(tc.factions),
 			}
-			if err := d.Resolve(); err != nil {
-				t.Errorf("Resolve: %v", err)
+			if err := d.Resolve(); (err != nil) != tc.expErr {
+				t.Errorf("Resolve() error = %v, expErr %v", err, tc.expErr)
 			}
 		})
 	}
@@ -0,0 +1,233 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+const (
+	// duelYieldPercent is the share of max HP, in percent, at or below which
+	// a duelist loses the duel.
+	duelYieldPercent = 20
+
+	// duelChallengeTimeout is how long a challenge to a duel stands.
+	duelChallengeTimeout = 2 * time.Minute
+)
+
+var (
+	// ErrDuelSelf is returned when a character challenges themselves.
+	ErrDuelSelf = errors.New("cannot duel yourself")
+	// ErrDuelBusy is returned when a character fighting or already in a duel
+	// challenges someone or accepts a challenge.
+	ErrDuelBusy = errors.New("already fighting")
+	// ErrDuelOpponentBusy is returned when the other side of a duel is
+	// fighting or already in a duel.
+	ErrDuelOpponentBusy = errors.New("opponent already fighting")
+	// ErrNoDuelChallenge is returned when a character accepts a challenge
+	// they don't have, that has lapsed, or whose challenger has left.
+	ErrNoDuelChallenge = errors.New("no duel challenge")
+	// ErrDueling is returned when a duelist attacks someone other than their
+	// opponent.
+	ErrDueling = errors.New("attacker is in a duel")
+	// ErrDuelInterference is returned when someone attacks a duelist who
+	// isn't their opponent.
+	ErrDuelInterference = errors.New("target is in a duel")
+)
+
+// duel is a consensual fight between two characters that ends when one of
+// them is worn down to duelYieldPercent of their HP. Both are then restored
+// to their resources and timed perks from before the duel.
+type duel struct {
+	mu       sync.Mutex
+	sides    [2]*CharacterInstance
+	peaceful bool                     // the duel may be fought in a peaceful room
+	before   [2]map[string]int        // each side's resources at the start
+	timed    [2]map[string]*timedPerk // each side's timed perks at the start
+	over     bool
+}
+
+// duelChallenge is a standing challenge to a duel.
+type duelChallenge struct {
+	from     *CharacterInstance
+	peaceful bool
+	at       time.Time
+}
+
+// opponent returns the other side of the duel from ci.
+func (d *duel) opponent(ci *CharacterInstance) *CharacterInstance {
+	if d.sides[0] == ci {
+		return d.sides[1]
+	}
+	return d.sides[0]
+}
+
+// ChallengeDuel challenges target to a duel, which they may then accept
+// with AcceptDuel. If peaceful is set the duel may be fought in a peaceful
+// room.
+func (ci *CharacterInstance) ChallengeDuel(target *CharacterInstance, peaceful bool) error {
+	if target == ci {
+		return ErrDuelSelf
+	}
+	if ci.fighting() {
+		return ErrDuelBusy
+	}
+	if target.fighting() {
+		return ErrDuelOpponentBusy
+	}
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	target.challenge = &duelChallenge{from: ci, peaceful: peaceful, at: time.Now()}
+	return nil
+}
+
+// AcceptDuel accepts the challenge made to the character and starts the
+// duel. Returns the challenger, if the challenge still stands.
+func (ci *CharacterInstance) AcceptDuel() (*CharacterInstance, error) {
+	ci.mu.Lock()
+	ch := ci.challenge
+	ci.challenge = nil
+	ci.mu.Unlock()
+
+	if ch == nil || time.Since(ch.at) > duelChallengeTimeout || !ci.Room().holds(ch.from) {
+		return nil, ErrNoDuelChallenge
+	}
+	if ci.fighting() {
+		return ch.from, ErrDuelBusy
+	}
+	if ch.from.fighting() {
+		return ch.from, ErrDuelOpponentBusy
+	}
+
+	d := &duel{sides: [2]*CharacterInstance{ch.from, ci}, peaceful: ch.peaceful}
+	for i, side := range d.sides {
+		d.before[i] = make(map[string]int)
+		side.ForEachResource(func(name string, current, _ int) {
+			d.before[i][name] = current
+		})
+		d.timed[i] = side.timedSnapshot()
+		side.mu.Lock()
+		side.duel = d
+		side.mu.Unlock()
+	}
+	ch.from.EnsureThreat(ci.Id(), ci)
+	ci.EnsureThreat(ch.from.Id(), ch.from)
+	return ch.from, nil
+}
+
+// fighting reports whether the character is in combat or a duel.
+func (ci *CharacterInstance) fighting() bool {
+	return ci.IsInCombat() || ci.currentDuel() != nil
+}
+
+// currentDuel returns the duel the character is in, or nil.
+func (ci *CharacterInstance) currentDuel() *duel {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.duel
+}
+
+// DuelOpponent returns the character a is dueling, or nil if a is not a
+// character in a duel.
+func DuelOpponent(a Actor) *CharacterInstance {
+	ci, ok := a.(*CharacterInstance)
+	if !ok {
+		return nil
+	}
+	d := ci.currentDuel()
+	if d == nil {
+		return nil
+	}
+	return d.opponent(ci)
+}
+
+// CheckDuel returns nil unless an attack by attacker on target would break
+// into a duel: a duelist may only attack their opponent, and only their
+// opponent may attack them.
+func CheckDuel(attacker, target Actor) error {
+	if attacker.Id() == target.Id() {
+		return nil
+	}
+	if opp := DuelOpponent(attacker); opp != nil && opp.Id() != target.Id() {
+		return ErrDueling
+	}
+	if opp := DuelOpponent(target); opp != nil && opp.Id() != attacker.Id() {
+		return ErrDuelInterference
+	}
+	return nil
+}
+
+// DuelIgnoresPeace reports whether attacker and target are dueling each
+// other in a duel that may be fought in a peaceful room.
+func DuelIgnoresPeace(attacker, target Actor) bool {
+	ci, ok := attacker.(*CharacterInstance)
+	if !ok {
+		return false
+	}
+	d := ci.currentDuel()
+	return d != nil && d.peaceful && d.opponent(ci).Id() == target.Id()
+}
+
+// duelTick ends the character's duel if they have been worn down to
+// duelYieldPercent of their HP, or calls it off if their opponent has left.
+func (ci *CharacterInstance) duelTick() {
+	d := ci.currentDuel()
+	if d == nil {
+		return
+	}
+	opp := d.opponent(ci)
+	if room := ci.Room(); room == nil || !room.holds(opp) {
+		d.finish(nil, nil)
+		return
+	}
+	hp, maxHP := ci.Resource(assets.ResourceHp)
+	if hp <= maxHP*duelYieldPercent/100 {
+		d.finish(opp, ci)
+	}
+}
+
+// finish ends the duel, restores both sides and tells the room how it went.
+// A nil winner calls the duel off. Safe to call more than once; only the
+// first call has any effect.
+func (d *duel) finish(winner, loser *CharacterInstance) {
+	d.mu.Lock()
+	if d.over {
+		d.mu.Unlock()
+		return
+	}
+	d.over = true
+	d.mu.Unlock()
+
+	for i, side := range d.sides {
+		side.mu.Lock()
+		side.duel = nil
+		side.combatTargetId = ""
+		side.mu.Unlock()
+		side.restoreTimed(d.timed[i])
+		for name, v := range d.before[i] {
+			side.SetResource(name, v)
+		}
+		side.ClearThreatTable()
+		side.deathProcessed.Store(false)
+	}
+
+	msg := fmt.Sprintf("The duel between %s and %s is called off.", d.sides[0].Name(), d.sides[1].Name())
+	if winner != nil {
+		msg = fmt.Sprintf("%s has won the duel against %s!", winner.Name(), loser.Name())
+	}
+	told := map[string]bool{}
+	for _, side := range d.sides {
+		side.QueueTickMsg(msg)
+		told[side.Id()] = true
+	}
+	if room := d.sides[0].Room(); room != nil {
+		room.ForEachPlayer(func(id string, ci *CharacterInstance) {
+			if !told[id] {
+				ci.QueueTickMsg(msg)
+			}
+		})
+	}
+}
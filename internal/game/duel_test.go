@@ -0,0 +1,279 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newTestDuelists returns Alice and Bob standing in the same room of a
+// test world, with HP pools.
+func newTestDuelists(t *testing.T) (alice, bob *CharacterInstance, room *RoomInstance) {
+	t.Helper()
+	w, _, room := newTestWorld()
+	var cis []*CharacterInstance
+	for _, c := range []struct{ id, name string }{{"alice", "Alice"}, {"bob", "Bob"}} {
+		ci, _ := NewCharacterInstance(storage.NewResolvedSmartIdentifier(c.id, &assets.Character{Name: c.name}), make(chan []byte, 4), room)
+		ci.PerkCache = *NewPerkCache([]assets.Perk{testHPPerk}, nil)
+		ci.initResources()
+		if err := w.AddPlayer(ci); err != nil {
+			t.Fatalf("AddPlayer: %v", err)
+		}
+		cis = append(cis, ci)
+	}
+	return cis[0], cis[1], room
+}
+
+func TestCharacterInstance_AcceptDuel(t *testing.T) {
+	tests := map[string]struct {
+		noChallenge bool
+		lapsed      bool
+		self        bool
+		bobFighting bool
+		expChallErr error
+		expErr      error
+	}{
+		"accepted": {},
+		"self": {
+			self:        true,
+			expChallErr: ErrDuelSelf,
+		},
+		"no challenge": {
+			noChallenge: true,
+			expErr:      ErrNoDuelChallenge,
+		},
+		"challenge lapsed": {
+			lapsed: true,
+			expErr: ErrNoDuelChallenge,
+		},
+		"opponent fighting": {
+			bobFighting: true,
+			expChallErr: ErrDuelOpponentBusy,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, _ := newTestDuelists(t)
+			if tc.bobFighting {
+				rat := newTestMob("rat", nil)
+				bob.EnsureThreat(rat.Id(), rat)
+			}
+			target := bob
+			if tc.self {
+				target = alice
+			}
+
+			if !tc.noChallenge {
+				if err := alice.ChallengeDuel(target, false); !errors.Is(err, tc.expChallErr) {
+					t.Fatalf("ChallengeDuel() = %v, want %v", err, tc.expChallErr)
+				}
+				if tc.expChallErr != nil {
+					return
+				}
+			}
+			if tc.lapsed {
+				bob.challenge.at = time.Now().Add(-duelChallengeTimeout - time.Second)
+			}
+
+			opp, err := bob.AcceptDuel()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("AcceptDuel() = %v, want %v", err, tc.expErr)
+			}
+			if tc.expErr != nil {
+				if DuelOpponent(bob) != nil {
+					t.Error("Bob is dueling after a failed accept")
+				}
+				return
+			}
+			if opp != alice {
+				t.Errorf("AcceptDuel() opponent = %v, want Alice", opp)
+			}
+			if DuelOpponent(alice) != bob || DuelOpponent(bob) != alice {
+				t.Error("Alice and Bob are not dueling each other")
+			}
+			if !alice.IsInCombat() || !bob.IsInCombat() {
+				t.Error("duelists are not in combat")
+			}
+		})
+	}
+}
+
+func TestCheckDuel(t *testing.T) {
+	tests := map[string]struct {
+		attacker string
+		target   string
+		expErr   error
+	}{
+		"duelist hits opponent":      {attacker: "alice", target: "bob"},
+		"duelist hits bystander":     {attacker: "alice", target: "carol", expErr: ErrDueling},
+		"bystander hits duelist":     {attacker: "carol", target: "bob", expErr: ErrDuelInterference},
+		"mob hits duelist":           {attacker: "rat", target: "alice", expErr: ErrDuelInterference},
+		"bystander hits bystander":   {attacker: "carol", target: "rat"},
+		"duelist targets themselves": {attacker: "alice", target: "alice"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, _ := newTestDuelists(t)
+			if err := alice.ChallengeDuel(bob, false); err != nil {
+				t.Fatalf("ChallengeDuel: %v", err)
+			}
+			if _, err := bob.AcceptDuel(); err != nil {
+				t.Fatalf("AcceptDuel: %v", err)
+			}
+			actors := map[string]Actor{
+				"alice": alice,
+				"bob":   bob,
+				"carol": newTestCI("carol", "Carol"),
+				"rat":   newTestMob("rat", nil),
+			}
+
+			if err := CheckDuel(actors[tc.attacker], actors[tc.target]); !errors.Is(err, tc.expErr) {
+				t.Errorf("CheckDuel() = %v, want %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_duelTick(t *testing.T) {
+	tests := map[string]struct {
+		bobHP     int
+		aliceLeft bool
+		wantOver  bool
+		wantMsg   string // substring of Alice's queued messages
+	}{
+		"still fighting": {
+			bobHP: 5,
+		},
+		"worn down": {
+			bobHP:    2,
+			wantOver: true,
+			wantMsg:  "Alice has won the duel against Bob!",
+		},
+		"opponent left": {
+			bobHP:     5,
+			aliceLeft: true,
+			wantOver:  true,
+			wantMsg:   "The duel between Alice and Bob is called off.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, room := newTestDuelists(t)
+			if err := alice.ChallengeDuel(bob, false); err != nil {
+				t.Fatalf("ChallengeDuel: %v", err)
+			}
+			if _, err := bob.AcceptDuel(); err != nil {
+				t.Fatalf("AcceptDuel: %v", err)
+			}
+			alice.setResourceCurrent(assets.ResourceHp, 7)
+			bob.setResourceCurrent(assets.ResourceHp, tc.bobHP)
+			if tc.aliceLeft {
+				room.RemovePlayer(alice.Id())
+			}
+
+			bob.duelTick()
+
+			if over := DuelOpponent(alice) == nil; over != tc.wantOver {
+				t.Fatalf("duel over = %v, want %v", over, tc.wantOver)
+			}
+			if !tc.wantOver {
+				return
+			}
+			for _, ci := range []*CharacterInstance{alice, bob} {
+				if hp, maxHP := ci.Resource(assets.ResourceHp); hp != maxHP {
+					t.Errorf("%s HP = %d, want restored to %d", ci.Name(), hp, maxHP)
+				}
+				if ci.IsInCombat() {
+					t.Errorf("%s is still in combat", ci.Name())
+				}
+			}
+			if got := strings.Join(alice.tickMsgBuf, "\n"); !strings.Contains(got, tc.wantMsg) {
+				t.Errorf("Alice messages = %q, want %q", got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDuel_finish_timedPerks(t *testing.T) {
+	const key = "test.ac"
+	ac := func(v int) []assets.Perk {
+		return []assets.Perk{{Type: assets.PerkTypeModifier, Key: key, Value: v}}
+	}
+	tests := map[string]struct {
+		before map[string]int // timed AC perks Bob has when the duel starts
+		during map[string]int // timed AC perks put on Bob during the duel
+		expAC  int
+	}{
+		"added during the duel": {
+			during: map[string]int{"curse": -2},
+			expAC:  0,
+		},
+		"from before the duel": {
+			before: map[string]int{"bless": 1},
+			expAC:  1,
+		},
+		"replaced during the duel": {
+			before: map[string]int{"bless": 1},
+			during: map[string]int{"bless": 5},
+			expAC:  1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, _ := newTestDuelists(t)
+			for n, v := range tc.before {
+				bob.AddTimedPerks(n, ac(v), 10)
+			}
+			if err := alice.ChallengeDuel(bob, false); err != nil {
+				t.Fatalf("ChallengeDuel: %v", err)
+			}
+			if _, err := bob.AcceptDuel(); err != nil {
+				t.Fatalf("AcceptDuel: %v", err)
+			}
+			for n, v := range tc.during {
+				bob.AddTimedPerks(n, ac(v), 10)
+			}
+
+			bob.currentDuel().finish(alice, bob)
+
+			if got := bob.ModifierValue(key); got != tc.expAC {
+				t.Errorf("AC = %d, want %d", got, tc.expAC)
+			}
+		})
+	}
+}
+
+func TestProcessDeath_duel(t *testing.T) {
+	alice, bob, _ := newTestDuelists(t)
+	bob.Character.Get().PvP = true
+	alice.Character.Get().PvP = true
+	if err := alice.ChallengeDuel(bob, false); err != nil {
+		t.Fatalf("ChallengeDuel: %v", err)
+	}
+	if _, err := bob.AcceptDuel(); err != nil {
+		t.Fatalf("AcceptDuel: %v", err)
+	}
+	bob.setResourceCurrent(assets.ResourceHp, 0)
+
+	alice.sweepDeadEnemies(context.Background())
+
+	select {
+	case <-bob.Done():
+		t.Fatal("Bob was kicked")
+	default:
+	}
+	if !bob.IsAlive() {
+		t.Error("Bob was not restored")
+	}
+	if kills, _ := alice.PvPRecord(); kills != 0 {
+		t.Errorf("Alice kills = %d, want 0", kills)
+	}
+	if got := strings.Join(alice.tickMsgBuf, "\n"); !strings.Contains(got, "Alice has won the duel against Bob!") {
+		t.Errorf("Alice messages = %q, want the duel won", got)
+	}
+}
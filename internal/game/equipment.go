@@ -74,17 +74,21 @@ func (eq *Equipment) Drain() []*ObjectInstance {
 }
 
 // Tick advances the embedded PerkCache tick and decays equipped items.
-// Expired items are removed and perks are rebuilt if needed.
-func (eq *Equipment) Tick() {
+// Expired items are removed and perks are rebuilt if needed. Returns the
+// object events raised this tick; a decayed container keeps its contents
+// for the holder to spill.
+func (eq *Equipment) Tick() []ObjectEvent {
 	eq.PerkCache.Tick()
 
 	eq.mu.Lock()
 	defer eq.mu.Unlock()
 
+	var evs []ObjectEvent
 	n := 0
 	for _, slot := range eq.objs {
-		slot.Obj.Tick()
+		evs = append(evs, slot.Obj.Tick()...)
 		if slot.Obj.Expired() {
+			evs = append(evs, ObjectEvent{Type: ObjectEventDecay, Obj: slot.Obj})
 			continue
 		}
 		eq.objs[n] = slot
@@ -94,6 +98,19 @@ func (eq *Equipment) Tick() {
 		eq.objs = eq.objs[:n]
 		eq.rebuildPerks()
 	}
+	return evs
+}
+
+// hasLit returns true if any equipped item is a lit light source.
+func (eq *Equipment) hasLit() bool {
+	eq.mu.RLock()
+	defer eq.mu.RUnlock()
+	for _, slot := range eq.objs {
+		if slot.Obj != nil && slot.Obj.Lit {
+			return true
+		}
+	}
+	return false
 }
 
 // --- Queries ---
@@ -148,13 +165,46 @@ func (eq *Equipment) Snapshot() (*ResolvedPerks, uint64) {
 	return eq.PerkCache.Snapshot()
 }
 
-// rebuildPerks aggregates perks from all equipped items into the embedded PerkCache.
+// Wear wears down one equipped item with durability, picked with randIntN,
+// by n. Returns the item if this broke it, or nil. A broken item stops
+// granting its perks.
+func (eq *Equipment) Wear(n int, randIntN func(int) int) *ObjectInstance {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+
+	var worn []*ObjectInstance
+	for _, slot := range eq.objs {
+		if slot.Obj != nil && slot.Obj.Object.Get().Durability > 0 && !slot.Obj.Broken() {
+			worn = append(worn, slot.Obj)
+		}
+	}
+	if len(worn) == 0 {
+		return nil
+	}
+	oi := worn[randIntN(len(worn))]
+	if !oi.Damage(n) {
+		return nil
+	}
+	eq.rebuildPerks()
+	return oi
+}
+
+// RefreshPerks re-aggregates equipped item perks after an equipped
+// instance's own perks or durability changed.
+func (eq *Equipment) RefreshPerks() {
+	eq.mu.Lock()
+	defer eq.mu.Unlock()
+	eq.rebuildPerks()
+}
+
+// rebuildPerks aggregates perks from all equipped items, including any
+// per-instance perks, into the embedded PerkCache.
 // Caller must hold the write lock.
 func (eq *Equipment) rebuildPerks() {
 	var perks []assets.Perk
 	for _, slot := range eq.objs {
 		if slot.Obj != nil {
-			perks = append(perks, slot.Obj.Object.Get().Perks...)
+			perks = append(perks, slot.Obj.Perks()...)
 		}
 	}
 	eq.SetOwn(perks)
@@ -195,3 +195,65 @@ func TestEquipment_Tick(t *testing.T) {
 		})
 	}
 }
+
+func TestEquipment_RefreshPerks(t *testing.T) {
+	eq := NewEquipment()
+	obj := storage.NewResolvedSmartIdentifier("ring", &assets.Object{
+		Aliases: []string{"ring"}, ShortDesc: "a ring",
+		Flags: []string{"wearable"}, WearSlots: []string{"finger"},
+		Durability: 5,
+	})
+	oi, _ := NewObjectInstance(obj)
+	eq.equip("finger", oi)
+
+	const key = "core.combat.ac.flat"
+	oi.AddPerks(assets.Perk{Type: assets.PerkTypeModifier, Key: key, Value: 3})
+	eq.RefreshPerks()
+	if got := eq.ModifierValue(key); got != 3 {
+		t.Errorf("ModifierValue after enchant = %d, want 3", got)
+	}
+
+	oi.Damage(5)
+	eq.RefreshPerks()
+	if got := eq.ModifierValue(key); got != 0 {
+		t.Errorf("ModifierValue after breaking = %d, want 0", got)
+	}
+}
+
+func TestEquipment_Wear(t *testing.T) {
+	tests := map[string]struct {
+		durability int
+		wear       int
+		wantBroken bool
+		wantLeft   int
+		wantAC     int
+	}{
+		"wears down":    {durability: 5, wear: 2, wantLeft: 3, wantAC: 2},
+		"breaks":        {durability: 2, wear: 2, wantBroken: true},
+		"no durability": {wear: 2, wantAC: 2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			eq := NewEquipment()
+			oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier("shield", &assets.Object{
+				Aliases: []string{"shield"}, ShortDesc: "a shield",
+				Flags: []string{"wearable"}, WearSlots: []string{"shield"},
+				Durability: tc.durability,
+				Perks:      []assets.Perk{{Type: assets.PerkTypeModifier, Key: "core.combat.ac.flat", Value: 2}},
+			}))
+			eq.equip("shield", oi)
+
+			broke := eq.Wear(tc.wear, func(int) int { return 0 })
+
+			if (broke != nil) != tc.wantBroken {
+				t.Errorf("Wear() = %v, want broken %v", broke, tc.wantBroken)
+			}
+			if oi.Durability != tc.wantLeft {
+				t.Errorf("Durability = %d, want %d", oi.Durability, tc.wantLeft)
+			}
+			if got := eq.ModifierValue("core.combat.ac.flat"); got != tc.wantAC {
+				t.Errorf("ModifierValue = %d, want %d", got, tc.wantAC)
+			}
+		})
+	}
+}
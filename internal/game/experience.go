@@ -73,3 +73,32 @@ func LevelDiffMultiplier(playerLevel, mobLevel int) float64 {
 		return 0.0
 	}
 }
+
+const (
+	// groupBonusPercent is the extra XP, in percent of the base, a kill is
+	// worth for each group member present beyond the first.
+	groupBonusPercent = 10
+
+	// maxGroupBonusPercent caps the group bonus.
+	maxGroupBonusPercent = 50
+)
+
+// groupExpShares splits the base XP for a kill among group members of the
+// given levels, weighted by level, after adding the group bonus. A lone
+// member gets the base XP. Returns the shares in the order of levels.
+func groupExpShares(base int, levels []int) []int {
+	if len(levels) == 0 {
+		return nil
+	}
+	bonus := min((len(levels)-1)*groupBonusPercent, maxGroupBonusPercent)
+	pool := base * (100 + bonus) / 100
+	total := 0
+	for _, l := range levels {
+		total += max(l, 1)
+	}
+	shares := make([]int, len(levels))
+	for i, l := range levels {
+		shares[i] = pool * max(l, 1) / total
+	}
+	return shares
+}
@@ -1,6 +1,9 @@
 package game
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestBaseExpForLevel(t *testing.T) {
 	tests := map[string]struct {
@@ -74,3 +77,26 @@ func TestExpToNextLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupExpShares(t *testing.T) {
+	tests := map[string]struct {
+		base   int
+		levels []int
+		want   []int
+	}{
+		"alone":               {base: 300, levels: []int{5}, want: []int{300}},
+		"pair of equals":      {base: 300, levels: []int{5, 5}, want: []int{165, 165}},
+		"weighted by level":   {base: 300, levels: []int{10, 5}, want: []int{220, 110}},
+		"bonus is capped":     {base: 100, levels: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, want: []int{15, 15, 15, 15, 15, 15, 15, 15, 15, 15}},
+		"level 0 counts as 1": {base: 120, levels: []int{0, 1}, want: []int{66, 66}},
+		"no members":          {base: 300},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := groupExpShares(tc.base, tc.levels)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("groupExpShares(%d, %v) = %v, want %v", tc.base, tc.levels, got, tc.want)
+			}
+		})
+	}
+}
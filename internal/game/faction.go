@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Standing returns the character's standing with a faction: what they have
+// earned, or the faction's starting standing if they have had no dealings
+// with it.
+func (ci *CharacterInstance) Standing(f storage.SmartIdentifier[*assets.Faction]) int {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	if v, ok := ci.Character.Get().Standings[f.Id()]; ok {
+		return v
+	}
+	if def := f.Get(); def != nil {
+		return def.Starting
+	}
+	return 0
+}
+
+// Standings returns the character's standing with each faction they have had
+// dealings with, by faction ID.
+func (ci *CharacterInstance) Standings() map[string]int {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return maps.Clone(ci.Character.Get().Standings)
+}
+
+// AdjustStanding changes the character's standing with a faction by delta,
+// within StandingMin and StandingMax, and returns the new standing.
+func (ci *CharacterInstance) AdjustStanding(f storage.SmartIdentifier[*assets.Faction], delta int) int {
+	cur := ci.Standing(f)
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	c := ci.Character.Get()
+	if c.Standings == nil {
+		c.Standings = make(map[string]int)
+	}
+	next := min(max(cur+delta, assets.StandingMin), assets.StandingMax)
+	c.Standings[f.Id()] = next
+	return next
+}
+
+// MeetsStanding reports whether the character's standing is within r's
+// bounds. A requirement naming no faction always holds.
+func (ci *CharacterInstance) MeetsStanding(r *assets.StandingRequirement) bool {
+	if r.Faction.Id() == "" {
+		return true
+	}
+	return r.Allows(ci.Standing(r.Faction))
+}
+
+// killedMember adjusts the character's standing for killing a member of f:
+// by the faction's kill standing with f, half that with its allies, and half
+// that the other way with its enemies. The character is told through tell.
+func (ci *CharacterInstance) killedMember(f storage.SmartIdentifier[*assets.Faction], tell func(string)) {
+	def := f.Get()
+	if def == nil || def.KillStanding == 0 {
+		return
+	}
+	var lines []string
+	shift := func(f storage.SmartIdentifier[*assets.Faction], delta int) {
+		if delta == 0 || f.Get() == nil {
+			return
+		}
+		before := ci.Standing(f)
+		after := ci.AdjustStanding(f, delta)
+		if after == before {
+			return
+		}
+		verb := "improves"
+		if after < before {
+			verb = "worsens"
+		}
+		lines = append(lines, fmt.Sprintf("Your standing with %s %s.", f.Get().Name, verb))
+		if tier := assets.StandingTier(after); tier != assets.StandingTier(before) {
+			lines = append(lines, fmt.Sprintf("%s now regard you as %s.", display.Capitalize(f.Get().Name), tier))
+		}
+	}
+	shift(f, def.KillStanding)
+	for _, ally := range def.Allies {
+		shift(ally, def.KillStanding/2)
+	}
+	for _, enemy := range def.Enemies {
+		shift(enemy, -def.KillStanding/2)
+	}
+	if len(lines) > 0 {
+		tell(strings.Join(lines, "\n"))
+	}
+}
+
+// regards returns the standing tier the mob's faction has ci in, or "" if
+// the mob belongs to no faction.
+func (mi *MobileInstance) regards(ci *CharacterInstance) string {
+	f := mi.Mobile.Get().Faction
+	if f.Get() == nil {
+		return ""
+	}
+	return assets.StandingTier(ci.Standing(f))
+}
+
+// attacksOnSight reports whether the mob picks a fight with ci. Aggressive
+// mobs attack anyone their faction doesn't count as a friend, and other
+// faction mobs attack those their faction regards as hostile or worse.
+func (mi *MobileInstance) attacksOnSight(ci *CharacterInstance) bool {
+	aggressive := mi.Mobile.Get().HasFlag(assets.MobileFlagAggressive)
+	tier := mi.regards(ci)
+	if tier == "" {
+		return aggressive
+	}
+	rank := assets.StandingRank(tier)
+	if aggressive {
+		return rank < assets.StandingRank(assets.StandingFriendly)
+	}
+	return rank <= assets.StandingRank(assets.StandingHostile)
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestCharacterInstance_AdjustStanding(t *testing.T) {
+	tests := map[string]struct {
+		starting int
+		deltas   []int
+		want     int
+	}{
+		"starts from the faction's starting standing": {
+			starting: -150,
+			deltas:   []int{50},
+			want:     -100,
+		},
+		"accumulates": {
+			deltas: []int{40, 40, -20},
+			want:   60,
+		},
+		"clamped at the top": {
+			starting: 900,
+			deltas:   []int{500},
+			want:     assets.StandingMax,
+		},
+		"clamped at the bottom": {
+			deltas: []int{-600, -600},
+			want:   assets.StandingMin,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci := newTestCI("bob", "Bob")
+			f := storage.NewResolvedSmartIdentifier("town", &assets.Faction{Name: "the town", Starting: tc.starting})
+			for _, d := range tc.deltas {
+				ci.AdjustStanding(f, d)
+			}
+			if got := ci.Standing(f); got != tc.want {
+				t.Errorf("Standing() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_killedMember(t *testing.T) {
+	tests := map[string]struct {
+		kill      int
+		before    int // standing with the town before the kill
+		wantTown  int
+		wantGuild int // the town's ally
+		wantBand  int // the town's enemy
+		wantMsg   string
+	}{
+		"kill standing spreads to allies and enemies": {
+			kill:      -40,
+			wantTown:  -40,
+			wantGuild: -20,
+			wantBand:  20,
+			wantMsg:   "Your standing with the town worsens.\nYour standing with the guild worsens.\nYour standing with the band improves.",
+		},
+		"crossing a tier is announced": {
+			kill:      -40,
+			before:    -80,
+			wantTown:  -120,
+			wantGuild: -20,
+			wantBand:  20,
+			wantMsg:   "Your standing with the town worsens.\nThe town now regard you as unfriendly.",
+		},
+		"no kill standing": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			guild := storage.NewResolvedSmartIdentifier("guild", &assets.Faction{Name: "the guild"})
+			band := storage.NewResolvedSmartIdentifier("band", &assets.Faction{Name: "the band"})
+			town := storage.NewResolvedSmartIdentifier("town", &assets.Faction{
+				Name:         "the town",
+				KillStanding: tc.kill,
+				Allies:       []storage.SmartIdentifier[*assets.Faction]{guild},
+				Enemies:      []storage.SmartIdentifier[*assets.Faction]{band},
+			})
+			ci := newTestCI("bob", "Bob")
+			ci.AdjustStanding(town, tc.before)
+
+			var msg string
+			ci.killedMember(town, func(s string) { msg = s })
+
+			if got := ci.Standing(town); got != tc.wantTown {
+				t.Errorf("town standing = %d, want %d", got, tc.wantTown)
+			}
+			if got := ci.Standing(guild); got != tc.wantGuild {
+				t.Errorf("guild standing = %d, want %d", got, tc.wantGuild)
+			}
+			if got := ci.Standing(band); got != tc.wantBand {
+				t.Errorf("band standing = %d, want %d", got, tc.wantBand)
+			}
+			if !strings.HasPrefix(msg, tc.wantMsg) {
+				t.Errorf("message = %q, want prefix %q", msg, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestMobileInstance_attacksOnSight(t *testing.T) {
+	tests := map[string]struct {
+		aggressive bool
+		faction    bool
+		standing   int
+		want       bool
+	}{
+		"peaceful mob without a faction":        {},
+		"aggressive mob without a faction":      {aggressive: true, want: true},
+		"faction mob ignores the neutral":       {faction: true},
+		"faction mob attacks the hostile":       {faction: true, standing: -300, want: true},
+		"faction mob tolerates the unfriendly":  {faction: true, standing: -299},
+		"aggressive faction mob spares friends": {aggressive: true, faction: true, standing: 100},
+		"aggressive faction mob attacks others": {aggressive: true, faction: true, standing: 99, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			town := storage.NewResolvedSmartIdentifier("town", &assets.Faction{Name: "the town"})
+			def := &assets.Mobile{ShortDesc: "a guard"}
+			if tc.aggressive {
+				def.Flags = []string{"aggressive"}
+			}
+			if tc.faction {
+				def.Faction = town
+			}
+			mi, _ := NewMobileInstance(storage.NewResolvedSmartIdentifier("guard", def))
+			ci := newTestCI("bob", "Bob")
+			ci.AdjustStanding(town, tc.standing)
+
+			if got := mi.attacksOnSight(ci); got != tc.want {
+				t.Errorf("attacksOnSight() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package game
+
+import "github.com/pixil98/go-mud/internal/assets"
+
+const (
+	// backRankThreatPercent is how much of their threat counts, in percent,
+	// when an enemy picks between targets in the front and back ranks. An
+	// actor in the back rank is picked over one in the front only once they
+	// have built up more than twice the threat.
+	backRankThreatPercent = 50
+
+	// backRankDamagePercent is how much melee auto-attack damage, in
+	// percent, gets through to an actor in the back rank.
+	backRankDamagePercent = 50
+
+	// guardInterceptPercent is the chance, in percent, that a guard steps in
+	// to take an attack aimed at their ward.
+	guardInterceptPercent = 50
+)
+
+// BackRank reports whether the actor stands in the back rank of their group.
+// Safe to call while holding another actor's lock.
+func (a *ActorInstance) BackRank() bool {
+	return a.backRank.Load()
+}
+
+// SetBackRank moves the actor to the back rank of their group, or to the
+// front.
+func (a *ActorInstance) SetBackRank(on bool) {
+	a.backRank.Store(on)
+}
+
+// InBackRank reports whether actor stands in the back rank. Actors without
+// ranks are always in the front.
+func InBackRank(actor Actor) bool {
+	r, ok := actor.(interface{ BackRank() bool })
+	return ok && r.BackRank()
+}
+
+// BackRankDamage returns how much of a melee auto-attack's damage gets
+// through to target: all of it in the front rank, less in the back.
+func BackRankDamage(target Actor, damage int) int {
+	if InBackRank(target) {
+		return damage * backRankDamagePercent / 100
+	}
+	return damage
+}
+
+// rankedThreat returns e's threat as counted when picking a target.
+func rankedThreat(e *threatEntry) int {
+	if InBackRank(e.actor) {
+		return e.threat * backRankThreatPercent / 100
+	}
+	return e.threat
+}
+
+// Interceptor returns the guard who steps in to take attacker's attack on
+// target, or nil if no one does. A guard intercepts guardInterceptPercent of
+// the attacks on their ward while they stand in the ward's room, alive, in
+// the front rank, still grouped with the ward and free to fight attacker.
+func Interceptor(attacker, target Actor, randIntN func(int) int) Actor {
+	room := target.Room()
+	if room == nil {
+		return nil
+	}
+	for _, id := range target.GrantArgs(assets.PerkGrantGuardedBy) {
+		if id == attacker.Id() || id == target.Id() {
+			continue
+		}
+		guard := room.actor(id)
+		if guard == nil || !guard.IsAlive() || InBackRank(guard) || !AreAllies(guard, target) || AreAllies(attacker, guard) {
+			continue
+		}
+		if CheckDuel(attacker, guard) != nil || CheckPvP(attacker, guard) != nil {
+			continue
+		}
+		if randIntN(100) < guardInterceptPercent {
+			return guard
+		}
+	}
+	return nil
+}
+
+// actor returns the player or mob with the given ID in the room, or nil.
+func (ri *RoomInstance) actor(id string) Actor {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	if ci, ok := ri.players[id]; ok {
+		return ci
+	}
+	if mi, ok := ri.mobiles[id]; ok {
+		return mi
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestBackRankDamage(t *testing.T) {
+	tests := map[string]struct {
+		back bool
+		want int
+	}{
+		"front rank takes it all": {want: 9},
+		"back rank takes half":    {back: true, want: 4},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci := newTestCI("bob", "Bob")
+			ci.SetBackRank(tc.back)
+
+			if got := BackRankDamage(ci, 9); got != tc.want {
+				t.Errorf("BackRankDamage() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		unguarded bool
+		roll      int
+		guardBack bool
+		guardDead bool
+		guardGone bool
+		ungrouped bool
+		wantGuard bool
+	}{
+		"guard steps in":     {wantGuard: true},
+		"guard misses":       {roll: 50},
+		"no guard":           {unguarded: true},
+		"guard in back rank": {guardBack: true},
+		"guard is dead":      {guardDead: true},
+		"guard left":         {guardGone: true},
+		"group broken up":    {ungrouped: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, room := newTestDuelists(t)
+			bob.SetFollowing(alice)
+			alice.AddFollower(bob)
+			alice.SetFollowerGrouped(bob.Id(), true)
+			wolf := newEnemyMI("wolf")
+			room.AddMob(wolf)
+			if !tc.unguarded {
+				bob.AddTimedPerks("guard:alice", []assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantGuardedBy, Arg: alice.Id()}}, 3)
+			}
+			alice.SetBackRank(tc.guardBack)
+			if tc.guardDead {
+				alice.SetResource(assets.ResourceHp, 0)
+			}
+			if tc.guardGone {
+				room.RemovePlayer(alice.Id())
+			}
+			if tc.ungrouped {
+				alice.SetFollowerGrouped(bob.Id(), false)
+			}
+
+			got := Interceptor(wolf, bob, func(int) int { return tc.roll })
+
+			if tc.wantGuard {
+				if got == nil || got.Id() != alice.Id() {
+					t.Errorf("Interceptor() = %v, want Alice", got)
+				}
+			} else if got != nil {
+				t.Errorf("Interceptor() = %s, want nil", got.Id())
+			}
+		})
+	}
+}
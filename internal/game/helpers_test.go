@@ -37,11 +37,12 @@ func newTestZone(id string) *ZoneInstance {
 type fakeCommander struct {
 	commands  []string
 	abilities []string
+	err       error // returned from ExecCommand when set
 }
 
 func (fc *fakeCommander) ExecCommand(_ context.Context, cmd string, _ ...string) error {
 	fc.commands = append(fc.commands, cmd)
-	return nil
+	return fc.err
 }
 
 func (fc *fakeCommander) ExecAbility(_ context.Context, id string, _ Actor) error {
@@ -75,6 +76,12 @@ func newTestCI(id, name string) *CharacterInstance {
 	return ci
 }
 
+// newTestMob creates a MobileInstance with the given flags for behavior tests.
+func newTestMob(id string, flags []string) *MobileInstance {
+	mi, _ := NewMobileInstance(storage.NewResolvedSmartIdentifier(id, &assets.Mobile{ShortDesc: id, Flags: flags}))
+	return mi
+}
+
 // fakeStore is an in-memory Storer for use in tests.
 type fakeStore[T storage.ValidatingSpec] struct {
 	records map[string]T
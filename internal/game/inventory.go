@@ -85,17 +85,44 @@ func (inv *Inventory) Len() int {
 }
 
 // Tick advances decay on all items. Each object's Tick is called, then any
-// object whose RemainingTicks has reached zero is removed.
-func (inv *Inventory) Tick() {
+// object whose RemainingTicks has reached zero is removed and its contents
+// spill into this inventory. Returns the object events raised this tick.
+func (inv *Inventory) Tick() []ObjectEvent {
+	return inv.tick(false)
+}
+
+// tick is Tick for the contents of a container (packed) or any other
+// inventory; see ObjectInstance.tick.
+func (inv *Inventory) tick(packed bool) []ObjectEvent {
 	inv.mu.Lock()
 	defer inv.mu.Unlock()
 
+	var evs []ObjectEvent
+	var spilled []*ObjectInstance
 	for id, oi := range inv.objs {
-		oi.Tick()
+		evs = append(evs, oi.tick(packed)...)
 		if oi.Expired() {
 			delete(inv.objs, id)
+			spilled = append(spilled, oi.Spill()...)
+			evs = append(evs, ObjectEvent{Type: ObjectEventDecay, Obj: oi})
+		}
+	}
+	for _, oi := range spilled {
+		inv.objs[oi.InstanceId] = oi
+	}
+	return evs
+}
+
+// hasLit returns true if any top-level item is a lit light source.
+func (inv *Inventory) hasLit() bool {
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	for _, oi := range inv.objs {
+		if oi.Lit {
+			return true
 		}
 	}
+	return false
 }
 
 // Drain atomically removes and returns all items.
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+)
+
+// roomLightSource is the perk source a room carries while any lit light
+// source is present, letting everyone in the room see past room_dark.
+var roomLightSource = NewPerkCache([]assets.Perk{
+	{Type: assets.PerkTypeGrant, Key: assets.PerkGrantIgnoreRestriction, Arg: string(assets.RoomFlagDark)},
+}, nil)
+
+// RefreshLight adds or removes the room-wide light grant depending on
+// whether a lit object lies on the floor or is carried by anyone here.
+// Call after anything that may change the room's lighting; the room also
+// refreshes itself every tick.
+func (ri *RoomInstance) RefreshLight() {
+	lit := ri.objects.hasLit()
+	if !lit {
+		ri.ForEachActor(func(a Actor) {
+			if lit {
+				return
+			}
+			if inv := a.Inventory(); inv != nil && inv.hasLit() {
+				lit = true
+			} else if eq := a.Equipment(); eq != nil && eq.hasLit() {
+				lit = true
+			}
+		})
+	}
+
+	// Update the perk under the same lock as lit so concurrent refreshes
+	// can't leave the two disagreeing.
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if ri.lit == lit {
+		return
+	}
+	ri.lit = lit
+	if lit {
+		ri.Perks.AddSource("light", roomLightSource)
+	} else {
+		ri.Perks.RemoveSource("light")
+	}
+}
+
+// IsLit returns true if a light source is currently lighting the room.
+func (ri *RoomInstance) IsLit() bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return ri.lit
+}
+
+// burnoutMessage is the announcement for a light source that just went out.
+func burnoutMessage(oi *ObjectInstance) string {
+	return fmt.Sprintf("%s flickers and goes out.", display.Capitalize(oi.ShortDesc()))
+}
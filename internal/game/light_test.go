@@ -0,0 +1,247 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newTestLight creates a light source with the given burn time.
+func newTestLight(id string, burn int) *ObjectInstance {
+	oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier(id, &assets.Object{
+		Aliases: []string{id}, ShortDesc: id, Light: &assets.Light{Burn: burn},
+	}))
+	return oi
+}
+
+func TestObjectInstance_Light(t *testing.T) {
+	tests := map[string]struct {
+		burn         int
+		ignite       bool
+		ticks        int
+		wantLit      bool
+		wantBurn     int
+		wantOut      int
+		wantBurnedUp bool
+	}{
+		"unlit light keeps its fuel": {
+			burn: 5, ticks: 3,
+			wantLit: false, wantBurn: 5,
+		},
+		"lit light burns fuel": {
+			burn: 5, ignite: true, ticks: 3,
+			wantLit: true, wantBurn: 2,
+		},
+		"lit light burns out": {
+			burn: 2, ignite: true, ticks: 4,
+			wantLit: false, wantBurn: 0, wantOut: 1, wantBurnedUp: true,
+		},
+		"eternal light never burns out": {
+			burn: 0, ignite: true, ticks: 100,
+			wantLit: true, wantBurn: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestLight("torch", tc.burn)
+			if tc.ignite && !oi.Ignite() {
+				t.Fatal("Ignite() = false, want true")
+			}
+			out := 0
+			for range tc.ticks {
+				out += len(oi.Tick())
+			}
+			if oi.Lit != tc.wantLit {
+				t.Errorf("Lit = %v, want %v", oi.Lit, tc.wantLit)
+			}
+			if oi.BurnTicks != tc.wantBurn {
+				t.Errorf("BurnTicks = %d, want %d", oi.BurnTicks, tc.wantBurn)
+			}
+			if out != tc.wantOut {
+				t.Errorf("burned out %d times, want %d", out, tc.wantOut)
+			}
+			if oi.BurnedOut() != tc.wantBurnedUp {
+				t.Errorf("BurnedOut() = %v, want %v", oi.BurnedOut(), tc.wantBurnedUp)
+			}
+		})
+	}
+}
+
+func TestObjectInstance_IgniteExtinguish(t *testing.T) {
+	tests := map[string]struct {
+		obj            func() *ObjectInstance
+		wantIgnite     bool
+		wantExtinguish bool
+	}{
+		"light source": {
+			obj:        func() *ObjectInstance { return newTestLight("torch", 5) },
+			wantIgnite: true, wantExtinguish: true,
+		},
+		"burned out light": {
+			obj: func() *ObjectInstance {
+				oi := newTestLight("torch", 1)
+				oi.Ignite()
+				oi.Tick()
+				return oi
+			},
+		},
+		"not a light": {
+			obj: func() *ObjectInstance { return newTestObj("rock") },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := tc.obj()
+			if got := oi.Ignite(); got != tc.wantIgnite {
+				t.Errorf("Ignite() = %v, want %v", got, tc.wantIgnite)
+			}
+			if got := oi.Extinguish(); got != tc.wantExtinguish {
+				t.Errorf("Extinguish() = %v, want %v", got, tc.wantExtinguish)
+			}
+			if oi.Lit {
+				t.Error("Lit = true after Extinguish")
+			}
+		})
+	}
+}
+
+func TestObjectInstance_LightStateRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		ignite    bool
+		ticks     int
+		wantState bool
+		wantLit   bool
+		wantBurn  int
+	}{
+		"fresh light saves no state": {
+			wantState: false, wantBurn: 10,
+		},
+		"lit light persists lit and fuel": {
+			ignite: true, ticks: 3,
+			wantState: true, wantLit: true, wantBurn: 7,
+		},
+		"burned out light persists empty": {
+			ignite: true, ticks: 10,
+			wantState: true, wantLit: false, wantBurn: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestLight("torch", 10)
+			if tc.ignite {
+				oi.Ignite()
+			}
+			for range tc.ticks {
+				oi.Tick()
+			}
+			spec := objectInstanceToSpawn(oi)
+			if (spec.State != nil) != tc.wantState {
+				t.Fatalf("State = %+v, wantState %v", spec.State, tc.wantState)
+			}
+			restored, err := SpawnObject(spec)
+			if err != nil {
+				t.Fatalf("SpawnObject: %v", err)
+			}
+			if restored.Lit != tc.wantLit || restored.BurnTicks != tc.wantBurn {
+				t.Errorf("restored = lit %v burn %d, want lit %v burn %d",
+					restored.Lit, restored.BurnTicks, tc.wantLit, tc.wantBurn)
+			}
+		})
+	}
+}
+
+func TestRoomInstance_RefreshLight(t *testing.T) {
+	tests := map[string]struct {
+		setup   func(ri *RoomInstance, oi *ObjectInstance)
+		wantLit bool
+	}{
+		"dark room stays dark": {
+			setup:   func(ri *RoomInstance, oi *ObjectInstance) {},
+			wantLit: false,
+		},
+		"unlit light on floor": {
+			setup:   func(ri *RoomInstance, oi *ObjectInstance) { ri.AddObj(oi) },
+			wantLit: false,
+		},
+		"lit light on floor": {
+			setup: func(ri *RoomInstance, oi *ObjectInstance) {
+				oi.Ignite()
+				ri.AddObj(oi)
+			},
+			wantLit: true,
+		},
+		"lit light carried by occupant": {
+			setup: func(ri *RoomInstance, oi *ObjectInstance) {
+				oi.Ignite()
+				ci := newTestCI("c1", "Alice")
+				ci.inventory = NewInventory()
+				ci.inventory.AddObj(oi)
+				ri.AddPlayer(ci.Id(), ci)
+			},
+			wantLit: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ri := newTestRoom("r")
+			oi := newTestLight("torch", 10)
+			tc.setup(ri, oi)
+			ri.RefreshLight()
+
+			if ri.IsLit() != tc.wantLit {
+				t.Errorf("IsLit() = %v, want %v", ri.IsLit(), tc.wantLit)
+			}
+			granted := ri.Perks.HasGrant(assets.PerkGrantIgnoreRestriction, string(assets.RoomFlagDark))
+			if granted != tc.wantLit {
+				t.Errorf("room_dark grant = %v, want %v", granted, tc.wantLit)
+			}
+
+			oi.Extinguish()
+			ri.RefreshLight()
+			if ri.IsLit() {
+				t.Error("IsLit() = true after extinguishing")
+			}
+		})
+	}
+}
+
+func TestObjectInstance_Tick_packedLight(t *testing.T) {
+	tests := map[string]struct {
+		packed   bool
+		wantLit  bool
+		wantBurn int
+	}{
+		"carried light burns": {
+			wantLit: true, wantBurn: 4,
+		},
+		"light in a bag goes out and keeps its fuel": {
+			packed: true, wantLit: false, wantBurn: 5,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			inv := NewInventory()
+			torch := newTestLight("torch", 5)
+			torch.Ignite()
+			if tc.packed {
+				bag, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier("bag", &assets.Object{
+					Aliases: []string{"bag"}, ShortDesc: "a bag", Flags: []string{"container"},
+				}))
+				bag.Contents.AddObj(torch)
+				inv.AddObj(bag)
+			} else {
+				inv.AddObj(torch)
+			}
+
+			inv.Tick()
+
+			if torch.Lit != tc.wantLit {
+				t.Errorf("Lit = %v, want %v", torch.Lit, tc.wantLit)
+			}
+			if torch.BurnTicks != tc.wantBurn {
+				t.Errorf("BurnTicks = %d, want %d", torch.BurnTicks, tc.wantBurn)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package game
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/display"
+)
+
+// rollLoot rolls each table for a source of level and spawns what drops.
+// Objects that fail to spawn are logged and skipped.
+func rollLoot(rolls []assets.LootRoll, level int, randIntN func(int) int) []*ObjectInstance {
+	var out []*ObjectInstance
+	for i := range rolls {
+		for _, spec := range rolls[i].Roll(level, randIntN) {
+			oi, err := SpawnObject(spec)
+			if err != nil {
+				slog.Error("spawning loot", "table", rolls[i].Table.Id(), "object", spec.Object.Id(), "error", err)
+				continue
+			}
+			out = append(out, oi)
+		}
+	}
+	return out
+}
+
+// anyLeft reports whether inv still holds any of ois.
+func anyLeft(inv *Inventory, ois []*ObjectInstance) bool {
+	if len(ois) == 0 {
+		return false
+	}
+	return len(inv.FindObjs(func(oi *ObjectInstance) bool { return slices.Contains(ois, oi) })) > 0
+}
+
+// lootGracePeriod is how long after a kill only the members of the killing
+// group its loot mode picks may take from the corpse.
+const lootGracePeriod = time.Minute
+
+// setLootRights lets only the characters with the given IDs take from the
+// object, or take the object itself, for lootGracePeriod. It must be called
+// before the object is placed anywhere other goroutines can reach it; the
+// rights are not changed after, so MayLoot reads them without a lock.
+func (oi *ObjectInstance) setLootRights(ids []string) {
+	oi.looters = ids
+	oi.lootUntil = time.Now().Add(lootGracePeriod)
+}
+
+// MayLoot reports whether actor may take from the object or take the object
+// itself. Anyone may once its loot grace period is over.
+func (oi *ObjectInstance) MayLoot(actor Actor) bool {
+	if len(oi.looters) == 0 || time.Now().After(oi.lootUntil) {
+		return true
+	}
+	return slices.Contains(oi.looters, actor.Id())
+}
+
+// LootMode returns how the character shares out loot when leading a group.
+func (ci *CharacterInstance) LootMode() assets.LootMode {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	if mode := ci.Character.Get().LootMode; mode != "" {
+		return mode
+	}
+	return assets.LootModeFreeForAll
+}
+
+// SetLootMode sets how the character shares out loot when leading a group.
+func (ci *CharacterInstance) SetLootMode(mode assets.LootMode) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.Character.Get().LootMode = mode
+}
+
+// AutoLoot reports whether the character takes the contents of corpses they
+// may loot as soon as they fall.
+func (ci *CharacterInstance) AutoLoot() bool {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.Character.Get().AutoLoot
+}
+
+// SetAutoLoot turns auto-loot on or off.
+func (ci *CharacterInstance) SetAutoLoot(on bool) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.Character.Get().AutoLoot = on
+}
+
+// AutoSplit reports whether the character splits the gold they loot with
+// their group.
+func (ci *CharacterInstance) AutoSplit() bool {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.Character.Get().AutoSplit
+}
+
+// SetAutoSplit turns auto-split on or off.
+func (ci *CharacterInstance) SetAutoSplit(on bool) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.Character.Get().AutoSplit = on
+}
+
+// killGroups gathers the players who share in a kill in room: each player on
+// the threat snapshot, with the members of their group standing in the room.
+// A group several members of which fought is gathered once.
+func killGroups(world *WorldState, snap map[string]int, room *RoomInstance) [][]*CharacterInstance {
+	seen := make(map[string]bool)
+	var groups [][]*CharacterInstance
+	for _, id := range slices.Sorted(maps.Keys(snap)) {
+		ci := world.GetPlayer(id)
+		if ci == nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		members := []*CharacterInstance{ci}
+		if leader := GroupLeader(ci); leader != nil {
+			WalkGroup(leader, func(a Actor) {
+				m, ok := a.(*CharacterInstance)
+				if !ok || seen[m.Id()] || !room.holds(m) {
+					return
+				}
+				seen[m.Id()] = true
+				members = append(members, m)
+			})
+		}
+		groups = append(groups, members)
+	}
+	return groups
+}
+
+// claimLoot gives the corpses in drops to the group of the character behind
+// killer: for the grace period only the members their leader's loot mode
+// picks may take from them. It returns the first of those, who gets the
+// gold, and the group. Call it before the drops are placed in the room, so
+// no one can take from a corpse before its rights are set.
+func claimLoot(killer Actor, groups [][]*CharacterInstance, drops []*ObjectInstance) (*CharacterInstance, []*CharacterInstance) {
+	members := killingGroup(killer, groups)
+	if len(members) == 0 {
+		return nil, nil
+	}
+	looters := pickLooters(members)
+	ids := make([]string, len(looters))
+	for i, ci := range looters {
+		ids[i] = ci.Id()
+	}
+	for _, oi := range drops {
+		if oi.Contents != nil {
+			oi.setLootRights(ids)
+		}
+	}
+	return looters[0], members
+}
+
+// shareLoot pays mi's gold to looter, the first looter claimLoot picked
+// from members, and has them take the corpse's contents at once if they
+// auto-loot.
+func shareLoot(mi *MobileInstance, looter *CharacterInstance, members []*CharacterInstance, drops []*ObjectInstance, room *RoomInstance) {
+	payGold(looter, members, mi.Mobile.Get().Gold, mi.Name())
+	if looter.AutoLoot() {
+		for _, oi := range drops {
+			if oi.Contents != nil {
+				autoLoot(looter, oi, room)
+			}
+		}
+	}
+}
+
+// killingGroup returns the group in groups holding the character behind
+// killer, with that character first. If killer is no one's, a lone group
+// gets the kill; otherwise no one does.
+func killingGroup(killer Actor, groups [][]*CharacterInstance) []*CharacterInstance {
+	var c *CharacterInstance
+	if killer != nil {
+		c = Controller(killer)
+	}
+	for _, members := range groups {
+		if i := slices.Index(members, c); i >= 0 {
+			out := append([]*CharacterInstance{c}, members[:i]...)
+			return append(out, members[i+1:]...)
+		}
+	}
+	if c == nil && len(groups) == 1 {
+		return groups[0]
+	}
+	return nil
+}
+
+// pickLooters returns the members of a killing group who may loot, by their
+// leader's loot mode. A player with no group loots alone.
+func pickLooters(members []*CharacterInstance) []*CharacterInstance {
+	leader, _ := GroupLeader(members[0]).(*CharacterInstance)
+	if leader == nil {
+		return members
+	}
+	switch leader.LootMode() {
+	case assets.LootModeRoundRobin:
+		return []*CharacterInstance{leader.nextLooter(members)}
+	case assets.LootModeLeader:
+		if slices.Contains(members, leader) {
+			return []*CharacterInstance{leader}
+		}
+	}
+	return members
+}
+
+// nextLooter returns whose turn it is to loot among members, for a leader
+// using round-robin. Turns go in order of character ID, so they hold steady
+// as long as the same members are present.
+func (ci *CharacterInstance) nextLooter(members []*CharacterInstance) *CharacterInstance {
+	sorted := slices.SortedFunc(slices.Values(members), func(a, b *CharacterInstance) int {
+		return strings.Compare(a.Id(), b.Id())
+	})
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	next := sorted[ci.lootTurn%len(sorted)]
+	ci.lootTurn++
+	return next
+}
+
+// payGold gives the gold looted from the corpse of name to looter, who
+// splits it evenly with the rest of members if they auto-split. The looter
+// keeps what doesn't divide evenly.
+func payGold(looter *CharacterInstance, members []*CharacterInstance, gold int, name string) {
+	if gold <= 0 {
+		return
+	}
+	if !looter.AutoSplit() || len(members) == 1 {
+		looter.AddGold(gold)
+		looter.QueueTickMsg(fmt.Sprintf("You get %d gold coins from the corpse of %s.", gold, name))
+		return
+	}
+	share := gold / len(members)
+	for _, m := range members {
+		if m == looter {
+			continue
+		}
+		m.AddGold(share)
+		m.QueueTickMsg(fmt.Sprintf("%s splits %d gold coins. Your share is %d coins.", looter.Name(), gold, share))
+	}
+	kept := gold - share*(len(members)-1)
+	looter.AddGold(kept)
+	looter.QueueTickMsg(fmt.Sprintf("You get %d gold coins from the corpse of %s and split them with your group. Your share is %d coins.", gold, name, kept))
+}
+
+// autoLoot moves what ci can carry from corpse into their inventory.
+func autoLoot(ci *CharacterInstance, corpse *ObjectInstance, room *RoomInstance) {
+	for _, oi := range corpse.Contents.FindObjs(func(*ObjectInstance) bool { return true }) {
+		if !ci.CanCarry(oi.TotalWeight()) {
+			ci.QueueTickMsg(fmt.Sprintf("%s: you can't carry that much weight.", display.Capitalize(oi.ShortDesc())))
+			continue
+		}
+		if corpse.Contents.RemoveObj(oi.InstanceId) == nil {
+			continue
+		}
+		ci.Inventory().AddObj(oi)
+		ci.QueueTickMsg(fmt.Sprintf("You get %s from %s.", oi.ShortDesc(), corpse.ShortDesc()))
+		room.EmitObjectEvent(ObjectEventPickup, oi, ci)
+		ci.advanceQuests(questEvent{kind: assets.QuestObjectiveCollect, id: oi.Object.Id()}, ci.QueueTickMsg)
+	}
+}
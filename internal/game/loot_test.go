@@ -0,0 +1,214 @@
+package game
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newTestGroup returns Alice (level 10) leading Bob (level 5) in a group,
+// both standing in the room of a test world.
+func newTestGroup(t *testing.T) (alice, bob *CharacterInstance, room *RoomInstance) {
+	t.Helper()
+	w, _, room := newTestWorld()
+	var cis []*CharacterInstance
+	for _, c := range []struct {
+		id, name string
+		level    int
+	}{{"alice", "Alice", 10}, {"bob", "Bob", 5}} {
+		ci, _ := NewCharacterInstance(storage.NewResolvedSmartIdentifier(c.id, &assets.Character{Name: c.name, Level: c.level}), make(chan []byte, 4), room)
+		if err := w.AddPlayer(ci); err != nil {
+			t.Fatalf("AddPlayer: %v", err)
+		}
+		cis = append(cis, ci)
+	}
+	alice, bob = cis[0], cis[1]
+	bob.SetFollowing(alice)
+	alice.AddFollower(bob)
+	alice.SetFollowerGrouped(bob.Id(), true)
+	return alice, bob, room
+}
+
+func TestObjectInstance_MayLoot(t *testing.T) {
+	tests := map[string]struct {
+		looters []string
+		expired bool
+		want    bool
+	}{
+		"no rights":     {want: true},
+		"has rights":    {looters: []string{"alice", "bob"}, want: true},
+		"no share":      {looters: []string{"alice"}},
+		"grace is over": {looters: []string{"alice"}, expired: true, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := &ObjectInstance{}
+			if tc.looters != nil {
+				oi.setLootRights(tc.looters)
+			}
+			if tc.expired {
+				oi.lootUntil = time.Now().Add(-time.Second)
+			}
+
+			if got := oi.MayLoot(newTestCI("bob", "Bob")); got != tc.want {
+				t.Errorf("MayLoot() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPickLooters(t *testing.T) {
+	tests := map[string]struct {
+		mode  assets.LootMode
+		solo  bool
+		first string // who leads members
+		want  [][]string
+	}{
+		"free-for-all": {
+			mode: assets.LootModeFreeForAll,
+			want: [][]string{{"alice", "bob"}, {"alice", "bob"}},
+		},
+		"leader only": {
+			mode:  assets.LootModeLeader,
+			first: "bob",
+			want:  [][]string{{"alice"}, {"alice"}},
+		},
+		"round-robin takes turns": {
+			mode: assets.LootModeRoundRobin,
+			want: [][]string{{"alice"}, {"bob"}, {"alice"}},
+		},
+		"solo": {
+			mode: assets.LootModeLeader,
+			solo: true,
+			want: [][]string{{"bob"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, _ := newTestGroup(t)
+			alice.SetLootMode(tc.mode)
+			members := []*CharacterInstance{alice, bob}
+			switch {
+			case tc.solo:
+				alice.SetFollowerGrouped(bob.Id(), false)
+				members = []*CharacterInstance{bob}
+			case tc.first == "bob":
+				members = []*CharacterInstance{bob, alice}
+			}
+
+			for i, want := range tc.want {
+				got := pickLooters(members)
+				var ids []string
+				for _, ci := range got {
+					ids = append(ids, ci.Id())
+				}
+				if !slices.Equal(ids, want) {
+					t.Errorf("pickLooters() call %d = %v, want %v", i+1, ids, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessDeath_group(t *testing.T) {
+	tests := map[string]struct {
+		solo        bool
+		mode        assets.LootMode
+		killer      string
+		autoLoot    bool
+		autoSplit   bool
+		wantXP      [2]int // Alice, Bob
+		wantGold    [2]int
+		wantBobLoot bool // Bob may take from the corpse
+		wantLooted  bool // the ring went to Alice's inventory
+	}{
+		"solo kill": {
+			solo:     true,
+			killer:   "alice",
+			wantXP:   [2]int{1050, 0},
+			wantGold: [2]int{11, 0},
+		},
+		"group splits xp by level": {
+			killer:      "alice",
+			wantXP:      [2]int{770, 577},
+			wantGold:    [2]int{11, 0},
+			wantBobLoot: true,
+		},
+		"leader loots what bob kills": {
+			mode:     assets.LootModeLeader,
+			killer:   "bob",
+			wantXP:   [2]int{770, 577},
+			wantGold: [2]int{11, 0},
+		},
+		"bob gets the gold when he kills": {
+			killer:      "bob",
+			wantXP:      [2]int{770, 577},
+			wantGold:    [2]int{0, 11},
+			wantBobLoot: true,
+		},
+		"auto-split": {
+			killer:      "alice",
+			autoSplit:   true,
+			wantXP:      [2]int{770, 577},
+			wantGold:    [2]int{6, 5},
+			wantBobLoot: true,
+		},
+		"auto-loot": {
+			mode:       assets.LootModeLeader,
+			killer:     "alice",
+			autoLoot:   true,
+			wantXP:     [2]int{770, 577},
+			wantGold:   [2]int{11, 0},
+			wantLooted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice, bob, room := newTestGroup(t)
+			if tc.solo {
+				alice.SetFollowerGrouped(bob.Id(), false)
+				room.RemovePlayer(bob.Id())
+			}
+			if tc.mode != "" {
+				alice.SetLootMode(tc.mode)
+			}
+			alice.SetAutoLoot(tc.autoLoot)
+			alice.SetAutoSplit(tc.autoSplit)
+
+			mob, _ := NewMobileInstance(storage.NewResolvedSmartIdentifier("wolf", &assets.Mobile{ShortDesc: "a wolf", Level: 10, Gold: 11}))
+			ring, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier("ring", &assets.Object{ShortDesc: "a ring"}))
+			mob.Inventory().AddObj(ring)
+			room.AddMob(mob)
+			mob.EnsureThreat(alice.Id(), alice)
+			killer := alice
+			if tc.killer == "bob" {
+				killer = bob
+			}
+
+			processDeath(context.Background(), mob, killer, room)
+
+			for i, ci := range []*CharacterInstance{alice, bob} {
+				if xp := ci.Character.Get().Experience; xp != tc.wantXP[i] {
+					t.Errorf("%s XP = %d, want %d", ci.Name(), xp, tc.wantXP[i])
+				}
+				if gold := ci.Gold(); gold != tc.wantGold[i] {
+					t.Errorf("%s gold = %d, want %d", ci.Name(), gold, tc.wantGold[i])
+				}
+			}
+			corpses := room.objects.FindObjs(func(oi *ObjectInstance) bool { return oi.Contents != nil })
+			if len(corpses) != 1 {
+				t.Fatalf("got %d corpses, want 1", len(corpses))
+			}
+			if got := corpses[0].MayLoot(bob); got != tc.wantBobLoot && !tc.solo {
+				t.Errorf("Bob MayLoot() = %v, want %v", got, tc.wantBobLoot)
+			}
+			if got := alice.Inventory().FindObjByDef("ring") != nil; got != tc.wantLooted {
+				t.Errorf("ring looted = %v, want %v", got, tc.wantLooted)
+			}
+		})
+	}
+}
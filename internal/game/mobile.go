@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand/v2"
+	"text/template"
 
 	"github.com/google/uuid"
 	"github.com/pixil98/go-mud/internal/assets"
@@ -12,12 +13,19 @@ import (
 )
 
 const (
-	// wanderChance is the 1-in-N chance a mob attempts to wander each tick.
-	wanderChance = 20
-	// scavengeChance is the 1-in-N chance a scavenger mob picks up an item each tick.
-	scavengeChance = 10
-	// aggroChance is the 1-in-N chance an aggressive mob initiates combat each tick.
-	aggroChance = 5
+	// defaultWanderChance is the 1-in-N chance a mob attempts to wander each
+	// tick when neither the mob nor its zone sets one.
+	defaultWanderChance = 20
+	// defaultScavengeChance is the 1-in-N chance a scavenger mob picks up an
+	// item each tick when neither the mob nor its zone sets one.
+	defaultScavengeChance = 10
+	// defaultAggroChance is the 1-in-N chance an aggressive mob initiates
+	// combat each tick when neither the mob nor its zone sets one.
+	defaultAggroChance = 5
+	// huntDepth is how many rooms away a hunting mob can still find its quarry.
+	huntDepth = 20
+	// huntDuration is how many ticks a mob hunts before giving up.
+	huntDuration = 100
 )
 
 // MobileInstance represents a single spawned instance of a Mobile definition.
@@ -28,6 +36,13 @@ type MobileInstance struct {
 	ActorInstance
 
 	randIntN func(int) int // source of randomness for wander/scavenge; defaults to rand.IntN
+
+	home *RoomInstance // room the mob was spawned into by a reset; nil otherwise
+
+	hunting   Actor // quarry the mob is tracking down; nil when not hunting
+	huntTicks int   // ticks left before the hunt is abandoned
+
+	triggers triggerState
 }
 
 // NewMobileInstance constructs a fully initialized MobileInstance from a mob
@@ -61,17 +76,54 @@ func (mi *MobileInstance) Name() string {
 	return mi.Mobile.Get().ShortDesc
 }
 
-// Tick advances one game tick: expires timed perks, regenerates resources,
-// and runs autonomous behavior (wandering, scavenging) when not in combat.
+// Home returns the room the mob was spawned into, or nil if it wasn't
+// spawned by a zone reset.
+func (mi *MobileInstance) Home() *RoomInstance {
+	return mi.home
+}
+
+// homeZone returns the zone that owns the mob for reset accounting, or nil
+// if it has no home.
+func (mi *MobileInstance) homeZone() *ZoneInstance {
+	if mi.home == nil {
+		return nil
+	}
+	return mi.home.zone
+}
+
+// strayFrom reports whether the mob belongs to a zone other than zone.
+func (mi *MobileInstance) strayFrom(zone *ZoneInstance) bool {
+	home := mi.homeZone()
+	return home != nil && home != zone
+}
+
+// chance returns a 1-in-N behavior chance: the mob's own setting picked out
+// by pick, else its home zone's, else def.
+func (mi *MobileInstance) chance(pick func(assets.MobBehavior) int, def int) int {
+	if n := pick(mi.Mobile.Get().Behavior); n > 0 {
+		return n
+	}
+	if zone := mi.homeZone(); zone != nil {
+		if n := pick(zone.Zone.Get().MobBehavior); n > 0 {
+			return n
+		}
+	}
+	return def
+}
+
+// Tick advances one game tick: expires timed perks, runs fight, hp and
+// random triggers, regenerates resources, and runs autonomous behavior
+// (hunting, walking, wandering, scavenging) when not in combat.
 func (mi *MobileInstance) Tick(ctx context.Context) {
 	if mi.commander == nil {
 		slog.Error("mob ticking without commander", "mob", mi.Mobile.Id())
 		return
 	}
 
-	mi.inventory.Tick()
-	mi.equipment.Tick()
+	mi.tickCarried()
 	mi.PerkCache.Tick()
+	mi.chaseDistantEnemies()
+	mi.triggerTick(ctx)
 
 	if mi.IsInCombat() {
 		mi.combatTick(ctx, "")
@@ -79,7 +131,7 @@ func (mi *MobileInstance) Tick(ctx context.Context) {
 		mi.mu.Lock()
 		mi.regenTick()
 		mi.mu.Unlock()
-		if mi.tryAggro() {
+		if mi.tryAggro() || mi.tryHunt(ctx) || mi.walkTick(ctx) {
 			return
 		}
 		mi.tryWander(ctx)
@@ -87,13 +139,39 @@ func (mi *MobileInstance) Tick(ctx context.Context) {
 	}
 }
 
+// chaseDistantEnemies drops living enemies that aren't in the mob's room,
+// such as an archer shooting from afar, and hunts the most threatening of
+// them. Sentinels hold their post and let the fight go.
+func (mi *MobileInstance) chaseDistantEnemies() {
+	room := mi.Room()
+	threat := mi.ThreatSnapshot()
+	var quarry Actor
+	for _, enemy := range mi.ThreatEnemies() {
+		if !enemy.IsAlive() || enemy.Room() == room {
+			continue
+		}
+		mi.RemoveThreatEntry(enemy.Id())
+		if t, ok := enemy.(interface{ RemoveThreatEntry(string) }); ok {
+			t.RemoveThreatEntry(mi.Id())
+		}
+		if quarry == nil || threat[enemy.Id()] > threat[quarry.Id()] {
+			quarry = enemy
+		}
+	}
+	if quarry != nil && !mi.Mobile.Get().HasFlag(assets.MobileFlagSentinel) {
+		mi.Hunt(quarry)
+	}
+}
+
 // tryAggro initiates combat with a living player in the mob's room if the mob
-// has the aggressive flag. Returns true if combat was initiated.
+// has the aggressive flag or belongs to a faction, and attacks that player on
+// sight. Returns true if combat was initiated.
 func (mi *MobileInstance) tryAggro() bool {
-	if !mi.Mobile.Get().HasFlag(assets.MobileFlagAggressive) {
+	def := mi.Mobile.Get()
+	if !def.HasFlag(assets.MobileFlagAggressive) && def.Faction.Get() == nil {
 		return false
 	}
-	if mi.randIntN(aggroChance) != 0 {
+	if mi.randIntN(mi.chance(func(b assets.MobBehavior) int { return b.AggroChance }, defaultAggroChance)) != 0 {
 		return false
 	}
 	if !mi.IsAlive() {
@@ -109,7 +187,7 @@ func (mi *MobileInstance) tryAggro() bool {
 
 	var target *CharacterInstance
 	room.ForEachPlayer(func(_ string, ci *CharacterInstance) {
-		if target != nil || !ci.IsAlive() {
+		if target != nil || !ci.IsAlive() || !mi.attacksOnSight(ci) || !mi.mayTurnOn(ci) || CheckDuel(mi, ci) != nil {
 			return
 		}
 		target = ci
@@ -123,12 +201,71 @@ func (mi *MobileInstance) tryAggro() bool {
 	return true
 }
 
+// Hunt sets the mob tracking target down, or stops the hunt when target is
+// nil. A hunting mob walks the shortest path toward its quarry one room per
+// tick and attacks on catching up. It gives up after huntDuration ticks, or
+// once the quarry dies, gets out of reach, or can't be tracked.
+func (mi *MobileInstance) Hunt(target Actor) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	mi.hunting = target
+	mi.huntTicks = huntDuration
+}
+
+// Hunting returns the actor the mob is tracking down, or nil.
+func (mi *MobileInstance) Hunting() Actor {
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+	return mi.hunting
+}
+
+// tryHunt takes one step of the hunt. Returns true if the mob moved or
+// attacked.
+func (mi *MobileInstance) tryHunt(ctx context.Context) bool {
+	mi.mu.Lock()
+	target := mi.hunting
+	mi.huntTicks--
+	if mi.huntTicks < 0 {
+		mi.hunting, target = nil, nil
+	}
+	mi.mu.Unlock()
+	if target == nil {
+		return false
+	}
+
+	from, to := mi.Room(), target.Room()
+	if from == nil || to == nil || !to.holds(target) || !target.IsAlive() || target.HasGrant(assets.PerkGrantNoTrack, "") {
+		mi.Hunt(nil)
+		return false
+	}
+
+	if from == to {
+		mi.Hunt(nil)
+		if from.Restricts(mi, assets.RoomFlagPeaceful) || CheckDuel(mi, target) != nil {
+			return false
+		}
+		mi.EnsureThreat(target.Id(), target)
+		target.EnsureThreat(mi.Id(), mi)
+		return true
+	}
+
+	path, ok := FindPath(from, mi, huntDepth, func(ri *RoomInstance) bool { return ri == to })
+	if !ok {
+		mi.Hunt(nil)
+		return false
+	}
+	if err := mi.commander.ExecCommand(ctx, path[0]); err != nil {
+		slog.Debug("mob hunt step failed", "mob", mi.Mobile.Id(), "direction", path[0], "error", err)
+	}
+	return true
+}
+
 // tryWander gives the mob a chance to move to a random adjacent room.
 func (mi *MobileInstance) tryWander(ctx context.Context) {
 	if mi.Mobile.Get().HasFlag(assets.MobileFlagSentinel) {
 		return
 	}
-	if mi.randIntN(wanderChance) != 0 {
+	if mi.randIntN(mi.chance(func(b assets.MobBehavior) int { return b.WanderChance }, defaultWanderChance)) != 0 {
 		return
 	}
 
@@ -137,20 +274,11 @@ func (mi *MobileInstance) tryWander(ctx context.Context) {
 		return
 	}
 
-	stayZone := mi.Mobile.Get().HasFlag(assets.MobileFlagStayZone)
-
 	var directions []string
 	for dir, re := range from.exits {
-		if re.closed || re.Dest == nil {
-			continue
-		}
-		if re.Dest.Restricts(mi, assets.RoomFlagNoMob) || re.Dest.Restricts(mi, assets.RoomFlagDeath) {
-			continue
-		}
-		if stayZone && re.Dest.zone != from.zone {
-			continue
+		if from.canWalk(mi, dir, re, from.zone) {
+			directions = append(directions, dir)
 		}
-		directions = append(directions, dir)
 	}
 
 	if len(directions) == 0 {
@@ -168,7 +296,7 @@ func (mi *MobileInstance) tryScavenge(ctx context.Context) {
 	if !mi.Mobile.Get().HasFlag(assets.MobileFlagScavenger) {
 		return
 	}
-	if mi.randIntN(scavengeChance) != 0 {
+	if mi.randIntN(mi.chance(func(b assets.MobBehavior) int { return b.ScavengeChance }, defaultScavengeChance)) != 0 {
 		return
 	}
 
@@ -213,6 +341,10 @@ func (mi *MobileInstance) Flags() []string {
 func (mi *MobileInstance) Move(fromRoom, toRoom *RoomInstance) {
 	fromRoom.RemoveMob(mi.Id())
 	toRoom.AddMob(mi)
+	mi.SetResting(false)
+
+	fromRoom.RefreshLight()
+	toRoom.RefreshLight()
 }
 
 // OnDeath creates a corpse containing all of the mob's inventory and equipped items.
@@ -230,7 +362,15 @@ func (mi *MobileInstance) Publish(_ []byte, _ []string) {}
 // QueueTickMsg is a no-op for mobs since they have no client connection.
 func (mi *MobileInstance) QueueTickMsg(_ string) {}
 
-// newCorpse creates a container ObjectInstance holding all of the mob's loot.
+// CorpseLifetime is the number of ticks a mob's corpse lasts before it rots
+// away and spills whatever is still inside onto the floor.
+const CorpseLifetime = 150
+
+// corpseDecayTmpl is the message shown when a corpse rots away.
+var corpseDecayTmpl = template.Must(template.New("decay").Parse("A quivering horde of maggots consumes {{ .Object }}."))
+
+// newCorpse creates a container ObjectInstance holding all of the mob's
+// belongings plus whatever its loot tables roll.
 func newCorpse(mi *MobileInstance) *ObjectInstance {
 	name := mi.Name()
 	corpseObj := &assets.Object{
@@ -239,13 +379,16 @@ func newCorpse(mi *MobileInstance) *ObjectInstance {
 		LongDesc:     fmt.Sprintf("The corpse of %s lies here.", name),
 		DetailedDesc: fmt.Sprintf("The lifeless body of %s. It may still be carrying some belongings.", name),
 		Flags:        []string{"container"},
+		Lifetime:     CorpseLifetime,
 	}
+	corpseObj.SetDecayTemplate(corpseDecayTmpl)
 	si := storage.NewResolvedSmartIdentifier("corpse-"+mi.Id(), corpseObj)
 	corpse := &ObjectInstance{
 		InstanceId: uuid.New().String(),
 		Object:     si,
 		Contents:   NewInventory(),
 	}
+	corpse.ActivateDecay()
 	for _, oi := range mi.inventory.Drain() {
 		oi.ActivateDecay()
 		corpse.Contents.AddObj(oi)
@@ -254,6 +397,10 @@ func newCorpse(mi *MobileInstance) *ObjectInstance {
 		oi.ActivateDecay()
 		corpse.Contents.AddObj(oi)
 	}
+	for _, oi := range rollLoot(mi.Mobile.Get().Loot, mi.Level(), mi.randIntN) {
+		oi.ActivateDecay()
+		corpse.Contents.AddObj(oi)
+	}
 	return corpse
 }
 
@@ -2,6 +2,7 @@ package game
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
@@ -120,9 +121,15 @@ func TestMobileInstance_SpendAP(t *testing.T) {
 
 
 func TestNewCorpse(t *testing.T) {
+	coins := storage.NewResolvedSmartIdentifier("coins", &assets.LootTable{Entries: []assets.LootEntry{
+		{Object: storage.NewResolvedSmartIdentifier("coin", &assets.Object{Aliases: []string{"coin"}, ShortDesc: "a coin"}), MinCount: 3},
+		{Object: storage.NewResolvedSmartIdentifier("gem", &assets.Object{Aliases: []string{"gem"}, ShortDesc: "a gem"}), MinLevel: 10},
+	}})
+
 	tests := map[string]struct {
 		inventoryItems []string
 		equippedItems  []string
+		loot           []assets.LootRoll
 		wantItemCount  int
 	}{
 		"empty mob yields empty corpse": {
@@ -141,11 +148,20 @@ func TestNewCorpse(t *testing.T) {
 			equippedItems:  []string{"ring"},
 			wantItemCount:  2,
 		},
+		"loot tables roll at the mob's level": {
+			inventoryItems: []string{"sword"},
+			loot:           []assets.LootRoll{{Table: coins}},
+			wantItemCount:  4,
+		},
+		"loot rolled at a set level": {
+			loot:          []assets.LootRoll{{Table: coins, Level: 10}},
+			wantItemCount: 4,
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			ref := storage.NewResolvedSmartIdentifier("test-mob", &assets.Mobile{ShortDesc: "a test mob", Level: 5})
+			ref := storage.NewResolvedSmartIdentifier("test-mob", &assets.Mobile{ShortDesc: "a test mob", Level: 5, Loot: tc.loot})
 			mi, _ := NewMobileInstance(ref)
 			for _, id := range tc.inventoryItems {
 				mi.inventory.AddObj(newTestObj(id))
@@ -264,7 +280,7 @@ func TestMobileInstance_sweepDeadEnemies(t *testing.T) {
 			room.AddMob(enemy)
 
 			mi.EnsureThreat("enemy", enemy)
-			mi.sweepDeadEnemies()
+			mi.sweepDeadEnemies(context.Background())
 
 			if got := mi.HasThreatFrom("enemy"); got != tc.wantInTable {
 				t.Errorf("HasThreatFrom(enemy) = %v, want %v", got, tc.wantInTable)
@@ -395,6 +411,16 @@ func TestMobileInstance_tryWander(t *testing.T) {
 			},
 			wantCommands: 1,
 		},
+		"cross-zone exit without stay_zone causes wander": {
+			randResult: zeroRand,
+			setupRoom: func(ri, dest *RoomInstance, zi *ZoneInstance) {
+				otherZone := newTestZone("other")
+				zi.AddRoom(ri)
+				otherZone.AddRoom(dest)
+				ri.exits["north"] = &ResolvedExit{Exit: assets.Exit{}, Dest: dest}
+			},
+			wantCommands: 1,
+		},
 		"stay_zone flag skips cross-zone exit": {
 			flags:      []string{"stay_zone"},
 			randResult: zeroRand,
@@ -436,6 +462,61 @@ func TestMobileInstance_tryWander(t *testing.T) {
 	}
 }
 
+func TestMobileInstance_chance(t *testing.T) {
+	tests := map[string]struct {
+		mob  assets.MobBehavior
+		zone assets.MobBehavior
+		home bool // spawned into the zone by a reset
+		want int
+	}{
+		"game default": {
+			home: true,
+			want: defaultWanderChance,
+		},
+		"zone setting": {
+			zone: assets.MobBehavior{WanderChance: 3},
+			home: true,
+			want: 3,
+		},
+		"mob setting beats zone": {
+			mob:  assets.MobBehavior{WanderChance: 7},
+			zone: assets.MobBehavior{WanderChance: 3},
+			home: true,
+			want: 7,
+		},
+		"zone setting needs a home": {
+			zone: assets.MobBehavior{WanderChance: 3},
+			want: defaultWanderChance,
+		},
+		"other chances don't leak": {
+			mob:  assets.MobBehavior{AggroChance: 2},
+			zone: assets.MobBehavior{ScavengeChance: 4},
+			home: true,
+			want: defaultWanderChance,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			zi, _ := NewZoneInstance(storage.NewResolvedSmartIdentifier("z", &assets.Zone{
+				ResetMode: assets.ZoneResetNever, MobBehavior: tc.zone,
+			}), nil)
+			ri := newTestRoom("den")
+			zi.AddRoom(ri)
+			mi, _ := NewMobileInstance(storage.NewResolvedSmartIdentifier("wolf", &assets.Mobile{
+				ShortDesc: "a wolf", Behavior: tc.mob,
+			}))
+			if tc.home {
+				mi.home = ri
+			}
+
+			got := mi.chance(func(b assets.MobBehavior) int { return b.WanderChance }, defaultWanderChance)
+			if got != tc.want {
+				t.Errorf("chance = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMobileInstance_tryScavenge(t *testing.T) {
 	ctx := context.Background()
 	tests := map[string]struct {
@@ -689,3 +770,151 @@ func TestMobileInstance_StatSections(t *testing.T) {
 		})
 	}
 }
+
+func TestMobileInstance_tryHunt(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		quarryIn     string // room holding the quarry; "" when not hunting
+		quarryDead   bool
+		notrack      bool
+		expired      bool
+		peaceful     bool
+		wantAct      bool
+		wantCommands []string
+		wantHunting  bool
+		wantFight    bool
+	}{
+		"not hunting": {},
+		"steps toward the quarry": {
+			quarryIn:     "far",
+			wantAct:      true,
+			wantCommands: []string{"north"},
+			wantHunting:  true,
+		},
+		"attacks on catching up": {
+			quarryIn:  "den",
+			wantAct:   true,
+			wantFight: true,
+		},
+		"no attack in a peaceful room": {
+			quarryIn: "den",
+			peaceful: true,
+		},
+		"dead quarry ends the hunt": {
+			quarryIn:   "far",
+			quarryDead: true,
+		},
+		"notrack quarry leaves no trail": {
+			quarryIn: "far",
+			notrack:  true,
+		},
+		"hunt gives up when time runs out": {
+			quarryIn: "far",
+			expired:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var denFlags []assets.RoomFlag
+			if tc.peaceful {
+				denFlags = append(denFlags, assets.RoomFlagPeaceful)
+			}
+			zi := newTestZone("z")
+			den, mid, far := newPathRoom("den", denFlags...), newPathRoom("mid"), newPathRoom("far")
+			for _, ri := range []*RoomInstance{den, mid, far} {
+				zi.AddRoom(ri)
+			}
+			den.exits["north"] = &ResolvedExit{Dest: mid}
+			mid.exits["north"] = &ResolvedExit{Dest: far}
+
+			fc := &fakeCommander{}
+			mi := newTestMob("hunter", []string{"memory"})
+			mi.commander = fc
+			mi.room = den
+			den.AddMob(mi)
+
+			quarry := newTestCI("quarry", "Quarry")
+			if tc.notrack {
+				quarry.PerkCache = *NewPerkCache([]assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantNoTrack}}, nil)
+			}
+			if !tc.quarryDead {
+				quarry.setResourceCurrent(assets.ResourceHp, 10)
+			}
+			if tc.quarryIn != "" {
+				room := map[string]*RoomInstance{"den": den, "far": far}[tc.quarryIn]
+				quarry.room = room
+				room.AddPlayer(quarry.Id(), quarry)
+				mi.Hunt(quarry)
+			}
+			if tc.expired {
+				mi.huntTicks = 0
+			}
+
+			if got := mi.tryHunt(ctx); got != tc.wantAct {
+				t.Errorf("tryHunt() = %v, want %v", got, tc.wantAct)
+			}
+			if !slices.Equal(fc.commands, tc.wantCommands) {
+				t.Errorf("commands = %v, want %v", fc.commands, tc.wantCommands)
+			}
+			if got := mi.Hunting() != nil; got != tc.wantHunting {
+				t.Errorf("still hunting = %v, want %v", got, tc.wantHunting)
+			}
+			if got := mi.HasThreatFrom(quarry.Id()) && quarry.HasThreatFrom(mi.Id()); got != tc.wantFight {
+				t.Errorf("fighting = %v, want %v", got, tc.wantFight)
+			}
+		})
+	}
+}
+
+func TestMobileInstance_chaseDistantEnemies(t *testing.T) {
+	tests := map[string]struct {
+		shooterIn   string
+		sentinel    bool
+		wantHunting bool
+		wantFight   bool
+	}{
+		"fights an enemy in the same room": {
+			shooterIn: "den",
+			wantFight: true,
+		},
+		"hunts an enemy shooting from afar": {
+			shooterIn:   "far",
+			wantHunting: true,
+		},
+		"sentinel lets a distant enemy go": {
+			shooterIn: "far",
+			sentinel:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			den, far := newPathRoom("den"), newPathRoom("far")
+			den.exits["north"] = &ResolvedExit{Dest: far}
+
+			var flags []string
+			if tc.sentinel {
+				flags = append(flags, "sentinel")
+			}
+			mi := newTestMob("target", flags)
+			mi.room = den
+			den.AddMob(mi)
+
+			shooter := newTestCI("shooter", "Shooter")
+			shooter.setResourceCurrent(assets.ResourceHp, 10)
+			room := map[string]*RoomInstance{"den": den, "far": far}[tc.shooterIn]
+			shooter.room = room
+			room.AddPlayer(shooter.Id(), shooter)
+			mi.EnsureThreat(shooter.Id(), shooter)
+			shooter.EnsureThreat(mi.Id(), mi)
+
+			mi.chaseDistantEnemies()
+
+			if got := mi.Hunting() == Actor(shooter); got != tc.wantHunting {
+				t.Errorf("hunting = %v, want %v", got, tc.wantHunting)
+			}
+			if got := mi.HasThreatFrom(shooter.Id()) && shooter.HasThreatFrom(mi.Id()); got != tc.wantFight {
+				t.Errorf("fighting = %v, want %v", got, tc.wantFight)
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package game
 
 import (
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pixil98/go-mud/internal/assets"
@@ -13,11 +16,24 @@ import (
 type ObjectInstance struct {
 	InstanceId     string
 	Object         storage.SmartIdentifier[*assets.Object]
-	Contents       *Inventory // Non-nil for containers; holds objects stored inside
-	Closed         bool       // Runtime open/closed state for containers with a Closure
-	Locked         bool       // Runtime lock state for containers with a Lock
-	RemainingTicks int        // Ticks until decay; 0 = not decaying
-	decaying       bool       // True once ActivateDecay has been called
+	Contents       *Inventory    // Non-nil for containers; holds objects stored inside
+	Closed         bool          // Runtime open/closed state for containers with a Closure
+	Locked         bool          // Runtime lock state for containers with a Lock
+	Trap           TrapState     // Runtime state of the closure's trap, if any
+	RemainingTicks int           // Ticks until decay; 0 = not decaying
+	Liquid         string        // Current liquid for drink containers; "" when empty
+	Sips           int           // Remaining sips for finite drink containers
+	Lit            bool          // True while a light source is burning
+	BurnTicks      int           // Remaining burn for light sources with finite fuel
+	Durability     int           // Remaining durability; meaningful when the definition sets one
+	Charges        int           // Remaining charges; guarded by mu once the instance is shared
+	Name           string        // Restrung short description; "" uses the definition's
+	ExtraPerks     []assets.Perk // Perks on this instance only, on top of the definition's
+	decaying       bool          // True once ActivateDecay has been called
+	looters        []string      // IDs of the characters who alone may loot it until lootUntil
+	lootUntil      time.Time     // When the loot grace period ends
+	triggers       triggerState  // Script variables for the definition's triggers
+	mu             sync.Mutex    // Guards Charges, which several actors may spend at once
 }
 
 // NewObjectInstance creates an ObjectInstance linked to its definition.
@@ -39,11 +55,152 @@ func NewObjectInstance(obj storage.SmartIdentifier[*assets.Object]) (*ObjectInst
 			if def.Closure.Lock != nil {
 				oi.Locked = def.Closure.Lock.Locked
 			}
+			oi.Trap.Reset(def.Closure)
 		}
 	}
+	if def.Drink != nil {
+		oi.Liquid = def.Drink.Liquid
+		oi.Sips = def.Drink.Sips
+	}
+	if def.Light != nil {
+		oi.BurnTicks = def.Light.Burn
+	}
+	oi.Durability = def.Durability
+	oi.Charges = def.Charges
 	return oi, nil
 }
 
+// ShortDesc returns the instance's restrung name, or the definition's short
+// description if it hasn't been restrung.
+func (oi *ObjectInstance) ShortDesc() string {
+	if oi.Name != "" {
+		return oi.Name
+	}
+	return oi.Object.Get().ShortDesc
+}
+
+// Perks returns the perks this instance grants while equipped: the
+// definition's perks plus any instance perks. Broken items grant none.
+func (oi *ObjectInstance) Perks() []assets.Perk {
+	if oi.Broken() {
+		return nil
+	}
+	def := oi.Object.Get().Perks
+	if len(oi.ExtraPerks) == 0 {
+		return def
+	}
+	return append(slices.Clone(def), oi.ExtraPerks...)
+}
+
+// AddPerks attaches extra perks to this instance, such as an enchantment.
+// A perk replaces any the instance already has with the same type, key and
+// arg, so enchanting an item again doesn't stack. Equipment holding the
+// instance must call RefreshPerks afterwards.
+func (oi *ObjectInstance) AddPerks(perks ...assets.Perk) {
+	for _, p := range perks {
+		oi.ExtraPerks = slices.DeleteFunc(oi.ExtraPerks, func(e assets.Perk) bool {
+			return e.Type == p.Type && e.Key == p.Key && e.Arg == p.Arg
+		})
+		oi.ExtraPerks = append(oi.ExtraPerks, p)
+	}
+}
+
+// Broken returns true if the item has durability and it has been used up.
+func (oi *ObjectInstance) Broken() bool {
+	return oi.Object.Get().Durability > 0 && oi.Durability == 0
+}
+
+// Damage wears the item down by n and returns true if this broke it.
+// Items without durability are unaffected.
+func (oi *ObjectInstance) Damage(n int) bool {
+	if oi.Object.Get().Durability == 0 || oi.Broken() || n <= 0 {
+		return false
+	}
+	oi.Durability = max(0, oi.Durability-n)
+	return oi.Durability == 0
+}
+
+// Repair restores the item to its full durability.
+func (oi *ObjectInstance) Repair() {
+	oi.Durability = oi.Object.Get().Durability
+}
+
+// UseCharge spends one charge and returns how many are left, or false if
+// none were. Of several actors spending at once, exactly one sees the last
+// charge go, with none left.
+func (oi *ObjectInstance) UseCharge() (int, bool) {
+	oi.mu.Lock()
+	defer oi.mu.Unlock()
+	if oi.Charges <= 0 {
+		return 0, false
+	}
+	oi.Charges--
+	return oi.Charges, true
+}
+
+// chargesLeft returns the remaining charges.
+func (oi *ObjectInstance) chargesLeft() int {
+	oi.mu.Lock()
+	defer oi.mu.Unlock()
+	return oi.Charges
+}
+
+// Sip drinks one sip from a drink container and returns the liquid drunk.
+// Infinite sources never run dry. Returns "" if the object holds nothing.
+func (oi *ObjectInstance) Sip() string {
+	d := oi.Object.Get().Drink
+	if d == nil || oi.Liquid == "" {
+		return ""
+	}
+	liquid := oi.Liquid
+	if !d.Infinite {
+		oi.Sips--
+		if oi.Sips <= 0 {
+			oi.Sips = 0
+			oi.Liquid = ""
+		}
+	}
+	return liquid
+}
+
+// FillFrom tops up this finite drink container from src and returns the
+// number of sips transferred. The caller checks that the liquids are
+// compatible; a non-empty container keeps its own liquid.
+func (oi *ObjectInstance) FillFrom(src *ObjectInstance) int {
+	d, sd := oi.Object.Get().Drink, src.Object.Get().Drink
+	if d == nil || d.Infinite || sd == nil || src.Liquid == "" {
+		return 0
+	}
+	n := d.Capacity - oi.Sips
+	if !sd.Infinite {
+		n = min(n, src.Sips)
+	}
+	if n <= 0 {
+		return 0
+	}
+	oi.Liquid = src.Liquid
+	oi.Sips += n
+	if !sd.Infinite {
+		src.Sips -= n
+		if src.Sips == 0 {
+			src.Liquid = ""
+		}
+	}
+	return n
+}
+
+// Empty pours out a finite drink container and returns the liquid it held.
+func (oi *ObjectInstance) Empty() string {
+	d := oi.Object.Get().Drink
+	if d == nil || d.Infinite {
+		return ""
+	}
+	liquid := oi.Liquid
+	oi.Liquid = ""
+	oi.Sips = 0
+	return liquid
+}
+
 // ActivateDecay starts the decay timer if this object has a finite Lifetime
 // and hasn't already been activated. Call this when a player acquires the item.
 func (oi *ObjectInstance) ActivateDecay() {
@@ -58,16 +215,82 @@ func (oi *ObjectInstance) Expired() bool {
 	return oi.decaying && oi.RemainingTicks == 0
 }
 
-// Tick decrements the decay timer if active and recursively ticks container
-// contents. The caller is responsible for removing this instance when
-// RemainingTicks reaches zero.
-func (oi *ObjectInstance) Tick() {
+// Tick decrements the decay timer if active, burns fuel if lit, and
+// recursively ticks container contents. It returns the events raised inside
+// the container and a burnout event if this light went out. The caller is
+// responsible for removing this instance when RemainingTicks reaches zero.
+func (oi *ObjectInstance) Tick() []ObjectEvent {
+	return oi.tick(false)
+}
+
+// tick is Tick for an object that is packed inside a container or not. A
+// light packed away gives no light, so it is put out with its fuel kept
+// rather than left burning.
+func (oi *ObjectInstance) tick(packed bool) []ObjectEvent {
+	var evs []ObjectEvent
 	if oi.Contents != nil {
-		oi.Contents.Tick()
+		evs = oi.Contents.tick(true)
 	}
 	if oi.RemainingTicks > 0 {
 		oi.RemainingTicks--
 	}
+	if packed {
+		oi.Extinguish()
+	} else if oi.burnTick() {
+		evs = append(evs, ObjectEvent{Type: ObjectEventBurnout, Obj: oi})
+	}
+	return evs
+}
+
+// Spill empties a container and returns what was inside, so a container
+// that decays can leave its contents behind. Returns nil for non-containers.
+func (oi *ObjectInstance) Spill() []*ObjectInstance {
+	if oi.Contents == nil {
+		return nil
+	}
+	return oi.Contents.Drain()
+}
+
+// burnTick consumes one tick of fuel from a lit light source and returns
+// true if it just burned out.
+func (oi *ObjectInstance) burnTick() bool {
+	l := oi.Object.Get().Light
+	if !oi.Lit || l == nil || l.Burn == 0 {
+		return false
+	}
+	oi.BurnTicks--
+	if oi.BurnTicks > 0 {
+		return false
+	}
+	oi.BurnTicks = 0
+	oi.Lit = false
+	return true
+}
+
+// BurnedOut returns true if this light source has no fuel left.
+func (oi *ObjectInstance) BurnedOut() bool {
+	l := oi.Object.Get().Light
+	return l != nil && l.Burn > 0 && oi.BurnTicks == 0
+}
+
+// Ignite lights a light source. Returns false if the object isn't a light
+// source, is already lit, or has burned out.
+func (oi *ObjectInstance) Ignite() bool {
+	if oi.Object.Get().Light == nil || oi.Lit || oi.BurnedOut() {
+		return false
+	}
+	oi.Lit = true
+	return true
+}
+
+// Extinguish puts out a lit light source, preserving its remaining fuel.
+// Returns false if it wasn't lit.
+func (oi *ObjectInstance) Extinguish() bool {
+	if !oi.Lit {
+		return false
+	}
+	oi.Lit = false
+	return true
 }
 
 // Resolve resolves this instance's object definition and recursively resolves
@@ -97,6 +320,9 @@ func SpawnObject(spec assets.ObjectSpawn) (*ObjectInstance, error) {
 	if err != nil {
 		return nil, fmt.Errorf("spawning: %w", err)
 	}
+	if spec.State != nil {
+		oi.applyState(spec.State)
+	}
 
 	for _, contentSpawn := range spec.Contents {
 		soi, err := SpawnObject(contentSpawn)
@@ -108,6 +334,52 @@ func SpawnObject(spec assets.ObjectSpawn) (*ObjectInstance, error) {
 	return oi, nil
 }
 
+// applyState restores persisted per-instance state onto a freshly spawned instance.
+func (oi *ObjectInstance) applyState(st *assets.ObjectState) {
+	def := oi.Object.Get()
+	if d := def.Drink; d != nil && !d.Infinite {
+		oi.Liquid = st.Liquid
+		oi.Sips = max(0, min(st.Sips, d.Capacity))
+		if oi.Sips == 0 {
+			oi.Liquid = ""
+		}
+	}
+	if l := def.Light; l != nil {
+		if l.Burn > 0 {
+			oi.BurnTicks = max(0, min(st.Burn, l.Burn))
+		}
+		oi.Lit = st.Lit && !oi.BurnedOut()
+	}
+	oi.Durability = max(0, def.Durability-st.Damage)
+	oi.Charges = max(0, def.Charges-st.ChargesUsed)
+	oi.Name = st.Name
+	oi.ExtraPerks = slices.Clone(st.Perks)
+}
+
+// state captures per-instance state worth persisting, or nil if the instance
+// is indistinguishable from a fresh spawn.
+func (oi *ObjectInstance) state() *assets.ObjectState {
+	def := oi.Object.Get()
+	drinkChanged := def.Drink != nil && !def.Drink.Infinite && (oi.Liquid != def.Drink.Liquid || oi.Sips != def.Drink.Sips)
+	lightChanged := def.Light != nil && (oi.Lit || oi.BurnTicks != def.Light.Burn)
+	charges := oi.chargesLeft()
+	wearChanged := oi.Durability != def.Durability || charges != def.Charges
+	custom := oi.Name != "" || len(oi.ExtraPerks) > 0
+	if !drinkChanged && !lightChanged && !wearChanged && !custom {
+		return nil
+	}
+	return &assets.ObjectState{
+		Liquid:      oi.Liquid,
+		Sips:        oi.Sips,
+		Lit:         oi.Lit,
+		Burn:        oi.BurnTicks,
+		Perks:       slices.Clone(oi.ExtraPerks),
+		Damage:      def.Durability - oi.Durability,
+		ChargesUsed: def.Charges - charges,
+		Name:        oi.Name,
+	}
+}
+
 // materializeInventoryEquipment batch-spawns a set of inventory and equipment
 // specs into runtime containers. Shared by character and mobile construction.
 func materializeInventoryEquipment(invSpawns []assets.ObjectSpawn, eqSpawns []assets.EquipmentSpawn) (*Inventory, *Equipment, error) {
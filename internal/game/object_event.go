@@ -0,0 +1,128 @@
+package game
+
+import (
+	"bytes"
+	"log/slog"
+	"text/template"
+
+	"github.com/pixil98/go-mud/internal/display"
+)
+
+// ObjectEventType identifies a point in an object instance's lifecycle.
+type ObjectEventType string
+
+// ObjectEventType values.
+const (
+	ObjectEventSpawn   ObjectEventType = "spawn"   // created by a reset or effect
+	ObjectEventPickup  ObjectEventType = "pickup"  // moved into an actor's inventory
+	ObjectEventDrop    ObjectEventType = "drop"    // moved from an actor onto the floor
+	ObjectEventDecay   ObjectEventType = "decay"   // lifetime ran out and it was removed
+	ObjectEventDestroy ObjectEventType = "destroy" // removed from the world any other way
+	ObjectEventBurnout ObjectEventType = "burnout" // lit light source ran out of fuel
+)
+
+// ObjectEvent describes something that happened to an object instance.
+type ObjectEvent struct {
+	Type ObjectEventType
+	Obj  *ObjectInstance
+
+	// Actor is the actor holding or acting on the object, or nil when the
+	// object was on the floor or affected by the world itself.
+	Actor Actor
+
+	// Room is where the event happened. Nil when the holder is nowhere.
+	Room *RoomInstance
+}
+
+// ObjectHook is called for every object lifecycle event.
+type ObjectHook func(ObjectEvent)
+
+// OnObjectEvent registers a hook to be called for each object lifecycle
+// event in the world. Register hooks before the driver starts.
+func (w *WorldState) OnObjectEvent(h ObjectHook) {
+	w.objectHooks = append(w.objectHooks, h)
+}
+
+// EmitObjectEvent announces an object event to the room and runs the
+// world's object hooks. actor may be nil.
+func (ri *RoomInstance) EmitObjectEvent(typ ObjectEventType, oi *ObjectInstance, actor Actor) {
+	emitObjectEvent(ObjectEvent{Type: typ, Obj: oi, Actor: actor, Room: ri})
+}
+
+// emitObjectEvent delivers the player-facing message for ev, if any, then
+// passes it to the hooks of the world the event happened in.
+func emitObjectEvent(ev ObjectEvent) {
+	announceObjectEvent(ev)
+	if ev.Room == nil || ev.Room.zone == nil || ev.Room.zone.world == nil {
+		return
+	}
+	for _, h := range ev.Room.zone.world.objectHooks {
+		h(ev)
+	}
+}
+
+// emitCarriedEvents emits events raised while ticking an actor's inventory
+// or equipment, attributing them to the holder.
+func emitCarriedEvents(holder Actor, evs []ObjectEvent) {
+	if len(evs) == 0 {
+		return
+	}
+	room := holder.Room()
+	for _, ev := range evs {
+		ev.Actor = holder
+		ev.Room = room
+		emitObjectEvent(ev)
+	}
+}
+
+// announceObjectEvent sends the messages players see for decay and burnout.
+// Carried objects are reported to their holder; floor objects to the room.
+func announceObjectEvent(ev ObjectEvent) {
+	var msg string
+	switch ev.Type {
+	case ObjectEventDecay:
+		msg = decayMessage(ev.Obj, ev.Actor != nil)
+	case ObjectEventBurnout:
+		msg = burnoutMessage(ev.Obj)
+	default:
+		return
+	}
+
+	if ev.Actor == nil {
+		if ev.Room != nil {
+			ev.Room.Publish([]byte(msg), nil)
+		}
+		return
+	}
+	ev.Actor.QueueTickMsg(msg)
+	// Others only notice a carried light going out, not a trinket rotting.
+	if ev.Type == ObjectEventBurnout && ev.Room != nil {
+		ev.Room.Publish([]byte(msg), []string{ev.Actor.Id()})
+	}
+}
+
+// Decay messages for objects without a decay_message of their own, parsed
+// once.
+var (
+	carriedDecayTmpl = template.Must(template.New("decay").Parse("{{ .Object }} crumbles to dust."))
+	floorDecayTmpl   = template.Must(template.New("decay").Parse("{{ .Object }} rots away."))
+)
+
+// decayMessage renders the object's decay_message template, or a default
+// that depends on whether the object was carried or lying on the floor.
+func decayMessage(oi *ObjectInstance, carried bool) string {
+	t := oi.Object.Get().DecayTemplate()
+	if t == nil {
+		t = floorDecayTmpl
+		if carried {
+			t = carriedDecayTmpl
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct{ Object string }{oi.ShortDesc()}); err != nil {
+		slog.Warn("rendering decay message", "object", oi.Object.Id(), "error", err)
+		return display.Capitalize(oi.ShortDesc()) + " decays."
+	}
+	return display.Capitalize(buf.String())
+}
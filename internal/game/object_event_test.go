@@ -0,0 +1,131 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+func TestDecayMessage(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		carried  bool
+		want     string
+	}{
+		"default carried": {
+			carried: true,
+			want:    "The rusty key crumbles to dust.",
+		},
+		"default on floor": {
+			want: "The rusty key rots away.",
+		},
+		"custom template": {
+			template: "{{ .Object }} dissolves into mist.",
+			carried:  true,
+			want:     "The rusty key dissolves into mist.",
+		},
+		"broken template falls back": {
+			template: "{{ .Missing }}",
+			want:     "The rusty key decays.",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			def := &assets.Object{
+				Aliases: []string{"key"}, ShortDesc: "the rusty key", Lifetime: 1, DecayMessage: tc.template,
+			}
+			if err := def.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier("key", def))
+			if got := decayMessage(oi, tc.carried); got != tc.want {
+				t.Errorf("decayMessage() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInventory_TickSpillsContents(t *testing.T) {
+	inv := NewInventory()
+	bag, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier("bag", &assets.Object{
+		Aliases: []string{"bag"}, ShortDesc: "a bag", Flags: []string{"container"}, Lifetime: 1,
+	}))
+	bag.ActivateDecay()
+	coin := newTestObj("coin")
+	rot := newTestObj("apple", 1)
+	rot.ActivateDecay()
+	bag.Contents.AddObj(coin)
+	bag.Contents.AddObj(rot)
+	inv.AddObj(bag)
+
+	evs := inv.Tick()
+
+	var decayed []string
+	for _, ev := range evs {
+		if ev.Type == ObjectEventDecay {
+			decayed = append(decayed, ev.Obj.Object.Id())
+		}
+	}
+	if len(decayed) != 2 {
+		t.Errorf("decay events = %v, want apple and bag", decayed)
+	}
+	if inv.Len() != 1 || inv.FindObjByDef("coin") == nil {
+		t.Errorf("inventory after tick has %d items, want only the spilled coin", inv.Len())
+	}
+}
+
+func TestRoomInstance_TickEmitsObjectEvents(t *testing.T) {
+	w, _, ri := newTestWorld()
+	var got []ObjectEvent
+	w.OnObjectEvent(func(ev ObjectEvent) { got = append(got, ev) })
+
+	mi := newTestMI("goblin", "a goblin")
+	mi.inventory = NewInventory()
+	mi.inventory.AddObj(newTestObj("dagger"))
+	corpse := newCorpse(mi)
+	corpse.RemainingTicks = 1
+	ri.AddObj(corpse)
+
+	ri.Tick()
+
+	if len(got) != 1 || got[0].Type != ObjectEventDecay || got[0].Obj != corpse {
+		t.Fatalf("events = %+v, want one corpse decay", got)
+	}
+	if got[0].Room != ri || got[0].Actor != nil {
+		t.Errorf("event room = %v actor = %v, want the room and no actor", got[0].Room, got[0].Actor)
+	}
+	if found := ri.FindObjs(func(oi *ObjectInstance) bool { return oi.Object.Id() == "dagger" }); len(found) != 1 {
+		t.Errorf("dagger on floor = %d, want 1 spilled from the corpse", len(found))
+	}
+}
+
+func TestActorInstance_tickCarried(t *testing.T) {
+	w, _, ri := newTestWorld()
+	var got []ObjectEvent
+	w.OnObjectEvent(func(ev ObjectEvent) { got = append(got, ev) })
+
+	ci := newTestCI("c1", "Alice")
+	ci.inventory = NewInventory()
+	ci.equipment = NewEquipment()
+	ci.room = ri
+	key := newTestObj("key", 1)
+	key.ActivateDecay()
+	ci.inventory.AddObj(key)
+
+	ci.tickCarried()
+
+	if len(got) != 1 || got[0].Type != ObjectEventDecay || got[0].Actor != Actor(ci) || got[0].Room != ri {
+		t.Fatalf("events = %+v, want one key decay attributed to the holder", got)
+	}
+	if len(ci.tickMsgBuf) != 1 || ci.tickMsgBuf[0] != "Key crumbles to dust." {
+		t.Errorf("tick messages = %q, want the crumble message", ci.tickMsgBuf)
+	}
+}
+
+func TestDecayMessage_corpse(t *testing.T) {
+	corpse := newCorpse(newTestMI("goblin", "a goblin"))
+	if got, want := decayMessage(corpse, false), "A quivering horde of maggots consumes the corpse of a goblin."; got != want {
+		t.Errorf("decayMessage() = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -209,4 +211,293 @@ func TestObjectInstance_Resolve(t *testing.T) {
 	}
 }
 
+// newTestDrink creates a drink container instance holding sips of liquid.
+func newTestDrink(id string, d assets.Drink) *ObjectInstance {
+	flags := []string(nil)
+	if d.Infinite {
+		flags = []string{"immobile"}
+	}
+	oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier(id, &assets.Object{
+		Aliases: []string{id}, ShortDesc: id, Flags: flags, Drink: &d,
+	}))
+	return oi
+}
+
+func TestObjectInstance_Sip(t *testing.T) {
+	tests := map[string]struct {
+		drink      assets.Drink
+		sips       int
+		wantLiquid string
+		wantSips   int
+		wantEmpty  bool
+	}{
+		"sip from full container": {
+			drink: assets.Drink{Liquid: "water", Capacity: 5, Sips: 5}, sips: 1,
+			wantLiquid: "water", wantSips: 4,
+		},
+		"last sip empties container": {
+			drink: assets.Drink{Liquid: "ale", Capacity: 5, Sips: 1}, sips: 1,
+			wantLiquid: "ale", wantSips: 0, wantEmpty: true,
+		},
+		"empty container yields nothing": {
+			drink: assets.Drink{Capacity: 5}, sips: 1,
+			wantLiquid: "", wantSips: 0, wantEmpty: true,
+		},
+		"fountain never runs dry": {
+			drink: assets.Drink{Liquid: "water", Infinite: true}, sips: 50,
+			wantLiquid: "water",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestDrink("flask", tc.drink)
+			var got string
+			for range tc.sips {
+				got = oi.Sip()
+			}
+			if got != tc.wantLiquid {
+				t.Errorf("Sip() = %q, want %q", got, tc.wantLiquid)
+			}
+			if oi.Sips != tc.wantSips {
+				t.Errorf("Sips = %d, want %d", oi.Sips, tc.wantSips)
+			}
+			if (oi.Liquid == "") != tc.wantEmpty {
+				t.Errorf("Liquid = %q, wantEmpty %v", oi.Liquid, tc.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestObjectInstance_FillFrom(t *testing.T) {
+	tests := map[string]struct {
+		dst, src      assets.Drink
+		wantN         int
+		wantDstSips   int
+		wantSrcSips   int
+		wantDstLiquid string
+		wantSrcLiquid string
+	}{
+		"fill empty container from fountain": {
+			dst:   assets.Drink{Capacity: 10},
+			src:   assets.Drink{Liquid: "water", Infinite: true},
+			wantN: 10, wantDstSips: 10, wantDstLiquid: "water", wantSrcLiquid: "water",
+		},
+		"top up partly full container": {
+			dst:   assets.Drink{Liquid: "water", Capacity: 10, Sips: 7},
+			src:   assets.Drink{Liquid: "water", Infinite: true},
+			wantN: 3, wantDstSips: 10, wantDstLiquid: "water", wantSrcLiquid: "water",
+		},
+		"finite source limits transfer and empties": {
+			dst:   assets.Drink{Capacity: 10},
+			src:   assets.Drink{Liquid: "ale", Capacity: 5, Sips: 4},
+			wantN: 4, wantDstSips: 4, wantSrcSips: 0, wantDstLiquid: "ale", wantSrcLiquid: "",
+		},
+		"full container takes nothing": {
+			dst:   assets.Drink{Liquid: "water", Capacity: 3, Sips: 3},
+			src:   assets.Drink{Liquid: "water", Capacity: 5, Sips: 5},
+			wantN: 0, wantDstSips: 3, wantSrcSips: 5, wantDstLiquid: "water", wantSrcLiquid: "water",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dst := newTestDrink("flask", tc.dst)
+			src := newTestDrink("source", tc.src)
+			if n := dst.FillFrom(src); n != tc.wantN {
+				t.Errorf("FillFrom() = %d, want %d", n, tc.wantN)
+			}
+			if dst.Sips != tc.wantDstSips || dst.Liquid != tc.wantDstLiquid {
+				t.Errorf("dst = %d %q, want %d %q", dst.Sips, dst.Liquid, tc.wantDstSips, tc.wantDstLiquid)
+			}
+			if !tc.src.Infinite && src.Sips != tc.wantSrcSips {
+				t.Errorf("src sips = %d, want %d", src.Sips, tc.wantSrcSips)
+			}
+			if src.Liquid != tc.wantSrcLiquid {
+				t.Errorf("src liquid = %q, want %q", src.Liquid, tc.wantSrcLiquid)
+			}
+		})
+	}
+}
+
+func TestObjectInstance_StateRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		drink     assets.Drink
+		sips      int
+		wantState bool
+		wantSips  int
+	}{
+		"untouched container saves no state": {
+			drink: assets.Drink{Liquid: "water", Capacity: 5, Sips: 5}, sips: 0,
+			wantState: false, wantSips: 5,
+		},
+		"partly drunk container persists sips": {
+			drink: assets.Drink{Liquid: "water", Capacity: 5, Sips: 5}, sips: 2,
+			wantState: true, wantSips: 3,
+		},
+		"emptied container persists empty": {
+			drink: assets.Drink{Liquid: "water", Capacity: 2, Sips: 2}, sips: 2,
+			wantState: true, wantSips: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestDrink("flask", tc.drink)
+			for range tc.sips {
+				oi.Sip()
+			}
+			spec := objectInstanceToSpawn(oi)
+			if (spec.State != nil) != tc.wantState {
+				t.Fatalf("State = %+v, wantState %v", spec.State, tc.wantState)
+			}
+			restored, err := SpawnObject(spec)
+			if err != nil {
+				t.Fatalf("SpawnObject: %v", err)
+			}
+			if restored.Sips != tc.wantSips || restored.Liquid != oi.Liquid {
+				t.Errorf("restored = %d %q, want %d %q", restored.Sips, restored.Liquid, tc.wantSips, oi.Liquid)
+			}
+		})
+	}
+}
+
+// newTestGear creates an item with the given durability, charges, and one
+// armor perk, for instance state tests.
+func newTestGear(id string, durability, charges int) *ObjectInstance {
+	oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier(id, &assets.Object{
+		Aliases: []string{id}, ShortDesc: "a " + id, Durability: durability, Charges: charges,
+		Perks: []assets.Perk{{Type: assets.PerkTypeModifier, Key: "core.combat.ac.flat", Value: 1}},
+	}))
+	return oi
+}
 
+func TestObjectInstance_Perks(t *testing.T) {
+	enchant := assets.Perk{Type: assets.PerkTypeModifier, Key: "core.combat.ac.flat", Value: 2}
+	tests := map[string]struct {
+		durability int
+		extra      []assets.Perk
+		damage     int
+		wantPerks  int
+		wantBroken bool
+	}{
+		"definition perks only": {
+			wantPerks: 1,
+		},
+		"instance perks are added": {
+			extra: []assets.Perk{enchant}, wantPerks: 2,
+		},
+		"same instance perk replaces the last": {
+			extra: []assets.Perk{enchant, enchant}, wantPerks: 2,
+		},
+		"damaged item keeps its perks": {
+			durability: 10, damage: 4, extra: []assets.Perk{enchant}, wantPerks: 2,
+		},
+		"broken item grants nothing": {
+			durability: 10, damage: 12, extra: []assets.Perk{enchant}, wantPerks: 0, wantBroken: true,
+		},
+		"item without durability can't break": {
+			damage: 100, wantPerks: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestGear("shield", tc.durability, 0)
+			oi.AddPerks(tc.extra...)
+			if broke := oi.Damage(tc.damage); broke != tc.wantBroken {
+				t.Errorf("Damage() = %v, want %v", broke, tc.wantBroken)
+			}
+			if got := len(oi.Perks()); got != tc.wantPerks {
+				t.Errorf("len(Perks()) = %d, want %d", got, tc.wantPerks)
+			}
+			if len(oi.Object.Get().Perks) != 1 {
+				t.Error("instance perks leaked into the definition")
+			}
+			oi.Repair()
+			if oi.Broken() {
+				t.Error("Broken() = true after Repair")
+			}
+		})
+	}
+}
+
+func TestObjectInstance_UseCharge(t *testing.T) {
+	oi := newTestGear("wand", 0, 2)
+	for i, want := range []struct {
+		left int
+		ok   bool
+	}{{1, true}, {0, true}, {0, false}} {
+		if left, ok := oi.UseCharge(); left != want.left || ok != want.ok {
+			t.Errorf("UseCharge() #%d = %d, %v, want %d, %v", i+1, left, ok, want.left, want.ok)
+		}
+	}
+}
+
+func TestObjectInstance_UseCharge_concurrent(t *testing.T) {
+	oi := newTestGear("portal", 0, 3)
+	var spent, emptied atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if left, ok := oi.UseCharge(); ok {
+				spent.Add(1)
+				if left == 0 {
+					emptied.Add(1)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if spent.Load() != 3 || emptied.Load() != 1 {
+		t.Errorf("spent %d charges and emptied it %d times, want 3 and 1", spent.Load(), emptied.Load())
+	}
+}
+
+func TestObjectInstance_CustomStateRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		modify    func(oi *ObjectInstance)
+		wantState bool
+	}{
+		"untouched item saves no state": {
+			modify: func(oi *ObjectInstance) {},
+		},
+		"restrung item": {
+			modify:    func(oi *ObjectInstance) { oi.Name = "Vorpal, the blade of legend" },
+			wantState: true,
+		},
+		"enchanted item": {
+			modify: func(oi *ObjectInstance) {
+				oi.AddPerks(assets.Perk{Type: assets.PerkTypeGrant, Key: "attack", Arg: "1d4"})
+			},
+			wantState: true,
+		},
+		"damaged item with spent charges": {
+			modify: func(oi *ObjectInstance) {
+				oi.Damage(3)
+				oi.UseCharge()
+			},
+			wantState: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			oi := newTestGear("sword", 10, 5)
+			tc.modify(oi)
+			spec := objectInstanceToSpawn(oi)
+			if (spec.State != nil) != tc.wantState {
+				t.Fatalf("State = %+v, wantState %v", spec.State, tc.wantState)
+			}
+			restored, err := SpawnObject(spec)
+			if err != nil {
+				t.Fatalf("SpawnObject: %v", err)
+			}
+			if restored.ShortDesc() != oi.ShortDesc() {
+				t.Errorf("ShortDesc() = %q, want %q", restored.ShortDesc(), oi.ShortDesc())
+			}
+			if restored.Durability != oi.Durability || restored.Charges != oi.Charges {
+				t.Errorf("restored durability/charges = %d/%d, want %d/%d",
+					restored.Durability, restored.Charges, oi.Durability, oi.Charges)
+			}
+			if len(restored.ExtraPerks) != len(oi.ExtraPerks) {
+				t.Errorf("restored %d instance perks, want %d", len(restored.ExtraPerks), len(oi.ExtraPerks))
+			}
+		})
+	}
+}
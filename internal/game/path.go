@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// FindPath searches breadth-first from from for the nearest room accepted by
+// goal that walker can reach in at most maxSteps moves, crossing zones as
+// the exits lead. It returns the directions to walk in order, and false if
+// no such room was found. A walker already standing in a goal room gets an
+// empty path.
+func FindPath(from *RoomInstance, walker GrantHolder, maxSteps int, goal func(*RoomInstance) bool) ([]string, bool) {
+	if goal(from) {
+		return nil, true
+	}
+
+	type step struct {
+		room  *RoomInstance
+		prev  *step
+		dir   string
+		depth int
+	}
+	seen := map[*RoomInstance]bool{from: true}
+	queue := []*step{{room: from}}
+	var found *step
+	for len(queue) > 0 && found == nil {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.depth >= maxSteps {
+			continue
+		}
+		cur.room.ForEachExit(func(dir string, re *ResolvedExit) {
+			if found != nil || seen[re.Dest] || !cur.room.canWalk(walker, dir, re, from.zone) {
+				return
+			}
+			seen[re.Dest] = true
+			next := &step{room: re.Dest, prev: cur, dir: dir, depth: cur.depth + 1}
+			if goal(re.Dest) {
+				found = next
+				return
+			}
+			queue = append(queue, next)
+		})
+	}
+	if found == nil {
+		return nil, false
+	}
+
+	path := make([]string, found.depth)
+	for s := found; s.prev != nil; s = s.prev {
+		path[s.depth-1] = s.dir
+	}
+	return path, true
+}
+
+// CanWalk reports whether walker may set off through the exit in direction
+// dir under the same rules FindPath follows.
+func (ri *RoomInstance) CanWalk(walker GrantHolder, dir string) bool {
+	re, ok := ri.exits[dir]
+	return ok && ri.canWalk(walker, dir, re, ri.zone)
+}
+
+// canWalk reports whether walker may take the exit: it must be visible to
+// the walker, open, and lead somewhere. Nobody walks into deep water or a
+// death trap without an ignore grant for it; mobs also keep out of no-mob
+// rooms, and stay-zone mobs out of zones other than home. A mob spawned by a
+// reset counts its spawn zone as home rather than the one given.
+func (ri *RoomInstance) canWalk(walker GrantHolder, dir string, re *ResolvedExit, home *ZoneInstance) bool {
+	if re.Dest == nil || re.closed || !ri.exitVisible(walker, dir, re) {
+		return false
+	}
+	if re.Dest.Restricts(walker, assets.RoomFlagWater) || re.Dest.Restricts(walker, assets.RoomFlagDeath) {
+		return false
+	}
+	if mi, ok := walker.(*MobileInstance); ok {
+		if re.Dest.Restricts(mi, assets.RoomFlagNoMob) {
+			return false
+		}
+		if hz := mi.homeZone(); hz != nil {
+			home = hz
+		}
+		if mi.Mobile.Get().HasFlag(assets.MobileFlagStayZone) && re.Dest.zone != home {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newPathRoom creates a room carrying the given room flags.
+func newPathRoom(id string, flags ...assets.RoomFlag) *RoomInstance {
+	var perks []assets.Perk
+	for _, f := range flags {
+		perks = append(perks, assets.Perk{Type: assets.PerkTypeGrant, Key: string(f)})
+	}
+	ri, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier(id, &assets.Room{Name: id, Perks: perks}))
+	return ri
+}
+
+func TestFindPath(t *testing.T) {
+	tests := map[string]struct {
+		goal      string
+		maxSteps  int
+		flags     map[string][]assets.RoomFlag
+		walker    func() GrantHolder
+		wantPath  []string
+		wantFound bool
+	}{
+		"already there": {
+			goal:      "a",
+			wantFound: true,
+		},
+		"adjacent room": {
+			goal:      "b",
+			wantPath:  []string{"north"},
+			wantFound: true,
+		},
+		"shortest route is taken": {
+			goal:      "c",
+			wantPath:  []string{"north", "north"},
+			wantFound: true,
+		},
+		"closed door is not walked through": {
+			goal: "d",
+		},
+		"route longer than max steps": {
+			goal:     "c",
+			maxSteps: 1,
+		},
+		"crosses into another zone": {
+			goal:      "e",
+			wantPath:  []string{"north", "north", "east"},
+			wantFound: true,
+		},
+		"deep water blocks the way": {
+			goal:  "e",
+			flags: map[string][]assets.RoomFlag{"e": {assets.RoomFlagWater}},
+		},
+		"water walker crosses deep water": {
+			goal:  "e",
+			flags: map[string][]assets.RoomFlag{"e": {assets.RoomFlagWater}},
+			walker: func() GrantHolder {
+				ci := newTestCI("swimmer", "Swimmer")
+				ci.PerkCache = *NewPerkCache([]assets.Perk{
+					{Type: assets.PerkTypeGrant, Key: assets.PerkGrantIgnoreRestriction, Arg: string(assets.RoomFlagWater)},
+				}, nil)
+				return ci
+			},
+			wantPath:  []string{"north", "north", "east"},
+			wantFound: true,
+		},
+		"death trap is avoided": {
+			goal:  "c",
+			flags: map[string][]assets.RoomFlag{"b": {assets.RoomFlagDeath}},
+		},
+		"players walk through no-mob rooms": {
+			goal:      "c",
+			flags:     map[string][]assets.RoomFlag{"b": {assets.RoomFlagNoMob}},
+			wantPath:  []string{"north", "north"},
+			wantFound: true,
+		},
+		"mobs keep out of no-mob rooms": {
+			goal:   "c",
+			flags:  map[string][]assets.RoomFlag{"b": {assets.RoomFlagNoMob}},
+			walker: func() GrantHolder { return newTestMob("m", nil) },
+		},
+		"stay-zone mobs keep to their zone": {
+			goal:   "e",
+			walker: func() GrantHolder { return newTestMob("m", []string{"stay_zone"}) },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// a -north-> b -north-> c -east-> e (other zone)
+			// a -east-> d behind a closed door; d -north-> c
+			home, other := newTestZone("home"), newTestZone("other")
+			rooms := map[string]*RoomInstance{}
+			for _, id := range []string{"a", "b", "c", "d", "e"} {
+				rooms[id] = newPathRoom(id, tc.flags[id]...)
+				if id == "e" {
+					other.AddRoom(rooms[id])
+				} else {
+					home.AddRoom(rooms[id])
+				}
+			}
+			rooms["a"].exits["north"] = &ResolvedExit{Dest: rooms["b"]}
+			rooms["a"].exits["east"] = &ResolvedExit{Dest: rooms["d"], closed: true}
+			rooms["b"].exits["north"] = &ResolvedExit{Dest: rooms["c"]}
+			rooms["c"].exits["east"] = &ResolvedExit{Dest: rooms["e"]}
+			rooms["d"].exits["north"] = &ResolvedExit{Dest: rooms["c"]}
+
+			var walker GrantHolder = newTestCI("walker", "Walker")
+			if tc.walker != nil {
+				walker = tc.walker()
+			}
+			maxSteps := tc.maxSteps
+			if maxSteps == 0 {
+				maxSteps = 10
+			}
+
+			goal := rooms[tc.goal]
+			path, found := FindPath(rooms["a"], walker, maxSteps, func(ri *RoomInstance) bool { return ri == goal })
+			if found != tc.wantFound {
+				t.Fatalf("found = %v, want %v", found, tc.wantFound)
+			}
+			if !slices.Equal(path, tc.wantPath) {
+				t.Errorf("path = %v, want %v", path, tc.wantPath)
+			}
+		})
+	}
+}
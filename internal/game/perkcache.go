@@ -1,6 +1,7 @@
 package game
 
 import (
+	"maps"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -136,6 +137,37 @@ func (pc *PerkCache) AddTimedPerks(name string, perks []assets.Perk, ticks int)
 	pc.invalidate()
 }
 
+// timedSnapshot returns the timed entries registered now, for restoreTimed.
+func (pc *PerkCache) timedSnapshot() map[string]*timedPerk {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return maps.Clone(pc.timedEntries)
+}
+
+// restoreTimed removes the timed entries added or replaced since snapshot
+// was taken, putting back a replaced entry if it had ticks left. Entries
+// that expired since are not restored.
+func (pc *PerkCache) restoreTimed(snapshot map[string]*timedPerk) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	changed := false
+	for name, e := range pc.timedEntries {
+		old := snapshot[name]
+		if e == old {
+			continue
+		}
+		if old != nil && old.remaining > 0 {
+			pc.timedEntries[name] = old
+		} else {
+			delete(pc.timedEntries, name)
+		}
+		changed = true
+	}
+	if changed {
+		pc.invalidate()
+	}
+}
+
 // Tick decrements all timed perk timers and removes expired entries.
 // Returns true if any entries were removed.
 func (pc *PerkCache) Tick() bool {
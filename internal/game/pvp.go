@@ -0,0 +1,202 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+const (
+	// pvpToggleCooldown is how long a character must wait between changes to
+	// their PvP flag, so it can't be dropped the moment a fight turns.
+	pvpToggleCooldown = 10 * time.Minute
+
+	// pvpRepeatWindow is how long after killing a player another kill of the
+	// same player goes unrecorded, so a record can't be padded on an alt.
+	pvpRepeatWindow = time.Hour
+
+	// pvpLevelGap is how many levels below the killer a victim may be for
+	// the kill to be recorded.
+	pvpLevelGap = 5
+)
+
+var (
+	// ErrPvPOff is returned when an attacker who hasn't agreed to fight other
+	// players attacks one outside an arena.
+	ErrPvPOff = errors.New("attacker has not agreed to player fights")
+	// ErrPvPProtected is returned when the target of an attack outside an
+	// arena hasn't agreed to fight other players.
+	ErrPvPProtected = errors.New("target has not agreed to player fights")
+	// ErrPvPCooldown is returned when a character changes their PvP flag
+	// again too soon.
+	ErrPvPCooldown = errors.New("pvp flag changed too recently")
+	// ErrPvPInCombat is returned when a character tries to turn their PvP
+	// flag off mid-fight.
+	ErrPvPInCombat = errors.New("cannot leave pvp while fighting")
+)
+
+// Controller returns the character in charge of actor: the actor itself if
+// it is a character, or the character at the head of a mob's follow chain
+// (pets, charms, escorts). Returns nil for mobs no character leads.
+func Controller(actor Actor) *CharacterInstance {
+	cur := actor
+	for i := 0; i < 100 && cur != nil; i++ {
+		if ci, ok := cur.(*CharacterInstance); ok {
+			return ci
+		}
+		cur = cur.Following()
+	}
+	return nil
+}
+
+// CheckPvP returns nil if attacker may start a fight with target. Only fights
+// that pit two players (or the mobs they lead) against each other are
+// limited: those are always allowed between duelists and when both sides
+// stand in an arena, and otherwise need both players to have agreed to them.
+func CheckPvP(attacker, target Actor) error {
+	if opp := DuelOpponent(attacker); opp != nil && opp.Id() == target.Id() {
+		return nil
+	}
+	a, t := Controller(attacker), Controller(target)
+	if a == nil || t == nil || a == t {
+		return nil
+	}
+	if attacker.Room().IsArena() && target.Room().IsArena() {
+		return nil
+	}
+	if !a.PvP() {
+		return ErrPvPOff
+	}
+	if !t.PvP() {
+		return ErrPvPProtected
+	}
+	return nil
+}
+
+// PvP reports whether the character has agreed to fight other players
+// outside arenas.
+func (ci *CharacterInstance) PvP() bool {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return ci.Character.Get().PvP
+}
+
+// PvPCooldown returns how long until the character may change their PvP flag
+// again.
+func (ci *CharacterInstance) PvPCooldown() time.Duration {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	return max(time.Until(ci.Character.Get().PvPToggledAt.Add(pvpToggleCooldown)), 0)
+}
+
+// SetPvP turns the character's PvP flag on or off. The flag can't change
+// again within pvpToggleCooldown, nor be turned off mid-fight.
+func (ci *CharacterInstance) SetPvP(on bool) error {
+	if ci.PvP() == on {
+		return nil
+	}
+	if !on && ci.IsInCombat() {
+		return ErrPvPInCombat
+	}
+	if ci.PvPCooldown() > 0 {
+		return ErrPvPCooldown
+	}
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	c := ci.Character.Get()
+	c.PvP = on
+	c.PvPToggledAt = time.Now()
+	return nil
+}
+
+// PvPRecord returns how many players the character has killed, and been
+// killed by, outside arenas.
+func (ci *CharacterInstance) PvPRecord() (kills, deaths int) {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	c := ci.Character.Get()
+	return c.PvPKills, c.PvPDeaths
+}
+
+// recordPvPKill credits the character with killing victim and reports
+// whether the kill counted toward their record. It doesn't if the victim
+// is more than pvpLevelGap levels below the character, or the character
+// killed them within the last pvpRepeatWindow.
+func (ci *CharacterInstance) recordPvPKill(victim *CharacterInstance) bool {
+	counts := victim.Level() >= ci.Level()-pvpLevelGap
+	now := time.Now()
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if last, ok := ci.pvpVictims[victim.Id()]; ok && now.Sub(last) < pvpRepeatWindow {
+		counts = false
+	}
+	if ci.pvpVictims == nil {
+		ci.pvpVictims = make(map[string]time.Time)
+	}
+	ci.pvpVictims[victim.Id()] = now
+	if counts {
+		ci.Character.Get().PvPKills++
+	}
+	return counts
+}
+
+// processPvPDeath records a player's death in room at the hands of the other
+// players on their threat table, if any.
+func processPvPDeath(victim *CharacterInstance, room *RoomInstance) {
+	snap := victim.ThreatSnapshot()
+	if len(snap) == 0 {
+		return
+	}
+	world := room.Zone().World()
+	var killed bool
+	for actorId := range snap {
+		killer := world.GetPlayer(actorId)
+		if killer == nil || killer == victim {
+			continue
+		}
+		killed = true
+		if killer.recordPvPKill(victim) {
+			kills, _ := killer.PvPRecord()
+			killer.QueueTickMsg(fmt.Sprintf("You have killed %s. Player kills: %d.", victim.Name(), kills))
+		} else {
+			killer.QueueTickMsg(fmt.Sprintf("You have killed %s, but it doesn't count toward your record.", victim.Name()))
+		}
+	}
+	if killed {
+		victim.mu.Lock()
+		victim.Character.Get().PvPDeaths++
+		victim.mu.Unlock()
+	}
+}
+
+// defeatInArena ends the character's fights after they fall in an arena.
+// They are restored rather than killed, and may fight again.
+func (ci *CharacterInstance) defeatInArena(room *RoomInstance) {
+	_, maxHP := ci.Resource(assets.ResourceHp)
+	ci.SetResource(assets.ResourceHp, maxHP)
+	ci.Disengage()
+	ci.deathProcessed.Store(false)
+	ci.QueueTickMsg("You have been defeated! You pick yourself up, none the worse for it.")
+	msg := fmt.Sprintf("%s is defeated!", ci.Name())
+	room.ForEachPlayer(func(id string, other *CharacterInstance) {
+		if id != ci.Id() {
+			other.QueueTickMsg(msg)
+		}
+	})
+}
+
+// mayTurnOn reports whether the mob may pick a fight with ci under the PvP
+// rules. A mob a character leads never turns on that character or their
+// group, and attacks other players only where its leader could.
+func (mi *MobileInstance) mayTurnOn(ci *CharacterInstance) bool {
+	owner := Controller(mi)
+	if owner == nil {
+		return true
+	}
+	if owner == ci || AreAllies(owner, ci) {
+		return false
+	}
+	return CheckPvP(mi, ci) == nil
+}
@@ -0,0 +1,239 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+var arenaPerk = assets.Perk{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagArena)}
+
+func TestCheckPvP(t *testing.T) {
+	tests := map[string]struct {
+		alicePvP bool
+		bobPvP   bool
+		arena    bool
+		petFight bool // Alice's wolf attacks Bob
+		ownPet   bool // Alice attacks her own wolf
+		mob      bool // a wild mob attacks Bob
+		expErr   error
+	}{
+		"neither agreed": {
+			expErr: ErrPvPOff,
+		},
+		"target not agreed": {
+			alicePvP: true,
+			expErr:   ErrPvPProtected,
+		},
+		"attacker not agreed": {
+			bobPvP: true,
+			expErr: ErrPvPOff,
+		},
+		"both agreed":           {alicePvP: true, bobPvP: true},
+		"arena allows anyone":   {arena: true},
+		"pet follows its owner": {petFight: true, bobPvP: true, expErr: ErrPvPOff},
+		"pet of a pvp player":   {petFight: true, alicePvP: true, bobPvP: true},
+		"own pet":               {ownPet: true},
+		"wild mob":              {mob: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			room := newTestRoom("yard")
+			if tc.arena {
+				room.Perks.SetOwn([]assets.Perk{arenaPerk})
+			}
+			alice := newTestCI("alice", "Alice")
+			bob := newTestCI("bob", "Bob")
+			wolf := newTestMob("wolf", nil)
+			for _, a := range []*ActorInstance{&alice.ActorInstance, &bob.ActorInstance, &wolf.ActorInstance} {
+				a.room = room
+			}
+			alice.Character.Get().PvP = tc.alicePvP
+			bob.Character.Get().PvP = tc.bobPvP
+			wolf.SetFollowing(alice)
+
+			var attacker, target Actor = alice, bob
+			switch {
+			case tc.petFight:
+				attacker = wolf
+			case tc.ownPet:
+				target = wolf
+			case tc.mob:
+				attacker = newTestMob("rat", nil)
+			}
+
+			if err := CheckPvP(attacker, target); !errors.Is(err, tc.expErr) {
+				t.Errorf("CheckPvP() = %v, want %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_SetPvP(t *testing.T) {
+	tests := map[string]struct {
+		on       bool
+		toggled  time.Duration // how long ago the flag last changed; zero for never
+		fighting bool
+		expErr   error
+		want     bool
+	}{
+		"turn on": {
+			on:   true,
+			want: true,
+		},
+		"turn off": {
+			toggled: -time.Hour,
+		},
+		"too soon": {
+			on:      true,
+			toggled: -time.Minute,
+			expErr:  ErrPvPCooldown,
+		},
+		"off mid-fight": {
+			toggled:  -time.Hour,
+			fighting: true,
+			expErr:   ErrPvPInCombat,
+			want:     true,
+		},
+		"on mid-fight": {
+			on:       true,
+			fighting: true,
+			want:     true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci := newTestCI("bob", "Bob")
+			c := ci.Character.Get()
+			c.PvP = !tc.on
+			if tc.toggled != 0 {
+				c.PvPToggledAt = time.Now().Add(tc.toggled)
+			}
+			if tc.fighting {
+				mob := newTestMob("rat", nil)
+				ci.EnsureThreat(mob.Id(), mob)
+			}
+
+			if err := ci.SetPvP(tc.on); !errors.Is(err, tc.expErr) {
+				t.Errorf("SetPvP() = %v, want %v", err, tc.expErr)
+			}
+			if got := ci.PvP(); got != tc.want {
+				t.Errorf("PvP() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_recordPvPKill(t *testing.T) {
+	tests := map[string]struct {
+		killerLevel int
+		victimLevel int
+		earlier     time.Duration // how long ago the killer last killed the victim; zero for never
+		want        bool
+	}{
+		"counts":               {killerLevel: 10, victimLevel: 8, want: true},
+		"victim within gap":    {killerLevel: 10, victimLevel: 5, want: true},
+		"victim far below":     {killerLevel: 10, victimLevel: 4},
+		"same victim again":    {killerLevel: 10, victimLevel: 10, earlier: -time.Minute},
+		"same victim long ago": {killerLevel: 10, victimLevel: 10, earlier: -2 * time.Hour, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			alice := newTestCI("alice", "Alice")
+			alice.level = tc.killerLevel
+			bob := newTestCI("bob", "Bob")
+			bob.level = tc.victimLevel
+			if tc.earlier != 0 {
+				alice.pvpVictims = map[string]time.Time{"bob": time.Now().Add(tc.earlier)}
+			}
+
+			if got := alice.recordPvPKill(bob); got != tc.want {
+				t.Errorf("recordPvPKill() = %v, want %v", got, tc.want)
+			}
+			wantKills := 0
+			if tc.want {
+				wantKills = 1
+			}
+			if kills, _ := alice.PvPRecord(); kills != wantKills {
+				t.Errorf("kills = %d, want %d", kills, wantKills)
+			}
+		})
+	}
+}
+
+func TestProcessDeath_player(t *testing.T) {
+	tests := map[string]struct {
+		arena      bool
+		wantKicked bool
+		wantKills  int
+		wantDeaths int
+		wantMsg    string // substring of Alice's queued messages
+	}{
+		"killed outside an arena": {
+			wantKicked: true,
+			wantKills:  1,
+			wantDeaths: 1,
+			wantMsg:    "You have killed Bob. Player kills: 1.",
+		},
+		"defeated in an arena": {
+			arena:   true,
+			wantMsg: "Bob is defeated!",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, _, room := newTestWorld()
+			if tc.arena {
+				room.Perks.SetOwn([]assets.Perk{arenaPerk})
+			}
+			alice, _ := NewCharacterInstance(storage.NewResolvedSmartIdentifier("alice", &assets.Character{Name: "Alice"}), make(chan []byte, 4), room)
+			bob, _ := NewCharacterInstance(storage.NewResolvedSmartIdentifier("bob", &assets.Character{Name: "Bob"}), make(chan []byte, 4), room)
+			for _, ci := range []*CharacterInstance{alice, bob} {
+				ci.Character.Get().PvP = true
+				ci.PerkCache = *NewPerkCache([]assets.Perk{testHPPerk}, nil)
+				ci.initResources()
+				if err := w.AddPlayer(ci); err != nil {
+					t.Fatalf("AddPlayer: %v", err)
+				}
+			}
+			alice.EnsureThreat(bob.Id(), bob)
+			bob.EnsureThreat(alice.Id(), alice)
+			bob.setResourceCurrent(assets.ResourceHp, 0)
+
+			alice.sweepDeadEnemies(context.Background())
+
+			select {
+			case <-bob.Done():
+				if !tc.wantKicked {
+					t.Error("Bob was kicked")
+				}
+			default:
+				if tc.wantKicked {
+					t.Error("Bob was not kicked")
+				}
+			}
+			if !tc.wantKicked {
+				if !bob.IsAlive() || bob.IsInCombat() {
+					t.Errorf("Bob alive = %v, in combat = %v; want restored and out of the fight", bob.IsAlive(), bob.IsInCombat())
+				}
+			}
+			if kills, _ := alice.PvPRecord(); kills != tc.wantKills {
+				t.Errorf("Alice kills = %d, want %d", kills, tc.wantKills)
+			}
+			if _, deaths := bob.PvPRecord(); deaths != tc.wantDeaths {
+				t.Errorf("Bob deaths = %d, want %d", deaths, tc.wantDeaths)
+			}
+			if xp := alice.Character.Get().Experience; xp != 0 {
+				t.Errorf("Alice XP = %d, want 0", xp)
+			}
+			if got := strings.Join(alice.tickMsgBuf, "\n"); !strings.Contains(got, tc.wantMsg) {
+				t.Errorf("Alice messages = %q, want %q", got, tc.wantMsg)
+			}
+		})
+	}
+}
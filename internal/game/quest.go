@@ -0,0 +1,428 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+var (
+	// ErrQuestStarted is returned when starting a quest the character has
+	// already started or completed.
+	ErrQuestStarted = errors.New("quest already started")
+	// ErrQuestUnavailable is returned when starting a quest whose
+	// prerequisites the character doesn't meet.
+	ErrQuestUnavailable = errors.New("quest prerequisites not met")
+	// ErrQuestNotActive is returned for a quest the character isn't on.
+	ErrQuestNotActive = errors.New("quest not active")
+	// ErrQuestUnfinished is returned when handing in a quest with objectives
+	// left to do.
+	ErrQuestUnfinished = errors.New("quest objectives not done")
+)
+
+// QuestStatus is where a character stands on one quest, as their journal
+// shows it.
+type QuestStatus struct {
+	Id         string
+	Quest      *assets.Quest
+	State      string // active, ready or completed
+	Objectives []ObjectiveStatus
+}
+
+// ObjectiveStatus is how far along one quest objective is.
+type ObjectiveStatus struct {
+	Text string
+	Have int
+	Need int
+}
+
+// Done reports whether the objective has been met.
+func (o ObjectiveStatus) Done() bool {
+	return o.Have >= o.Need
+}
+
+// questEvent is something the character did that quest objectives may count.
+type questEvent struct {
+	kind string // an assets.QuestObjective* type
+	id   string // the mobile killed, object collected or delivered, room visited or ability used
+	to   string // the mobile an object was delivered to
+}
+
+func (e questEvent) matches(o *assets.QuestObjective) bool {
+	if o.Type != e.kind {
+		return false
+	}
+	switch o.Type {
+	case assets.QuestObjectiveKill:
+		return o.Mobile.Id() == e.id
+	case assets.QuestObjectiveCollect:
+		return o.Object.Id() == e.id
+	case assets.QuestObjectiveDeliver:
+		return o.Object.Id() == e.id && o.Mobile.Id() == e.to
+	case assets.QuestObjectiveVisit:
+		return o.Room.Id() == e.id
+	case assets.QuestObjectiveUse:
+		return o.Ability.Id() == e.id
+	}
+	return false
+}
+
+// Quests returns the character's quest journal: every quest they have
+// started, in the order they started them.
+func (ci *CharacterInstance) Quests() []QuestStatus {
+	carried := ci.carried()
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+
+	var out []QuestStatus
+	for i := range ci.Character.Get().Quests {
+		qp := &ci.Character.Get().Quests[i]
+		q := qp.Quest.Get()
+		if q == nil {
+			continue
+		}
+		st := QuestStatus{Id: qp.Quest.Id(), Quest: q, State: qp.State}
+		if qp.State == assets.QuestStateActive && questDone(qp, carried) {
+			st.State = assets.QuestStateReady
+		}
+		for j := range q.Objectives {
+			o := &q.Objectives[j]
+			need := o.Needed()
+			have := min(objectiveHave(qp, j, carried), need)
+			if qp.State == assets.QuestStateCompleted {
+				have = need
+			}
+			st.Objectives = append(st.Objectives, ObjectiveStatus{Text: objectiveText(o), Have: have, Need: need})
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// QuestState returns the saved state of a quest: none, active or completed.
+func (ci *CharacterInstance) QuestState(questId string) string {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	if qp := ci.questProgress(questId); qp != nil {
+		return qp.State
+	}
+	return assets.QuestStateNone
+}
+
+// InQuestState reports whether the character's standing on q is state. On
+// top of the saved states, a quest not started is available when its
+// prerequisites are met, and an active quest is ready once its objectives
+// are done.
+func (ci *CharacterInstance) InQuestState(q storage.SmartIdentifier[*assets.Quest], state string) bool {
+	switch state {
+	case assets.QuestStateAvailable:
+		return ci.QuestState(q.Id()) == assets.QuestStateNone && ci.meetsPrerequisites(q.Get())
+	case assets.QuestStateReady:
+		carried := ci.carried()
+		ci.mu.RLock()
+		defer ci.mu.RUnlock()
+		qp := ci.questProgress(q.Id())
+		return qp != nil && qp.State == assets.QuestStateActive && questDone(qp, carried)
+	default:
+		return ci.QuestState(q.Id()) == state
+	}
+}
+
+// StartQuest puts q in the character's journal.
+func (ci *CharacterInstance) StartQuest(q storage.SmartIdentifier[*assets.Quest]) error {
+	if q.Get() == nil {
+		return ErrQuestUnavailable
+	}
+	if ci.QuestState(q.Id()) != assets.QuestStateNone {
+		return ErrQuestStarted
+	}
+	if !ci.meetsPrerequisites(q.Get()) {
+		return ErrQuestUnavailable
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if ci.questProgress(q.Id()) != nil {
+		return ErrQuestStarted
+	}
+	c := ci.Character.Get()
+	c.Quests = append(c.Quests, assets.QuestProgress{Quest: q, State: assets.QuestStateActive})
+	return nil
+}
+
+// AbandonQuest drops an active quest and all progress on it. It can be
+// started again afresh.
+func (ci *CharacterInstance) AbandonQuest(questId string) error {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	c := ci.Character.Get()
+	i := slices.IndexFunc(c.Quests, func(qp assets.QuestProgress) bool {
+		return qp.Quest.Id() == questId && qp.State == assets.QuestStateActive
+	})
+	if i < 0 {
+		return ErrQuestNotActive
+	}
+	c.Quests = slices.Delete(c.Quests, i, i+1)
+	return nil
+}
+
+// CompleteQuest hands in an active quest whose objectives are done: the
+// objects collected for it are taken and its rewards given.
+func (ci *CharacterInstance) CompleteQuest(questId string) error {
+	return ci.completeQuest(questId, ci.publishText)
+}
+
+// completeQuest is CompleteQuest, telling the character of their rewards
+// through tell.
+func (ci *CharacterInstance) completeQuest(questId string, tell func(string)) error {
+	carried := ci.carried()
+	ci.mu.Lock()
+	qp := ci.questProgress(questId)
+	if qp == nil || qp.State != assets.QuestStateActive || qp.Quest.Get() == nil {
+		ci.mu.Unlock()
+		return ErrQuestNotActive
+	}
+	if !questDone(qp, carried) {
+		ci.mu.Unlock()
+		return ErrQuestUnfinished
+	}
+	qp.State = assets.QuestStateCompleted
+	qp.Counts = nil
+	q := qp.Quest.Get()
+	ci.mu.Unlock()
+
+	for i := range q.Objectives {
+		if o := &q.Objectives[i]; o.Type == assets.QuestObjectiveCollect {
+			ci.takeObjects(o.Object.Id(), o.Needed())
+		}
+	}
+	ci.rewardQuest(q, tell)
+	return nil
+}
+
+// QuestCollected counts an object the character just picked up toward their
+// quests.
+func (ci *CharacterInstance) QuestCollected(oi *ObjectInstance) {
+	ci.advanceQuests(questEvent{kind: assets.QuestObjectiveCollect, id: oi.Object.Id()}, ci.publishText)
+}
+
+// QuestDelivered counts an object the character just gave mi toward their
+// quests.
+func (ci *CharacterInstance) QuestDelivered(mi *MobileInstance, oi *ObjectInstance) {
+	ci.advanceQuests(questEvent{kind: assets.QuestObjectiveDeliver, id: oi.Object.Id(), to: mi.Mobile.Id()}, ci.publishText)
+}
+
+// QuestUsedAbility counts a use of an ability toward the character's quests.
+func (ci *CharacterInstance) QuestUsedAbility(abilityId string) {
+	ci.advanceQuests(questEvent{kind: assets.QuestObjectiveUse, id: abilityId}, ci.publishText)
+}
+
+// advanceQuests counts ev toward the character's active quests, tells them
+// through tell how each has moved on, and completes any quest that needs no
+// handing in once its last objective is done.
+func (ci *CharacterInstance) advanceQuests(ev questEvent, tell func(string)) {
+	carried := ci.carried()
+	var msgs, finished []string
+
+	ci.mu.Lock()
+	c := ci.Character.Get()
+	for i := range c.Quests {
+		qp := &c.Quests[i]
+		q := qp.Quest.Get()
+		if q == nil || qp.State != assets.QuestStateActive {
+			continue
+		}
+		progressed := false
+		for j := range q.Objectives {
+			o := &q.Objectives[j]
+			need := o.Needed()
+			have := objectiveHave(qp, j, carried)
+			if ev.matches(o) {
+				switch {
+				case o.Type == assets.QuestObjectiveCollect:
+					// What's carried already includes the new arrival.
+					if have <= need {
+						msgs = append(msgs, fmt.Sprintf("%s: %s (%d/%d)", q.Name, objectiveText(o), have, need))
+						progressed = true
+					}
+				case have < need:
+					if len(qp.Counts) < len(q.Objectives) {
+						qp.Counts = append(qp.Counts, make([]int, len(q.Objectives)-len(qp.Counts))...)
+					}
+					qp.Counts[j]++
+					have++
+					msgs = append(msgs, fmt.Sprintf("%s: %s (%d/%d)", q.Name, objectiveText(o), have, need))
+					progressed = true
+				}
+			}
+			if q.Ordered && have < need {
+				break
+			}
+		}
+		if !progressed || !questDone(qp, carried) {
+			continue
+		}
+		if q.TurnIn {
+			msgs = append(msgs, fmt.Sprintf("%s: every task is done. Return to claim your reward.", q.Name))
+		} else {
+			finished = append(finished, qp.Quest.Id())
+		}
+	}
+	ci.mu.Unlock()
+
+	if len(msgs) > 0 {
+		tell(strings.Join(msgs, "\n"))
+	}
+	for _, id := range finished {
+		if err := ci.completeQuest(id, tell); err != nil {
+			slog.Warn("completing quest failed", "character", ci.Id(), "quest", id, "error", err)
+		}
+	}
+}
+
+// rewardQuest gives the character a completed quest's rewards and tells
+// them what they got.
+func (ci *CharacterInstance) rewardQuest(q *assets.Quest, tell func(string)) {
+	lines := []string{fmt.Sprintf("You have completed %s!", q.Name)}
+	r := &q.Rewards
+	if r.XP > 0 {
+		lines = append(lines, fmt.Sprintf("You receive %d experience points.", r.XP))
+		if ci.GainXP(r.XP) {
+			lines = append(lines, "You feel ready to advance to the next level!")
+		}
+	}
+	if r.Gold > 0 {
+		ci.AddGold(r.Gold)
+		lines = append(lines, fmt.Sprintf("You receive %d gold.", r.Gold))
+	}
+	for _, spawn := range r.Objects {
+		oi, err := SpawnObject(spawn)
+		if err != nil {
+			slog.Warn("quest reward failed", "character", ci.Id(), "object", spawn.Object.Id(), "error", err)
+			continue
+		}
+		ci.Inventory().AddObj(oi)
+		lines = append(lines, fmt.Sprintf("You receive %s.", oi.ShortDesc()))
+	}
+	if len(r.Perks) > 0 {
+		ci.Learn(r.Perks...)
+	}
+	tell(strings.Join(lines, "\n"))
+}
+
+// publishText sends msg straight to the character.
+func (ci *CharacterInstance) publishText(msg string) {
+	ci.Publish([]byte(msg), nil)
+}
+
+// meetsPrerequisites reports whether the character may start q.
+func (ci *CharacterInstance) meetsPrerequisites(q *assets.Quest) bool {
+	if q == nil || ci.Level() < q.MinLevel {
+		return false
+	}
+	for _, req := range q.Requires {
+		if ci.QuestState(req.Id()) != assets.QuestStateCompleted {
+			return false
+		}
+	}
+	for i := range q.Standings {
+		if !ci.MeetsStanding(&q.Standings[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// questProgress returns the character's progress on a quest, or nil if they
+// have never started it. Caller must hold ci.mu.
+func (ci *CharacterInstance) questProgress(questId string) *assets.QuestProgress {
+	qs := ci.Character.Get().Quests
+	for i := range qs {
+		if qs[i].Quest.Id() == questId {
+			return &qs[i]
+		}
+	}
+	return nil
+}
+
+// carried counts the objects in the character's inventory by definition ID.
+func (ci *CharacterInstance) carried() map[string]int {
+	counts := make(map[string]int)
+	ci.Inventory().ForEachObj(func(_ string, oi *ObjectInstance) {
+		counts[oi.Object.Id()]++
+	})
+	return counts
+}
+
+// takeObjects removes up to n objects of the given definition from the
+// character's inventory.
+func (ci *CharacterInstance) takeObjects(defId string, n int) {
+	inv := ci.Inventory()
+	for range n {
+		oi := inv.FindObjByDef(defId)
+		if oi == nil {
+			return
+		}
+		inv.RemoveObj(oi.InstanceId)
+	}
+}
+
+// objectiveHave returns how many times the i-th objective has been done.
+// It may be more than is needed.
+func objectiveHave(qp *assets.QuestProgress, i int, carried map[string]int) int {
+	o := &qp.Quest.Get().Objectives[i]
+	if o.Type == assets.QuestObjectiveCollect {
+		return carried[o.Object.Id()]
+	}
+	if i < len(qp.Counts) {
+		return qp.Counts[i]
+	}
+	return 0
+}
+
+// questDone reports whether every objective of the quest has been met.
+func questDone(qp *assets.QuestProgress, carried map[string]int) bool {
+	q := qp.Quest.Get()
+	for i := range q.Objectives {
+		if objectiveHave(qp, i, carried) < q.Objectives[i].Needed() {
+			return false
+		}
+	}
+	return true
+}
+
+// objectiveText describes an objective for the quest journal.
+func objectiveText(o *assets.QuestObjective) string {
+	if o.Text != "" {
+		return o.Text
+	}
+	mob := o.Mobile.Id()
+	if m := o.Mobile.Get(); m != nil {
+		mob = m.ShortDesc
+	}
+	obj := o.Object.Id()
+	if d := o.Object.Get(); d != nil {
+		obj = d.ShortDesc
+	}
+	switch o.Type {
+	case assets.QuestObjectiveKill:
+		return "Kill " + mob
+	case assets.QuestObjectiveCollect:
+		return "Collect " + obj
+	case assets.QuestObjectiveDeliver:
+		return fmt.Sprintf("Deliver %s to %s", obj, mob)
+	case assets.QuestObjectiveVisit:
+		if r := o.Room.Get(); r != nil {
+			return "Visit " + r.Name
+		}
+		return "Visit " + o.Room.Id()
+	case assets.QuestObjectiveUse:
+		return "Use " + o.Ability.Id()
+	}
+	return o.Type
+}
@@ -0,0 +1,315 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// testQuest wraps q in a resolved identifier named id.
+func testQuest(id string, q *assets.Quest) storage.SmartIdentifier[*assets.Quest] {
+	if q.Name == "" {
+		q.Name = "The " + id
+	}
+	return storage.NewResolvedSmartIdentifier(id, q)
+}
+
+func TestCharacterInstance_advanceQuests(t *testing.T) {
+	guard := storage.NewSmartIdentifier[*assets.Mobile]("guard")
+	pelt := storage.NewSmartIdentifier[*assets.Object]("pelt")
+	hall := storage.NewSmartIdentifier[*assets.Room]("hall")
+	killGuards := assets.QuestObjective{Type: assets.QuestObjectiveKill, Mobile: guard, Count: 2}
+	visitHall := assets.QuestObjective{Type: assets.QuestObjectiveVisit, Room: hall}
+
+	tests := map[string]struct {
+		quest     assets.Quest
+		pelts     int // pelts picked up, each reported as collected
+		events    []questEvent
+		wantState string
+		wantHave  []int
+		wantLast  string // substring of the last message
+	}{
+		"kills count": {
+			quest:     assets.Quest{Objectives: []assets.QuestObjective{killGuards, visitHall}},
+			events:    []questEvent{{kind: assets.QuestObjectiveKill, id: "guard"}},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{1, 0},
+			wantLast:  "The cull: Kill guard (1/2)",
+		},
+		"other kills don't": {
+			quest:     assets.Quest{Objectives: []assets.QuestObjective{killGuards}},
+			events:    []questEvent{{kind: assets.QuestObjectiveKill, id: "rat"}},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{0},
+		},
+		"parallel objectives in any order": {
+			quest:     assets.Quest{Objectives: []assets.QuestObjective{killGuards, visitHall}},
+			events:    []questEvent{{kind: assets.QuestObjectiveVisit, id: "hall"}},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{0, 1},
+			wantLast:  "The cull: Visit hall (1/1)",
+		},
+		"ordered objectives wait their turn": {
+			quest:     assets.Quest{Ordered: true, Objectives: []assets.QuestObjective{killGuards, visitHall}},
+			events:    []questEvent{{kind: assets.QuestObjectiveVisit, id: "hall"}},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{0, 0},
+		},
+		"last objective completes the quest": {
+			quest: assets.Quest{Ordered: true, Objectives: []assets.QuestObjective{killGuards, visitHall}, Rewards: assets.QuestRewards{XP: 50}},
+			events: []questEvent{
+				{kind: assets.QuestObjectiveKill, id: "guard"},
+				{kind: assets.QuestObjectiveKill, id: "guard"},
+				{kind: assets.QuestObjectiveVisit, id: "hall"},
+			},
+			wantState: assets.QuestStateCompleted,
+			wantHave:  []int{2, 1},
+			wantLast:  "You receive 50 experience points.",
+		},
+		"turn-in quests wait": {
+			quest:     assets.Quest{TurnIn: true, Objectives: []assets.QuestObjective{visitHall}},
+			events:    []questEvent{{kind: assets.QuestObjectiveVisit, id: "hall"}},
+			wantState: assets.QuestStateReady,
+			wantHave:  []int{1},
+			wantLast:  "Return to claim your reward.",
+		},
+		"collecting counts what's carried": {
+			quest:     assets.Quest{TurnIn: true, Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveCollect, Object: pelt, Count: 3}}},
+			pelts:     2,
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{2},
+			wantLast:  "Collect pelt (2/3)",
+		},
+		"deliveries must go to the right mobile": {
+			quest: assets.Quest{Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveDeliver, Object: pelt, Mobile: guard}}},
+			events: []questEvent{
+				{kind: assets.QuestObjectiveDeliver, id: "pelt", to: "tanner"},
+			},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{0},
+		},
+		"ability uses count": {
+			quest:     assets.Quest{Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveUse, Ability: storage.NewSmartIdentifier[*assets.Ability]("kick"), Count: 2}}},
+			events:    []questEvent{{kind: assets.QuestObjectiveUse, id: "kick"}},
+			wantState: assets.QuestStateActive,
+			wantHave:  []int{1},
+			wantLast:  "The cull: Use kick (1/2)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(nil, nil)
+			if err := s.player.StartQuest(testQuest("cull", &tc.quest)); err != nil {
+				t.Fatalf("StartQuest: %v", err)
+			}
+
+			for range tc.pelts {
+				oi := newTestObj("pelt")
+				s.player.inventory.AddObj(oi)
+				s.player.QuestCollected(oi)
+			}
+			for _, ev := range tc.events {
+				s.player.advanceQuests(ev, s.player.publishText)
+			}
+
+			quests := s.player.Quests()
+			if len(quests) != 1 {
+				t.Fatalf("Quests() = %d entries, want 1", len(quests))
+			}
+			if quests[0].State != tc.wantState {
+				t.Errorf("State = %q, want %q", quests[0].State, tc.wantState)
+			}
+			for i, o := range quests[0].Objectives {
+				if o.Have != tc.wantHave[i] {
+					t.Errorf("objective %d Have = %d, want %d", i, o.Have, tc.wantHave[i])
+				}
+			}
+			msgs := s.drain()
+			if tc.wantLast == "" && len(msgs) > 0 {
+				t.Errorf("messages = %q, want none", msgs)
+			}
+			if tc.wantLast != "" && (len(msgs) == 0 || !strings.Contains(msgs[len(msgs)-1], tc.wantLast)) {
+				t.Errorf("messages = %q, want last to contain %q", msgs, tc.wantLast)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_StartQuest(t *testing.T) {
+	first := testQuest("first", &assets.Quest{Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveVisit}}})
+	town := storage.NewResolvedSmartIdentifier("town", &assets.Faction{Name: "the town"})
+	friendly := []assets.StandingRequirement{{Faction: town, MinStanding: assets.StandingFriendly}}
+	tests := map[string]struct {
+		quest     *assets.Quest
+		level     int
+		standing  int  // standing with the town
+		done      bool // first already completed
+		started   bool // the quest already started
+		expErr    error
+		available bool
+	}{
+		"no prerequisites": {
+			quest:     &assets.Quest{},
+			available: true,
+		},
+		"level too low": {
+			quest:  &assets.Quest{MinLevel: 5},
+			level:  4,
+			expErr: ErrQuestUnavailable,
+		},
+		"level reached": {
+			quest:     &assets.Quest{MinLevel: 5},
+			level:     5,
+			available: true,
+		},
+		"required quest not done": {
+			quest:  &assets.Quest{Requires: []storage.SmartIdentifier[*assets.Quest]{first}},
+			expErr: ErrQuestUnavailable,
+		},
+		"required quest done": {
+			quest:     &assets.Quest{Requires: []storage.SmartIdentifier[*assets.Quest]{first}},
+			done:      true,
+			available: true,
+		},
+		"standing too low": {
+			quest:  &assets.Quest{Standings: friendly},
+			expErr: ErrQuestUnavailable,
+		},
+		"standing reached": {
+			quest:     &assets.Quest{Standings: friendly},
+			standing:  100,
+			available: true,
+		},
+		"already started": {
+			quest:   &assets.Quest{},
+			started: true,
+			expErr:  ErrQuestStarted,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci := newTestCI("bob", "Bob")
+			ci.inventory = NewInventory()
+			ci.level = tc.level
+			ci.AdjustStanding(town, tc.standing)
+			if tc.done {
+				ci.Character.Get().Quests = []assets.QuestProgress{{Quest: first, State: assets.QuestStateCompleted}}
+			}
+			q := testQuest("second", tc.quest)
+			if tc.started {
+				ci.StartQuest(q)
+			}
+
+			if got := ci.InQuestState(q, assets.QuestStateAvailable); got != tc.available {
+				t.Errorf("available = %v, want %v", got, tc.available)
+			}
+			if err := ci.StartQuest(q); !errors.Is(err, tc.expErr) {
+				t.Errorf("StartQuest() error = %v, want %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_CompleteQuest(t *testing.T) {
+	pelt := storage.NewSmartIdentifier[*assets.Object]("pelt")
+	cloak := storage.NewResolvedSmartIdentifier("cloak", &assets.Object{ShortDesc: "a fur cloak"})
+	q := testQuest("cull", &assets.Quest{
+		Name:       "The Cull",
+		TurnIn:     true,
+		Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveCollect, Object: pelt, Count: 2}},
+		Rewards: assets.QuestRewards{
+			XP:      40,
+			Gold:    7,
+			Objects: []assets.ObjectSpawn{{Object: cloak}},
+			Perks:   []assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantUnlockAbility, Arg: "skin"}},
+		},
+	})
+	tests := map[string]struct {
+		pelts  int
+		expErr error
+	}{
+		"objectives done": {pelts: 3},
+		"objectives left": {pelts: 1, expErr: ErrQuestUnfinished},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(nil, nil)
+			s.player.StartQuest(q)
+			for range tc.pelts {
+				s.player.inventory.AddObj(newTestObj("pelt"))
+			}
+
+			err := s.player.CompleteQuest("cull")
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("CompleteQuest() error = %v, want %v", err, tc.expErr)
+			}
+			if err != nil {
+				if got := s.player.QuestState("cull"); got != assets.QuestStateActive {
+					t.Errorf("QuestState() = %q, want active", got)
+				}
+				return
+			}
+
+			c := s.player.Character.Get()
+			if c.Experience != 40 || c.Gold != 7 {
+				t.Errorf("experience = %d, gold = %d, want 40 and 7", c.Experience, c.Gold)
+			}
+			if got := s.player.carried(); got["pelt"] != 1 || got["cloak"] != 1 {
+				t.Errorf("carried = %v, want one pelt and the cloak", got)
+			}
+			if !s.player.HasGrant(assets.PerkGrantUnlockAbility, "skin") {
+				t.Error("skin not granted")
+			}
+			if got := s.player.QuestState("cull"); got != assets.QuestStateCompleted {
+				t.Errorf("QuestState() = %q, want completed", got)
+			}
+			msgs := s.drain()
+			if len(msgs) == 0 || !strings.HasPrefix(msgs[len(msgs)-1], "You have completed The Cull!") {
+				t.Errorf("messages = %q, want the completion", msgs)
+			}
+			if err := s.player.CompleteQuest("cull"); !errors.Is(err, ErrQuestNotActive) {
+				t.Errorf("second CompleteQuest() error = %v, want %v", err, ErrQuestNotActive)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_AbandonQuest(t *testing.T) {
+	q := testQuest("cull", &assets.Quest{Objectives: []assets.QuestObjective{{Type: assets.QuestObjectiveKill, Count: 2}}})
+	s := newScriptScene(nil, nil)
+	s.player.StartQuest(q)
+	s.player.Character.Get().Quests[0].Counts = []int{1}
+
+	if err := s.player.AbandonQuest("cull"); err != nil {
+		t.Fatalf("AbandonQuest() error = %v", err)
+	}
+	if got := s.player.QuestState("cull"); got != assets.QuestStateNone {
+		t.Errorf("QuestState() = %q, want none", got)
+	}
+	if err := s.player.AbandonQuest("cull"); !errors.Is(err, ErrQuestNotActive) {
+		t.Errorf("second AbandonQuest() error = %v, want %v", err, ErrQuestNotActive)
+	}
+	if err := s.player.StartQuest(q); err != nil {
+		t.Errorf("StartQuest() after abandoning = %v", err)
+	}
+	if have := s.player.Quests()[0].Objectives[0].Have; have != 0 {
+		t.Errorf("restarted progress = %d, want 0", have)
+	}
+}
+
+func TestFireArrival_questVisit(t *testing.T) {
+	s := newScriptScene(nil, nil)
+	s.player.StartQuest(testQuest("tour", &assets.Quest{Objectives: []assets.QuestObjective{
+		{Type: assets.QuestObjectiveVisit, Room: storage.NewSmartIdentifier[*assets.Room]("hall")},
+	}}))
+
+	FireArrival(context.Background(), s.player, s.room)
+
+	if got := s.player.QuestState("tour"); got != assets.QuestStateCompleted {
+		t.Errorf("QuestState() = %q, want completed", got)
+	}
+}
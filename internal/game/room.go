@@ -2,8 +2,8 @@ package game
 
 import (
 	"fmt"
-	"log/slog"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -17,10 +17,12 @@ import (
 // ResolvedExit holds a resolved pointer to the destination room along with the
 // original exit definition and runtime closure state.
 type ResolvedExit struct {
-	Dest   *RoomInstance // nil until resolved during world init
-	Exit   assets.Exit   // original definition (closure, description, etc.)
-	closed bool
-	locked bool
+	Dest     *RoomInstance // nil until resolved during world init
+	Exit     assets.Exit   // original definition (closure, description, etc.)
+	closed   bool
+	locked   bool
+	trap     TrapState
+	revealed bool // true once a hidden exit is revealed to everyone
 }
 
 // IsClosed returns whether the exit is closed.
@@ -35,14 +37,29 @@ func (re *ResolvedExit) IsLocked() bool { return re.locked }
 // SetLocked sets the locked state.
 func (re *ResolvedExit) SetLocked(v bool) { re.locked = v }
 
+// Trap returns the runtime state of the exit's trap.
+func (re *ResolvedExit) Trap() *TrapState { return &re.trap }
+
 // OtherSide finds the reverse exit on the destination room that leads back
 // to source. Returns the direction and resolved exit, or ("", nil) if not found.
 func (re *ResolvedExit) OtherSide(source *RoomInstance) (string, *ResolvedExit) {
+	return re.reverse(source, func(other *ResolvedExit) bool { return other.Exit.Closure != nil })
+}
+
+// hiddenOtherSide finds the hidden exit on the destination room that leads
+// back to source, so secret passages are revealed from both ends at once.
+func (re *ResolvedExit) hiddenOtherSide(source *RoomInstance) (string, *ResolvedExit) {
+	return re.reverse(source, func(other *ResolvedExit) bool { return other.Exit.Hidden != nil })
+}
+
+// reverse finds an exit on the destination room accepted by match that
+// leads back to source.
+func (re *ResolvedExit) reverse(source *RoomInstance, match func(*ResolvedExit) bool) (string, *ResolvedExit) {
 	if re.Dest == nil {
 		return "", nil
 	}
 	for dir, other := range re.Dest.exits {
-		if other.Exit.Closure == nil {
+		if !match(other) {
 			continue
 		}
 		if other.Dest == source {
@@ -67,6 +84,18 @@ type RoomInstance struct {
 	mobiles map[string]*MobileInstance
 	objects *Inventory
 	players map[string]*CharacterInstance
+	lit     bool // true while the "light" perk source is attached
+
+	// Spawn slots, one per spawn entry in the room definition, remember what
+	// each entry last spawned so resets top up rather than duplicate. Only
+	// touched from the world tick.
+	mobSlots   []mobSlot
+	timedSlots []*MobileInstance
+	objSlots   []*ObjectInstance
+	objLoot    [][]*ObjectInstance // loot last rolled into each object spawn
+	lootSlots  [][]*ObjectInstance // loot last rolled onto the floor
+
+	triggers triggerState
 
 	Perks *PerkCache
 }
@@ -77,25 +106,39 @@ func NewRoomInstance(room storage.SmartIdentifier[*assets.Room]) (*RoomInstance,
 	if room.Get() == nil {
 		return nil, fmt.Errorf("unable to create instance from unresolved room %q", room.Id())
 	}
+	def := room.Get()
 	ri := &RoomInstance{
-		Room:    room,
-		exits:   make(map[string]*ResolvedExit),
-		mobiles: make(map[string]*MobileInstance),
-		objects: NewInventory(),
-		players: make(map[string]*CharacterInstance),
-		Perks:   NewPerkCache(room.Get().Perks, nil),
-	}
-	for dir, exit := range room.Get().Exits {
+		Room:       room,
+		exits:      make(map[string]*ResolvedExit),
+		mobiles:    make(map[string]*MobileInstance),
+		objects:    NewInventory(),
+		players:    make(map[string]*CharacterInstance),
+		mobSlots:   make([]mobSlot, len(def.MobSpawns)),
+		timedSlots: make([]*MobileInstance, len(def.TimedSpawns)),
+		objSlots:   make([]*ObjectInstance, len(def.ObjSpawns)),
+		objLoot:    make([][]*ObjectInstance, len(def.ObjSpawns)),
+		lootSlots:  make([][]*ObjectInstance, len(def.Loot)),
+		Perks:      NewPerkCache(def.Perks, nil),
+	}
+	for dir, exit := range def.Exits {
 		ri.exits[dir] = &ResolvedExit{Exit: exit}
 	}
 	ri.initExitClosures()
 	return ri, nil
 }
 
-// Tick advances one game tick for the room: expires timed perks and object decay.
+// Tick advances one game tick for the room: expires timed perks and object
+// decay, burns light sources on the floor, refreshes room lighting, and
+// brings back killed mobs whose respawn timer has run out.
+// Decayed containers such as corpses spill their contents onto the floor.
 func (ri *RoomInstance) Tick() {
 	ri.Perks.Tick()
-	ri.objects.Tick()
+	ri.respawnTick()
+	for _, ev := range ri.objects.Tick() {
+		ev.Room = ri
+		emitObjectEvent(ev)
+	}
+	ri.RefreshLight()
 }
 
 // Zone returns the zone this room belongs to.
@@ -119,80 +162,138 @@ func (ri *RoomInstance) Restricts(actor GrantHolder, flag assets.RoomFlag) bool
 	return !actor.HasGrant(assets.PerkGrantIgnoreRestriction, string(flag))
 }
 
-// Reset clears all mobs and objects and respawns them from the room definition.
-// Players are preserved. Exit closure state is restored to definition defaults.
-// Cross-zone door state is also synchronized via resolved exit pointers.
+// IsIndoors reports whether the room is sheltered from the sky.
+func (ri *RoomInstance) IsIndoors() bool {
+	return ri.Perks.HasGrant(string(assets.RoomFlagIndoors), "")
+}
+
+// IsArena reports whether players may always fight each other in the room.
+func (ri *RoomInstance) IsArena() bool {
+	return ri != nil && ri.Perks.HasGrant(string(assets.RoomFlagArena), "")
+}
+
+// TimeOfDay returns the world's current phase of the day, or "" for a room
+// not yet placed in a world.
+func (ri *RoomInstance) TimeOfDay() assets.TimeOfDay {
+	if ri.zone == nil || ri.zone.world == nil {
+		return ""
+	}
+	return ri.zone.world.calendar.TimeOfDay()
+}
+
+// GrantLister can list the args of a grant perk.
+type GrantLister interface {
+	GrantArgs(key string) []string
+}
+
+// MoveCost returns the movement points the actor spends entering this room:
+// the room's sector cost, lowered by any "move_cost" grant that covers the
+// sector. Malformed grant args are ignored.
+func (ri *RoomInstance) MoveCost(actor GrantLister) int {
+	sector := ri.Room.Get().Sector
+	cost := sector.MoveCost()
+	if sector == "" {
+		sector = assets.SectorInside
+	}
+	for _, arg := range actor.GrantArgs(assets.PerkGrantMoveCost) {
+		name, v, ok := strings.Cut(arg, ":")
+		if !ok || (name != string(sector) && name != assets.SectorAll) {
+			continue
+		}
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cost = min(cost, n)
+		}
+	}
+	return cost
+}
+
+// Reset restores the room toward its definition. Spawn entries whose mob
+// or object is gone are topped up within their spawn rules, while anything
+// players dropped stays put. Mobs no spawn entry accounts for are cleared,
+// except companions following a player. Exit closure state is restored to
+// definition defaults, and cross-zone door state is synchronized via
+// resolved exit pointers.
 func (ri *RoomInstance) Reset(cf CommanderFactory) error {
+	return ri.reset(ri.newResetPass(cf), nil)
+}
+
+// reset is Reset within a zone's reset pass, with strays recalled to this
+// room placed back in it first.
+func (ri *RoomInstance) reset(pass *resetPass, recalled []*MobileInstance) error {
 	ri.mu.Lock()
 	ri.initExitClosures()
 
 	// Synchronize the other side of any cross-zone exits.
 	for _, re := range ri.exits {
-		if re.Exit.Closure == nil || !re.Exit.Closure.Closed {
-			continue
-		}
 		if re.Dest == nil || re.Dest.zone == ri.zone {
 			continue // same zone, handled by its own reset
 		}
+		if re.Exit.Hidden != nil {
+			if _, other := re.hiddenOtherSide(ri); other != nil {
+				other.revealed = false
+			}
+		}
+		if re.Exit.Closure == nil || !re.Exit.Closure.Closed {
+			continue
+		}
 		if _, other := re.OtherSide(ri); other != nil {
 			other.closed = other.Exit.Closure.Closed
 			if other.Exit.Closure.Lock != nil {
 				other.locked = other.Exit.Closure.Lock.Locked
 			}
+			other.trap.Reset(other.Exit.Closure)
 		}
 	}
 
-	def := ri.Room.Get()
-
-	// Preserve mobs that are following a player (e.g. summoned companions).
-	kept := make(map[string]*MobileInstance)
 	for id, mi := range ri.mobiles {
-		if f := mi.Following(); f != nil && f.IsCharacter() {
-			kept[id] = mi
+		if f := mi.Following(); mi.home == nil && (f == nil || !f.IsCharacter()) {
+			delete(ri.mobiles, id)
 		}
 	}
-	ri.mobiles = kept
-
-	for _, mob := range def.MobSpawns {
-		mi, err := NewMobileInstance(mob)
-		if err != nil {
-			slog.Error("respawning mob", "mob", mob.Id(), "room", ri.Room.Id(), "error", err)
-			continue
-		}
-		if cf != nil {
-			mi.commander = cf(mi)
-		}
+	for _, mi := range recalled {
 		ri.addMob(mi)
 	}
 	ri.mu.Unlock()
 
-	_ = ri.objects.Drain()
-	for _, spawn := range def.ObjSpawns {
-		oi, err := SpawnObject(spawn)
-		if err != nil {
-			return fmt.Errorf("resetting room %q: %w", ri.Room.Id(), err)
-		}
-		ri.AddObj(oi)
+	for _, mi := range recalled {
+		mi.Hunt(nil)
 	}
+	ri.spawnMobs(pass)
+	if err := ri.spawnObjs(pass); err != nil {
+		return fmt.Errorf("resetting room %q: %w", ri.Room.Id(), err)
+	}
+	ri.spawnLoot(pass)
 	return nil
 }
 
+// Viewer is the subset of Actor needed to describe a room from its point of view.
+type Viewer interface {
+	Name() string
+	GrantHolder
+}
+
 // Describe returns the full room description including objects, mobs, players, and exits.
-// actorName is excluded from the player list.
-func (ri *RoomInstance) Describe(actorName string) string {
+// The viewer is excluded from the player list and only sees hidden exits it knows of.
+func (ri *RoomInstance) Describe(viewer Viewer) string {
+	actorName := viewer.Name()
 	var sb strings.Builder
 	def := ri.Room.Get()
 	sb.WriteString(display.Colorize(display.Color.Yellow, def.Name))
 	sb.WriteString("\n")
-	sb.WriteString(display.Wrap(def.Description))
+	desc := def.Description
+	if timed, ok := def.TimeDescs[ri.TimeOfDay()]; ok {
+		desc = timed
+	}
+	sb.WriteString(display.Wrap(desc))
 	sb.WriteString("\n")
-	sb.WriteString(display.Colorize(display.Color.Cyan, formatExits(ri.exits)))
+	sb.WriteString(display.Colorize(display.Color.Cyan, ri.formatExits(viewer)))
 	sb.WriteString("\n")
 
 	ri.objects.ForEachObj(func(_ string, oi *ObjectInstance) {
+		// A restrung item's long description would still name the original.
 		desc := oi.Object.Get().LongDesc
-		if desc == "" {
-			desc = fmt.Sprintf("%s is here.", oi.Object.Get().ShortDesc)
+		if desc == "" || oi.Name != "" {
+			desc = fmt.Sprintf("%s is here.", display.Capitalize(oi.ShortDesc()))
 		}
 		fmt.Fprintf(&sb, "%s\n", display.Colorize(display.Color.Green, desc))
 	})
@@ -232,6 +333,71 @@ func (ri *RoomInstance) FindExit(name string) (string, *ResolvedExit) {
 	return "", nil
 }
 
+// ForEachExit calls fn for every exit in the room, in direction order.
+func (ri *RoomInstance) ForEachExit(fn func(dir string, re *ResolvedExit)) {
+	dirs := make([]string, 0, len(ri.exits))
+	for dir := range ri.exits {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fn(dir, ri.exits[dir])
+	}
+}
+
+// ExitVisible reports whether viewer can see and use the exit in direction
+// dir. Hidden exits are visible once revealed to the room, or to a viewer
+// holding a reveal_exit grant for them. viewer may be nil.
+func (ri *RoomInstance) ExitVisible(viewer GrantHolder, dir string) bool {
+	re, ok := ri.exits[dir]
+	return ok && ri.exitVisible(viewer, dir, re)
+}
+
+func (ri *RoomInstance) exitVisible(viewer GrantHolder, dir string, re *ResolvedExit) bool {
+	if re.Exit.Hidden == nil || re.revealed {
+		return true
+	}
+	return viewer != nil && viewer.HasGrant(assets.PerkGrantRevealExit, revealExitArg(ri, dir))
+}
+
+// revealExitArg returns the reveal_exit grant arg for an exit.
+func revealExitArg(ri *RoomInstance, dir string) string {
+	return ri.Room.Id() + ":" + dir
+}
+
+// TimedPerkHolder is the subset of Actor needed to hold timed perks.
+type TimedPerkHolder interface {
+	AddTimedPerks(name string, perks []assets.Perk, ticks int)
+}
+
+// RevealExit reveals the hidden exit in direction dir along with the hidden
+// exit leading back from its destination. If the exit has a discovery
+// Duration and a finder is given, only the finder learns of it, for that
+// many ticks; otherwise it is revealed to everyone until the zone resets.
+// Returns false if there is no hidden exit in that direction.
+func (ri *RoomInstance) RevealExit(dir string, finder TimedPerkHolder) bool {
+	re, ok := ri.exits[dir]
+	if !ok || re.Exit.Hidden == nil {
+		return false
+	}
+	backDir, back := re.hiddenOtherSide(ri)
+
+	if dur := re.Exit.Hidden.Duration; dur > 0 && finder != nil {
+		perks := []assets.Perk{{Type: assets.PerkTypeGrant, Key: assets.PerkGrantRevealExit, Arg: revealExitArg(ri, dir)}}
+		if back != nil {
+			perks = append(perks, assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantRevealExit, Arg: revealExitArg(re.Dest, backDir)})
+		}
+		finder.AddTimedPerks("reveal:"+revealExitArg(ri, dir), perks, dur)
+		return true
+	}
+
+	re.revealed = true
+	if back != nil {
+		back.revealed = true
+	}
+	return true
+}
+
 // FindExtraDesc searches the room's extra descriptions and then the extra
 // descriptions on objects in the room for a keyword match (case-insensitive).
 func (ri *RoomInstance) FindExtraDesc(keyword string) *assets.ExtraDesc {
@@ -264,10 +430,13 @@ func (ri *RoomInstance) FindExtraDesc(keyword string) *assets.ExtraDesc {
 	return found
 }
 
-// initExitClosures resets closed/locked state on resolved exits from their definitions.
+// initExitClosures resets closed/locked state, re-arms traps and re-hides
+// secret exits on resolved exits from their definitions.
 // Caller must hold the write lock or call before the instance is shared.
 func (ri *RoomInstance) initExitClosures() {
 	for _, re := range ri.exits {
+		re.trap.Reset(re.Exit.Closure)
+		re.revealed = false
 		if re.Exit.Closure != nil {
 			re.closed = re.Exit.Closure.Closed
 			if re.Exit.Closure.Lock != nil {
@@ -296,6 +465,19 @@ func (ri *RoomInstance) FindMobs(match func(*MobileInstance) bool) []*MobileInst
 	return out
 }
 
+// holds reports whether the actor is currently in the room.
+func (ri *RoomInstance) holds(a Actor) bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	if ci, ok := ri.players[a.Id()]; ok {
+		return Actor(ci) == a
+	}
+	if mi, ok := ri.mobiles[a.Id()]; ok {
+		return Actor(mi) == a
+	}
+	return false
+}
+
 // GetMob returns the MobileInstance with the given instanceId, or nil if not found.
 func (ri *RoomInstance) GetMob(instanceId string) *MobileInstance {
 	ri.mu.RLock()
@@ -435,20 +617,26 @@ func formatFlags(flags []string) string {
 	return s.String()
 }
 
-func formatExits(exits map[string]*ResolvedExit) string {
-	if len(exits) == 0 {
-		return "[Exits: none]"
-	}
-	dirs := make([]string, 0, len(exits))
-	for dir, re := range exits {
+func (ri *RoomInstance) formatExits(viewer GrantHolder) string {
+	dirs := make([]string, 0, len(ri.exits))
+	for dir, re := range ri.exits {
+		if !ri.exitVisible(viewer, dir, re) {
+			continue
+		}
 		label := dir
 		if re.locked {
 			label += " (locked)"
 		} else if re.closed {
 			label += " (closed)"
 		}
+		if re.trap.Armed && re.trap.Found {
+			label += " (trapped)"
+		}
 		dirs = append(dirs, label)
 	}
+	if len(dirs) == 0 {
+		return "[Exits: none]"
+	}
 	sort.Strings(dirs)
 	return fmt.Sprintf("[Exits: %s]", strings.Join(dirs, ", "))
 }
@@ -525,6 +525,7 @@ func TestRoomInstance_Describe(t *testing.T) {
 		addMob     bool
 		addPlayer  bool
 		addExit    bool
+		hidden     bool
 		actorName  string
 		wantInOut  []string
 		wantNotOut []string
@@ -560,6 +561,13 @@ func TestRoomInstance_Describe(t *testing.T) {
 			addExit:   true,
 			wantInOut: []string{"north"},
 		},
+		"hidden exit left out": {
+			roomName:   "Crossroads",
+			addExit:    true,
+			hidden:     true,
+			wantInOut:  []string{"[Exits: none]"},
+			wantNotOut: []string{"north"},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -569,6 +577,11 @@ func TestRoomInstance_Describe(t *testing.T) {
 				exits = map[string]assets.Exit{
 					"north": {Room: storage.NewResolvedSmartIdentifier("dest", &assets.Room{Name: "Dest"})},
 				}
+				if tc.hidden {
+					exit := exits["north"]
+					exit.Hidden = &assets.HiddenExit{}
+					exits["north"] = exit
+				}
 			}
 			ri, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier("r", &assets.Room{Name: tc.roomName, Exits: exits}))
 			if tc.addExit {
@@ -590,7 +603,7 @@ func TestRoomInstance_Describe(t *testing.T) {
 				ri.AddPlayer("watcher", newTestCI("watcher", "Watcher"))
 			}
 
-			out := ri.Describe(tc.actorName)
+			out := ri.Describe(newTestCI("viewer", tc.actorName))
 
 			for _, want := range tc.wantInOut {
 				if !strings.Contains(out, want) {
@@ -658,3 +671,144 @@ func drainOne(ch chan []byte) string {
 		return ""
 	}
 }
+
+func TestRoomInstance_initExitClosuresRearmsTraps(t *testing.T) {
+	tests := map[string]struct {
+		closure   *assets.Closure
+		wantArmed bool
+	}{
+		"trapped door re-arms": {
+			closure:   &assets.Closure{Name: "door", Trap: &assets.Trap{Type: assets.TrapTypeAlarm}},
+			wantArmed: true,
+		},
+		"plain door has no trap": {
+			closure: &assets.Closure{Name: "door"},
+		},
+		"open exit has no trap": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ri, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier("r", &assets.Room{
+				Name:  "r",
+				Exits: map[string]assets.Exit{"north": {Closure: tc.closure}},
+			}))
+			_, re := ri.FindExit("north")
+			*re.Trap() = TrapState{Armed: false, Found: true}
+
+			ri.initExitClosures()
+
+			if got := *re.Trap(); got != (TrapState{Armed: tc.wantArmed}) {
+				t.Errorf("trap = %+v, want armed %v and not found", got, tc.wantArmed)
+			}
+		})
+	}
+}
+
+func TestRoomInstance_RevealExit(t *testing.T) {
+	tests := map[string]struct {
+		hidden     *assets.HiddenExit
+		withFinder bool
+		wantOK     bool
+		wantFinder bool // finder sees both sides
+		wantAll    bool // everyone sees both sides
+	}{
+		"room-wide reveal": {
+			hidden: &assets.HiddenExit{}, withFinder: true, wantOK: true, wantFinder: true, wantAll: true,
+		},
+		"timed reveal only for the finder": {
+			hidden: &assets.HiddenExit{Duration: 10}, withFinder: true, wantOK: true, wantFinder: true,
+		},
+		"timed exit without a finder reveals to all": {
+			hidden: &assets.HiddenExit{Duration: 10}, wantOK: true, wantFinder: true, wantAll: true,
+		},
+		"plain exit": {
+			withFinder: true, wantFinder: true, wantAll: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cellar, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier("cellar", &assets.Room{
+				Name:  "Cellar",
+				Exits: map[string]assets.Exit{"north": {Hidden: tc.hidden}},
+			}))
+			vault, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier("vault", &assets.Room{
+				Name:  "Vault",
+				Exits: map[string]assets.Exit{"south": {Hidden: tc.hidden}},
+			}))
+			cellar.exits["north"].Dest = vault
+			vault.exits["south"].Dest = cellar
+
+			finder := newTestCI("c1", "Finder")
+			var holder TimedPerkHolder
+			if tc.withFinder {
+				holder = finder
+			}
+			if got := cellar.RevealExit("north", holder); got != tc.wantOK {
+				t.Fatalf("RevealExit() = %v, want %v", got, tc.wantOK)
+			}
+
+			bystander := newTestCI("c2", "Bystander")
+			for _, side := range []struct {
+				room *RoomInstance
+				dir  string
+			}{{cellar, "north"}, {vault, "south"}} {
+				if got := side.room.ExitVisible(finder, side.dir); got != tc.wantFinder {
+					t.Errorf("%s visible to finder = %v, want %v", side.dir, got, tc.wantFinder)
+				}
+				if got := side.room.ExitVisible(bystander, side.dir); got != tc.wantAll {
+					t.Errorf("%s visible to bystander = %v, want %v", side.dir, got, tc.wantAll)
+				}
+			}
+
+			cellar.initExitClosures()
+			if tc.hidden != nil && cellar.ExitVisible(bystander, "north") {
+				t.Error("exit still revealed after reset")
+			}
+		})
+	}
+}
+
+func TestRoomInstance_TimeOfDay(t *testing.T) {
+	tests := map[string]struct {
+		hour     int
+		wantDesc string
+		wantMobs int
+	}{
+		"day shows the plain description":             {hour: 12, wantDesc: "Sunlit stones.", wantMobs: 0},
+		"night swaps description and spawns prowlers": {hour: 23, wantDesc: "Moonlit stones.", wantMobs: 1},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone := &assets.Zone{ResetMode: assets.ZoneResetNever}
+			room := &assets.Room{
+				Name:        "Ruins",
+				Description: "Sunlit stones.",
+				Zone:        storage.NewResolvedSmartIdentifier("z1", zone),
+				TimeDescs:   map[assets.TimeOfDay]string{assets.TimeNight: "Moonlit stones."},
+				TimedSpawns: []assets.TimedMobSpawn{{
+					Mobile: storage.NewResolvedSmartIdentifier("ghoul", &assets.Mobile{ShortDesc: "a ghoul"}),
+					Times:  []assets.TimeOfDay{assets.TimeNight},
+				}},
+			}
+			w, err := NewWorldState(
+				newFakeStore[*assets.Zone](map[string]*assets.Zone{"z1": zone}),
+				newFakeStore[*assets.Room](map[string]*assets.Room{"ruins": room}),
+			)
+			if err != nil {
+				t.Fatalf("NewWorldState: %v", err)
+			}
+			w.calendar = calendarAt(0, tc.hour, 0, 1)
+			ri := w.GetZone("z1").GetRoom("ruins")
+
+			if err := ri.Reset(nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+			if got := len(ri.mobiles); got != tc.wantMobs {
+				t.Errorf("mobs = %d, want %d", got, tc.wantMobs)
+			}
+			if got := ri.Describe(newTestCI("viewer", "Viewer")); !strings.Contains(got, tc.wantDesc) {
+				t.Errorf("Describe() = %q, want it to contain %q", got, tc.wantDesc)
+			}
+		})
+	}
+}
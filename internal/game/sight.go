@@ -0,0 +1,40 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// SightLine returns the rooms viewer sees looking out through the exit in
+// direction dir, nearest first and at most maxRange rooms away. Sight
+// carries straight on through open exits it can see, and stops at a closed
+// door or a dead end.
+func (ri *RoomInstance) SightLine(viewer GrantHolder, dir string, maxRange int) []*RoomInstance {
+	var rooms []*RoomInstance
+	cur := ri
+	for len(rooms) < maxRange {
+		re, ok := cur.exits[dir]
+		if !ok || re.Dest == nil || re.closed || !cur.exitVisible(viewer, dir, re) {
+			break
+		}
+		cur = re.Dest
+		if cur == ri || slices.Contains(rooms, cur) {
+			break
+		}
+		rooms = append(rooms, cur)
+	}
+	return rooms
+}
+
+// CanSee reports whether viewer can make out target. Invisible targets need
+// detect_invis and hiding ones sense_life.
+func CanSee(viewer, target GrantHolder) bool {
+	if target.HasGrant(assets.PerkGrantInvisible, "") && !viewer.HasGrant(assets.PerkGrantDetectInvis, "") {
+		return false
+	}
+	if target.HasGrant(assets.PerkGrantHide, "") && !viewer.HasGrant(assets.PerkGrantSenseLife, "") {
+		return false
+	}
+	return true
+}
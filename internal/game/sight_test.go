@@ -0,0 +1,92 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestRoomInstance_SightLine(t *testing.T) {
+	tests := map[string]struct {
+		dir      string
+		maxRange int
+		closed   bool
+		want     []string
+	}{
+		"looks down the corridor": {
+			dir:      "north",
+			maxRange: 5,
+			want:     []string{"b", "c"},
+		},
+		"limited by range": {
+			dir:      "north",
+			maxRange: 1,
+			want:     []string{"b"},
+		},
+		"stops at a closed door": {
+			dir:      "north",
+			maxRange: 5,
+			closed:   true,
+			want:     []string{"b"},
+		},
+		"no exit that way": {
+			dir:      "west",
+			maxRange: 5,
+		},
+		"loops are seen once": {
+			dir:      "east",
+			maxRange: 5,
+			want:     []string{"d"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// a -north-> b -north-> c; a -east-> d -east-> a
+			a, b, c, d := newPathRoom("a"), newPathRoom("b"), newPathRoom("c"), newPathRoom("d")
+			a.exits["north"] = &ResolvedExit{Dest: b}
+			b.exits["north"] = &ResolvedExit{Dest: c, closed: tc.closed}
+			a.exits["east"] = &ResolvedExit{Dest: d}
+			d.exits["east"] = &ResolvedExit{Dest: a}
+
+			var got []string
+			for _, ri := range a.SightLine(nil, tc.dir, tc.maxRange) {
+				got = append(got, ri.Room.Id())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("SightLine = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCanSee(t *testing.T) {
+	grants := func(keys ...string) *CharacterInstance {
+		ci := newTestCI("c", "C")
+		var perks []assets.Perk
+		for _, k := range keys {
+			perks = append(perks, assets.Perk{Type: assets.PerkTypeGrant, Key: k})
+		}
+		ci.PerkCache = *NewPerkCache(perks, nil)
+		return ci
+	}
+	tests := map[string]struct {
+		viewer []string
+		target []string
+		want   bool
+	}{
+		"plain sight":               {want: true},
+		"invisible":                 {target: []string{assets.PerkGrantInvisible}},
+		"invisible with detection":  {viewer: []string{assets.PerkGrantDetectInvis}, target: []string{assets.PerkGrantInvisible}, want: true},
+		"hiding":                    {target: []string{assets.PerkGrantHide}},
+		"hiding with sense life":    {viewer: []string{assets.PerkGrantSenseLife}, target: []string{assets.PerkGrantHide}, want: true},
+		"detect invis misses hider": {viewer: []string{assets.PerkGrantDetectInvis}, target: []string{assets.PerkGrantHide}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := CanSee(grants(tc.viewer...), grants(tc.target...)); got != tc.want {
+				t.Errorf("CanSee = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package game
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// Census key prefixes, so mob and object definitions sharing an ID are
+// counted apart.
+const (
+	censusMob = "mob:"
+	censusObj = "obj:"
+)
+
+// spawnCensus counts the live mobs and objects of each definition for spawn
+// max limits, across the world and within the zone being reset. Spawns made
+// while the census is in use are added as they happen.
+type spawnCensus struct {
+	world map[string]int
+	zone  map[string]int
+}
+
+// newSpawnCensus counts every mob and object in zones, carried ones
+// included. Those in home also count toward zone limits, as do home's mobs
+// that have strayed into other zones.
+func newSpawnCensus(zones []*ZoneInstance, home *ZoneInstance) *spawnCensus {
+	c := &spawnCensus{world: make(map[string]int), zone: make(map[string]int)}
+	for _, zi := range zones {
+		tally := func(key string) { c.world[key]++ }
+		if zi == home {
+			tally = c.add
+		}
+		zi.ForEachRoom(func(_ string, ri *RoomInstance) {
+			ri.ForEachMob(func(mi *MobileInstance) {
+				if zi != home && home != nil && mi.homeZone() == home {
+					c.add(censusMob + mi.Mobile.Id())
+				} else {
+					tally(censusMob + mi.Mobile.Id())
+				}
+				countCarried(&mi.ActorInstance, tally)
+			})
+			ri.ForEachPlayer(func(_ string, ci *CharacterInstance) {
+				countCarried(&ci.ActorInstance, tally)
+			})
+			countObjs(ri.objects, tally)
+		})
+	}
+	return c
+}
+
+// countCarried tallies everything an actor holds or wears.
+func countCarried(a *ActorInstance, tally func(string)) {
+	if a.inventory != nil {
+		countObjs(a.inventory, tally)
+	}
+	if a.equipment != nil {
+		for _, oi := range a.equipment.FindObjs(func(*ObjectInstance) bool { return true }) {
+			tally(censusObj + oi.Object.Id())
+			if oi.Contents != nil {
+				countObjs(oi.Contents, tally)
+			}
+		}
+	}
+}
+
+// countObjs tallies the objects in inv and, recursively, their contents.
+func countObjs(inv *Inventory, tally func(string)) {
+	inv.ForEachObj(func(_ string, oi *ObjectInstance) {
+		tally(censusObj + oi.Object.Id())
+		if oi.Contents != nil {
+			countObjs(oi.Contents, tally)
+		}
+	})
+}
+
+// allows reports whether another instance of the keyed definition fits
+// under rule's max.
+func (c *spawnCensus) allows(key string, rule assets.SpawnRule) bool {
+	if rule.Max == 0 {
+		return true
+	}
+	counts := c.world
+	if rule.MaxScope == assets.SpawnScopeZone {
+		counts = c.zone
+	}
+	return counts[key] < rule.Max
+}
+
+// add counts one more instance of the keyed definition in the zone.
+func (c *spawnCensus) add(key string) {
+	c.world[key]++
+	c.zone[key]++
+}
+
+// resetPass carries what spawning needs from the zone: commanders for new
+// mobs, the census for max limits, and dice for spawn chances.
+type resetPass struct {
+	cf       CommanderFactory
+	census   *spawnCensus
+	randIntN func(int) int
+}
+
+// fires reports whether a spawn of the keyed definition under rule goes
+// ahead: it must win its chance roll and fit under its max.
+func (p *resetPass) fires(key string, rule assets.SpawnRule) bool {
+	if rule.Chance > 0 && p.randIntN(100) >= rule.Chance {
+		return false
+	}
+	return p.census.allows(key, rule)
+}
+
+// mobSlot tracks the mob a room's spawn entry last produced.
+type mobSlot struct {
+	mob         *MobileInstance
+	vacantSince time.Time // when the mob was first missed; zero while it lives
+}
+
+// present reports whether mi is still standing in a room. Dead mobs are
+// taken out of their room, so this is false once mi has been killed.
+func present(mi *MobileInstance) bool {
+	if mi == nil {
+		return false
+	}
+	room := mi.Room()
+	return room != nil && room.holds(mi)
+}
+
+// newResetPass builds a pass for spawns made outside a zone reset.
+func (ri *RoomInstance) newResetPass(cf CommanderFactory) *resetPass {
+	pass := &resetPass{cf: cf, randIntN: rand.IntN}
+	var zones []*ZoneInstance
+	if ri.zone != nil {
+		zones = ri.zone.censusZones()
+		if ri.zone.randIntN != nil {
+			pass.randIntN = ri.zone.randIntN
+		}
+	}
+	pass.census = newSpawnCensus(zones, ri.zone)
+	return pass
+}
+
+// spawnMobs tops up the room's mob spawns: each entry whose last mob is gone
+// spawns a fresh one if its rule allows. Timed spawns only fill in during
+// their hours, and outside them their mob leaves unless it's fighting.
+func (ri *RoomInstance) spawnMobs(pass *resetPass) {
+	def := ri.Room.Get()
+	for i, spawn := range def.MobSpawns {
+		if present(ri.mobSlots[i].mob) {
+			continue
+		}
+		ri.mobSlots[i] = mobSlot{mob: ri.spawnMob(spawn.Mobile, spawn.SpawnRule, pass)}
+	}
+
+	now := ri.TimeOfDay()
+	for i, spawn := range def.TimedSpawns {
+		mi := ri.timedSlots[i]
+		if !spawn.SpawnsAt(now) {
+			if present(mi) && mi.Room() == ri && !mi.IsInCombat() {
+				ri.RemoveMob(mi.Id())
+				ri.timedSlots[i] = nil
+			}
+			continue
+		}
+		if !present(mi) {
+			ri.timedSlots[i] = ri.spawnMob(spawn.Mobile, assets.SpawnRule{}, pass)
+		}
+	}
+}
+
+// spawnMob spawns mob into the room as its home if rule allows, returning
+// the new instance or nil. Mobs that fail to instantiate are logged and
+// skipped.
+func (ri *RoomInstance) spawnMob(mob storage.SmartIdentifier[*assets.Mobile], rule assets.SpawnRule, pass *resetPass) *MobileInstance {
+	if rule.IfAbsent && len(ri.FindMobs(func(mi *MobileInstance) bool { return mi.Mobile.Id() == mob.Id() })) > 0 {
+		return nil
+	}
+	key := censusMob + mob.Id()
+	if !pass.fires(key, rule) {
+		return nil
+	}
+	mi, err := NewMobileInstance(mob)
+	if err != nil {
+		slog.Error("spawning mob", "mob", mob.Id(), "room", ri.Room.Id(), "error", err)
+		return nil
+	}
+	if pass.cf != nil {
+		mi.commander = pass.cf(mi)
+	}
+	mi.home = ri
+	ri.AddMob(mi)
+	pass.census.add(key)
+	return mi
+}
+
+// spawnObjs tops up the room's object spawns. An entry whose last object
+// still lies in the room has its contents refilled; otherwise a fresh one
+// spawns if the rule allows. Anything else on the floor is left alone.
+func (ri *RoomInstance) spawnObjs(pass *resetPass) error {
+	for i, spawn := range ri.Room.Get().ObjSpawns {
+		if oi := ri.objSlots[i]; oi != nil && ri.holdsObj(oi) {
+			topUpContents(oi, spawn.Contents)
+			if !anyLeft(oi.Contents, ri.objLoot[i]) {
+				ri.objLoot[i] = fillLoot(oi, spawn.Loot, pass)
+			}
+			continue
+		}
+		if spawn.IfAbsent && ri.objects.FindObjByDef(spawn.Object.Id()) != nil {
+			continue
+		}
+		key := censusObj + spawn.Object.Id()
+		if !pass.fires(key, spawn.SpawnRule) {
+			continue
+		}
+		oi, err := SpawnObject(spawn.ObjectSpawn)
+		if err != nil {
+			return err
+		}
+		ri.objLoot[i] = fillLoot(oi, spawn.Loot, pass)
+		ri.AddObj(oi)
+		ri.EmitObjectEvent(ObjectEventSpawn, oi, nil)
+		pass.census.add(key)
+		ri.objSlots[i] = oi
+	}
+	return nil
+}
+
+// fillLoot rolls loot into a spawned container and returns what it rolled.
+// Room assets only put loot on containers; see RoomObjectSpawn.Resolve.
+func fillLoot(oi *ObjectInstance, rolls []assets.LootRoll, pass *resetPass) []*ObjectInstance {
+	if len(rolls) == 0 || oi.Contents == nil {
+		return nil
+	}
+	loot := rollLoot(rolls, 0, pass.randIntN)
+	for _, l := range loot {
+		oi.Contents.AddObj(l)
+	}
+	return loot
+}
+
+// spawnLoot rolls the room's loot tables onto the floor. A table whose last
+// roll still lies partly in the room isn't rolled again until it's cleared.
+func (ri *RoomInstance) spawnLoot(pass *resetPass) {
+	for i := range ri.Room.Get().Loot {
+		if anyLeft(ri.objects, ri.lootSlots[i]) {
+			continue
+		}
+		loot := rollLoot(ri.Room.Get().Loot[i:i+1], 0, pass.randIntN)
+		for _, oi := range loot {
+			ri.AddObj(oi)
+			ri.EmitObjectEvent(ObjectEventSpawn, oi, nil)
+		}
+		ri.lootSlots[i] = loot
+	}
+}
+
+// holdsObj reports whether oi lies loose in the room.
+func (ri *RoomInstance) holdsObj(oi *ObjectInstance) bool {
+	return len(ri.objects.FindObjs(func(o *ObjectInstance) bool { return o == oi })) > 0
+}
+
+// topUpContents spawns whichever of specs the container is missing,
+// matching what's already inside by definition.
+func topUpContents(oi *ObjectInstance, specs []assets.ObjectSpawn) {
+	if oi.Contents == nil || len(specs) == 0 {
+		return
+	}
+	have := make(map[string]int)
+	oi.Contents.ForEachObj(func(_ string, c *ObjectInstance) {
+		have[c.Object.Id()]++
+	})
+	for _, spec := range specs {
+		if have[spec.Object.Id()] > 0 {
+			have[spec.Object.Id()]--
+			continue
+		}
+		soi, err := SpawnObject(spec)
+		if err != nil {
+			slog.Error("topping up container", "container", oi.Object.Id(), "object", spec.Object.Id(), "error", err)
+			continue
+		}
+		oi.Contents.AddObj(soi)
+	}
+}
+
+// respawnTick brings back killed mobs whose spawn entry has a respawn
+// timer, once the timer has run since they were missed.
+func (ri *RoomInstance) respawnTick() {
+	def := ri.Room.Get()
+	var pass *resetPass
+	for i := range def.MobSpawns {
+		spawn := &def.MobSpawns[i]
+		after := spawn.RespawnAfter()
+		if after == 0 {
+			continue
+		}
+		slot := &ri.mobSlots[i]
+		if present(slot.mob) {
+			slot.vacantSince = time.Time{}
+			continue
+		}
+		now := ri.now()
+		if slot.vacantSince.IsZero() {
+			slot.vacantSince = now
+			continue
+		}
+		if now.Sub(slot.vacantSince) < after {
+			continue
+		}
+		if pass == nil {
+			var cf CommanderFactory
+			if ri.zone != nil && ri.zone.world != nil {
+				cf = ri.zone.world.commanderFactory
+			}
+			pass = ri.newResetPass(cf)
+		}
+		*slot = mobSlot{mob: ri.spawnMob(spawn.Mobile, spawn.SpawnRule, pass)}
+	}
+}
+
+// now returns the current time by the zone's clock.
+func (ri *RoomInstance) now() time.Time {
+	if ri.zone != nil && ri.zone.clock != nil {
+		return ri.zone.clock()
+	}
+	return time.Now()
+}
@@ -0,0 +1,259 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+var testWolf = storage.NewResolvedSmartIdentifier("wolf", &assets.Mobile{Aliases: []string{"wolf"}, ShortDesc: "a wolf"})
+
+// newSpawnWorld builds a world with a forest den holding the given spawns
+// and an empty field out on the plains.
+func newSpawnWorld(t *testing.T, den *assets.Room) *WorldState {
+	t.Helper()
+	forest := &assets.Zone{ResetMode: assets.ZoneResetNever}
+	plains := &assets.Zone{ResetMode: assets.ZoneResetNever}
+	den.Name = "Den"
+	den.Zone = storage.NewResolvedSmartIdentifier("forest", forest)
+	w, err := NewWorldState(
+		newFakeStore(map[string]*assets.Zone{"forest": forest, "plains": plains}),
+		newFakeStore(map[string]*assets.Room{
+			"den":   den,
+			"field": {Name: "Field", Zone: storage.NewResolvedSmartIdentifier("plains", plains)},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewWorldState: %v", err)
+	}
+	return w
+}
+
+// homeMobs returns the mobs standing in ri that call it home.
+func homeMobs(ri *RoomInstance) []*MobileInstance {
+	return ri.FindMobs(func(mi *MobileInstance) bool { return mi.home == ri })
+}
+
+func TestRoomInstance_ResetSpawnRules(t *testing.T) {
+	tests := map[string]struct {
+		spawns      []assets.MobSpawn
+		fieldWolves int  // wolves already roaming the field
+		denWolf     bool // a wolf from elsewhere already in the den
+		roll        int  // what the percent die shows
+		want        int  // wolves spawned into the den
+	}{
+		"plain spawn": {
+			spawns: []assets.MobSpawn{{Mobile: testWolf}},
+			want:   1,
+		},
+		"world max counts other zones": {
+			spawns:      []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{Max: 2}}},
+			fieldWolves: 2,
+		},
+		"zone max ignores other zones": {
+			spawns:      []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{Max: 2, MaxScope: assets.SpawnScopeZone}}},
+			fieldWolves: 2,
+			want:        1,
+		},
+		"max counts this reset's spawns": {
+			spawns: []assets.MobSpawn{
+				{Mobile: testWolf, SpawnRule: assets.SpawnRule{Max: 1}},
+				{Mobile: testWolf, SpawnRule: assets.SpawnRule{Max: 1}},
+			},
+			want: 1,
+		},
+		"chance missed": {
+			spawns: []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{Chance: 30}}},
+			roll:   30,
+		},
+		"chance hit": {
+			spawns: []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{Chance: 30}}},
+			roll:   29,
+			want:   1,
+		},
+		"if_absent with a wolf present": {
+			spawns:  []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{IfAbsent: true}}},
+			denWolf: true,
+		},
+		"if_absent with the den empty": {
+			spawns: []assets.MobSpawn{{Mobile: testWolf, SpawnRule: assets.SpawnRule{IfAbsent: true}}},
+			want:   1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := newSpawnWorld(t, &assets.Room{MobSpawns: tc.spawns})
+			field := w.GetRoom("plains", "field")
+			for range tc.fieldWolves {
+				mi, _ := NewMobileInstance(testWolf)
+				field.AddMob(mi)
+			}
+			den := w.GetRoom("forest", "den")
+			if tc.denWolf {
+				mi, _ := NewMobileInstance(testWolf)
+				mi.home = field
+				den.AddMob(mi)
+			}
+			zone := w.GetZone("forest")
+			zone.randIntN = func(int) int { return tc.roll }
+
+			if err := zone.Reset(true, nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+			if got := len(homeMobs(den)); got != tc.want {
+				t.Errorf("wolves spawned = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoomInstance_ResetTopsUp(t *testing.T) {
+	coin := storage.NewResolvedSmartIdentifier("coin", &assets.Object{Aliases: []string{"coin"}, ShortDesc: "a coin"})
+	chest := storage.NewResolvedSmartIdentifier("chest", &assets.Object{Aliases: []string{"chest"}, ShortDesc: "a chest", Flags: []string{"container", "immobile"}})
+	lamp := storage.NewResolvedSmartIdentifier("lamp", &assets.Object{Aliases: []string{"lamp"}, ShortDesc: "a lamp"})
+	w := newSpawnWorld(t, &assets.Room{
+		MobSpawns: []assets.MobSpawn{{Mobile: testWolf}},
+		ObjSpawns: []assets.RoomObjectSpawn{
+			{ObjectSpawn: assets.ObjectSpawn{Object: chest, Contents: []assets.ObjectSpawn{{Object: coin}, {Object: coin}}}},
+			{ObjectSpawn: assets.ObjectSpawn{Object: lamp}},
+		},
+	})
+	den := w.GetRoom("forest", "den")
+	if err := den.Reset(nil); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	wolf := homeMobs(den)[0]
+	box := den.objects.FindObjByDef("chest")
+	oldLamp := den.objects.FindObjByDef("lamp")
+
+	// A player takes a coin and the lamp, and leaves some junk behind.
+	box.Contents.RemoveObj(box.Contents.FindObjByDef("coin").InstanceId)
+	den.RemoveObj(oldLamp.InstanceId)
+	den.AddObj(newTestObj("junk"))
+
+	if err := den.Reset(nil); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	if got := homeMobs(den); len(got) != 1 || got[0] != wolf {
+		t.Errorf("den wolves = %v, want the original wolf alone", got)
+	}
+	if den.objects.FindObjByDef("junk") == nil {
+		t.Error("dropped junk was swept away")
+	}
+	if got := den.objects.FindObjByDef("chest"); got != box {
+		t.Error("chest was replaced instead of kept")
+	}
+	if got := box.Contents.Len(); got != 2 {
+		t.Errorf("coins in chest = %d, want 2", got)
+	}
+	if got := den.objects.FindObjByDef("lamp"); got == nil || got == oldLamp {
+		t.Error("taken lamp was not replaced with a fresh one")
+	}
+	if got := den.objects.Len(); got != 3 {
+		t.Errorf("objects in den = %d, want 3", got)
+	}
+}
+
+func TestRoomInstance_respawnTick(t *testing.T) {
+	tests := map[string]struct {
+		respawn string
+		kill    bool
+		wait    time.Duration
+		want    bool // a fresh wolf is back in the den
+	}{
+		"killed wolf returns after the timer": {
+			respawn: "5m", kill: true, wait: 5 * time.Minute, want: true,
+		},
+		"not before the timer runs out": {
+			respawn: "5m", kill: true, wait: 4 * time.Minute,
+		},
+		"no timer waits for the reset": {
+			kill: true, wait: time.Hour,
+		},
+		"living wolf isn't doubled": {
+			respawn: "5m", wait: time.Hour,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := newSpawnWorld(t, &assets.Room{MobSpawns: []assets.MobSpawn{{Mobile: testWolf, Respawn: tc.respawn}}})
+			zone := w.GetZone("forest")
+			now := time.Now()
+			zone.clock = func() time.Time { return now }
+			den := w.GetRoom("forest", "den")
+			if err := den.Reset(nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+			first := homeMobs(den)[0]
+			if tc.kill {
+				den.RemoveMob(first.Id())
+			}
+
+			den.respawnTick() // notices the wolf is gone
+			now = now.Add(tc.wait)
+			den.respawnTick()
+
+			wolves := homeMobs(den)
+			back := len(wolves) == 1 && wolves[0] != first
+			if back != tc.want {
+				t.Errorf("fresh wolf back = %v, want %v (wolves: %d)", back, tc.want, len(wolves))
+			}
+			if !tc.kill && len(wolves) != 1 {
+				t.Errorf("wolves = %d, want 1", len(wolves))
+			}
+		})
+	}
+}
+
+func TestRoomInstance_ResetLoot(t *testing.T) {
+	table := func(id string) storage.SmartIdentifier[*assets.LootTable] {
+		obj := storage.NewResolvedSmartIdentifier(id, &assets.Object{Aliases: []string{id}, ShortDesc: "a " + id})
+		return storage.NewResolvedSmartIdentifier(id+"s", &assets.LootTable{Entries: []assets.LootEntry{{Object: obj}}})
+	}
+	chest := storage.NewResolvedSmartIdentifier("chest", &assets.Object{Aliases: []string{"chest"}, ShortDesc: "a chest", Flags: []string{"container", "immobile"}})
+
+	tests := map[string]struct {
+		takeGem  bool
+		takeBone bool
+		wantGems int
+		wantBone int
+	}{
+		"untouched loot isn't rolled again": {wantGems: 1, wantBone: 1},
+		"taken gem is rolled again":         {takeGem: true, wantGems: 1, wantBone: 1},
+		"taken bone is rolled again":        {takeBone: true, wantGems: 1, wantBone: 1},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := newSpawnWorld(t, &assets.Room{
+				ObjSpawns: []assets.RoomObjectSpawn{{ObjectSpawn: assets.ObjectSpawn{Object: chest}, Loot: []assets.LootRoll{{Table: table("gem")}}}},
+				Loot:      []assets.LootRoll{{Table: table("bone")}},
+			})
+			den := w.GetRoom("forest", "den")
+			if err := den.Reset(nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+			box := den.objects.FindObjByDef("chest")
+			if tc.takeGem {
+				box.Contents.RemoveObj(box.Contents.FindObjByDef("gem").InstanceId)
+			}
+			if tc.takeBone {
+				den.RemoveObj(den.objects.FindObjByDef("bone").InstanceId)
+			}
+
+			if err := den.Reset(nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+
+			if got := box.Contents.Len(); got != tc.wantGems {
+				t.Errorf("gems in chest = %d, want %d", got, tc.wantGems)
+			}
+			bones := den.objects.FindObjs(func(oi *ObjectInstance) bool { return oi.Object.Id() == "bone" })
+			if len(bones) != tc.wantBone {
+				t.Errorf("bones on floor = %d, want %d", len(bones), tc.wantBone)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+// This file exposes the game to Starlark code. Values wrap live instances, so
+// code always sees current state and its changes take effect at once.
+
+// ScriptRandom is the Starlark random(n) builtin, returning 1 to n.
+var ScriptRandom = starlark.NewBuiltin("random", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var n int
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &n); err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%s: n must be at least 1", b.Name())
+	}
+	return starlark.MakeInt(rand.IntN(n) + 1), nil
+})
+
+// methods maps a Starlark type's method names to their builtins, which get
+// the value as their receiver.
+type methods map[string]*starlark.Builtin
+
+func (m methods) attr(recv starlark.Value, name string) starlark.Value {
+	if b, ok := m[name]; ok {
+		return b.BindReceiver(recv)
+	}
+	return nil
+}
+
+// names returns the sorted attribute names: fields plus the methods.
+func (m methods) names(fields ...string) []string {
+	out := append([]string(nil), fields...)
+	for name := range m {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func method(name string, fn func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)) *starlark.Builtin {
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		return fn(b.Receiver(), args, kwargs)
+	})
+}
+
+// compareIds compares two wrapped values by identity; only == and != are
+// supported.
+func compareIds(op syntax.Token, x, y string) (bool, error) {
+	switch op {
+	case syntax.EQL:
+		return x == y, nil
+	case syntax.NEQ:
+		return x != y, nil
+	}
+	return false, fmt.Errorf("%s not supported", op)
+}
+
+func hashString(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// --- Actors ---
+
+// starActor is a mob or player as Starlark sees it.
+type starActor struct {
+	ctx context.Context
+	a   Actor
+}
+
+// ScriptActor wraps an actor for Starlark code, or returns None for nil.
+// Commands the code runs as the actor use ctx.
+func ScriptActor(ctx context.Context, a Actor) starlark.Value {
+	if a == nil {
+		return starlark.None
+	}
+	return &starActor{ctx: ctx, a: a}
+}
+
+var actorFields = []string{"alive", "equipment", "id", "in_combat", "inventory", "is_player", "level", "name", "room"}
+
+var actorMethods = methods{
+	// resource(name) returns (current, max).
+	"resource": method("resource", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		if err := starlark.UnpackPositionalArgs("resource", args, kwargs, 1, &name); err != nil {
+			return nil, err
+		}
+		cur, mx := recv.(*starActor).a.Resource(name)
+		return starlark.Tuple{starlark.MakeInt(cur), starlark.MakeInt(mx)}, nil
+	}),
+	// adjust(name, delta) changes a resource within its bounds.
+	"adjust": method("adjust", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var delta int
+		if err := starlark.UnpackPositionalArgs("adjust", args, kwargs, 2, &name, &delta); err != nil {
+			return nil, err
+		}
+		recv.(*starActor).a.AdjustResource(name, delta, false)
+		return starlark.None, nil
+	}),
+	// has_grant(key, arg="") reports whether the actor's perks grant key.
+	"has_grant": method("has_grant", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key, arg string
+		if err := starlark.UnpackArgs("has_grant", args, kwargs, "key", &key, "arg?", &arg); err != nil {
+			return nil, err
+		}
+		return starlark.Bool(recv.(*starActor).a.HasGrant(key, arg)), nil
+	}),
+	// modifier(key) sums the actor's modifiers for key.
+	"modifier": method("modifier", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		if err := starlark.UnpackPositionalArgs("modifier", args, kwargs, 1, &key); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(recv.(*starActor).a.ModifierValue(key)), nil
+	}),
+	// buff(name, key, ticks, value=0, arg="") adds a timed perk: a modifier
+	// when value is set, otherwise a grant.
+	"buff": method("buff", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name, key, arg string
+		var ticks, value int
+		if err := starlark.UnpackArgs("buff", args, kwargs, "name", &name, "key", &key, "ticks", &ticks, "value?", &value, "arg?", &arg); err != nil {
+			return nil, err
+		}
+		if ticks < 1 {
+			return nil, fmt.Errorf("buff: ticks must be at least 1")
+		}
+		perk := assets.Perk{Type: assets.PerkTypeGrant, Key: key, Arg: arg}
+		if value != 0 {
+			perk = assets.Perk{Type: assets.PerkTypeModifier, Key: key, Value: value}
+		}
+		recv.(*starActor).a.AddTimedPerks(name, []assets.Perk{perk}, ticks)
+		return starlark.None, nil
+	}),
+	// send(msg) sends a message to the actor.
+	"send": method("send", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var msg string
+		if err := starlark.UnpackPositionalArgs("send", args, kwargs, 1, &msg); err != nil {
+			return nil, err
+		}
+		recv.(*starActor).a.Publish([]byte(msg), nil)
+		return starlark.None, nil
+	}),
+	// command(line) runs a command as the actor and reports whether it
+	// succeeded. A player is told why a command failed, as if they typed it.
+	"command": method("command", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var line string
+		if err := starlark.UnpackPositionalArgs("command", args, kwargs, 1, &line); err != nil {
+			return nil, err
+		}
+		sa := recv.(*starActor)
+		return starlark.Bool(runCommand(sa.ctx, sa.a, line)), nil
+	}),
+}
+
+func (sa *starActor) String() string        { return fmt.Sprintf("<actor %s>", sa.a.Name()) }
+func (sa *starActor) Type() string          { return "actor" }
+func (sa *starActor) Freeze()               {}
+func (sa *starActor) Truth() starlark.Bool  { return starlark.True }
+func (sa *starActor) Hash() (uint32, error) { return hashString(sa.a.Id()), nil }
+func (sa *starActor) AttrNames() []string   { return actorMethods.names(actorFields...) }
+
+func (sa *starActor) CompareSameType(op syntax.Token, y starlark.Value, _ int) (bool, error) {
+	return compareIds(op, sa.a.Id(), y.(*starActor).a.Id())
+}
+
+func (sa *starActor) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "id":
+		return starlark.String(sa.a.Id()), nil
+	case "name":
+		return starlark.String(sa.a.Name()), nil
+	case "level":
+		return starlark.MakeInt(sa.a.Level()), nil
+	case "is_player":
+		return starlark.Bool(sa.a.IsCharacter()), nil
+	case "alive":
+		return starlark.Bool(sa.a.IsAlive()), nil
+	case "in_combat":
+		return starlark.Bool(sa.a.IsInCombat()), nil
+	case "room":
+		if room := sa.a.Room(); room != nil {
+			return ScriptRoom(sa.ctx, room), nil
+		}
+		return starlark.None, nil
+	case "inventory":
+		if inv := sa.a.Inventory(); inv != nil {
+			return &starObjects{find: inv.FindObjs}, nil
+		}
+		return &starObjects{}, nil
+	case "equipment":
+		if eq := sa.a.Equipment(); eq != nil {
+			return &starObjects{find: eq.FindObjs}, nil
+		}
+		return &starObjects{}, nil
+	}
+	return actorMethods.attr(sa, name), nil
+}
+
+// runCommand runs line as actor through its commander. A failed command is
+// shown to a player as if they had typed it. Returns whether it succeeded.
+func runCommand(ctx context.Context, actor Actor, line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	c, ok := actor.(interface{ boundCommander() Commander })
+	if !ok || c.boundCommander() == nil {
+		return false
+	}
+	if err := c.boundCommander().ExecCommand(ctx, fields[0], fields[1:]...); err != nil {
+		if actor.IsCharacter() {
+			actor.Publish([]byte(err.Error()), nil)
+		}
+		return false
+	}
+	return true
+}
+
+// --- Rooms ---
+
+// starRoom is a room as Starlark sees it.
+type starRoom struct {
+	ctx context.Context
+	ri  *RoomInstance
+}
+
+// ScriptRoom wraps a room for Starlark code. Actors reached through it run
+// commands with ctx.
+func ScriptRoom(ctx context.Context, ri *RoomInstance) starlark.Value {
+	return &starRoom{ctx: ctx, ri: ri}
+}
+
+var roomFields = []string{"actors", "id", "mobs", "name", "objects", "players", "zone"}
+
+var roomMethods = methods{
+	// echo(msg) sends a message to every player in the room.
+	"echo": method("echo", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var msg string
+		if err := starlark.UnpackPositionalArgs("echo", args, kwargs, 1, &msg); err != nil {
+			return nil, err
+		}
+		recv.(*starRoom).ri.Publish([]byte(msg), nil)
+		return starlark.None, nil
+	}),
+	// has_grant(key, arg="") reports whether the room's perks grant key.
+	"has_grant": method("has_grant", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key, arg string
+		if err := starlark.UnpackArgs("has_grant", args, kwargs, "key", &key, "arg?", &arg); err != nil {
+			return nil, err
+		}
+		return starlark.Bool(recv.(*starRoom).ri.Perks.HasGrant(key, arg)), nil
+	}),
+}
+
+func (sr *starRoom) String() string        { return fmt.Sprintf("<room %s>", sr.ri.Room.Id()) }
+func (sr *starRoom) Type() string          { return "room" }
+func (sr *starRoom) Freeze()               {}
+func (sr *starRoom) Truth() starlark.Bool  { return starlark.True }
+func (sr *starRoom) Hash() (uint32, error) { return hashString(sr.ri.Room.Id()), nil }
+func (sr *starRoom) AttrNames() []string   { return roomMethods.names(roomFields...) }
+
+func (sr *starRoom) CompareSameType(op syntax.Token, y starlark.Value, _ int) (bool, error) {
+	return compareIds(op, sr.ri.Room.Id(), y.(*starRoom).ri.Room.Id())
+}
+
+func (sr *starRoom) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "id":
+		return starlark.String(sr.ri.Room.Id()), nil
+	case "name":
+		return starlark.String(sr.ri.Room.Get().Name), nil
+	case "zone":
+		if sr.ri.Zone() == nil {
+			return starlark.String(""), nil
+		}
+		return starlark.String(sr.ri.Zone().Zone.Id()), nil
+	case "players":
+		return sr.actors(func(a Actor) bool { return a.IsCharacter() }), nil
+	case "mobs":
+		return sr.actors(func(a Actor) bool { return !a.IsCharacter() }), nil
+	case "actors":
+		return sr.actors(func(Actor) bool { return true }), nil
+	case "objects":
+		return &starObjects{find: sr.ri.FindObjs}, nil
+	}
+	return roomMethods.attr(sr, name), nil
+}
+
+// actors lists the room's actors accepted by match, sorted by name so code
+// sees a stable order.
+func (sr *starRoom) actors(match func(Actor) bool) *starlark.List {
+	var found []Actor
+	sr.ri.ForEachActor(func(a Actor) {
+		if match(a) {
+			found = append(found, a)
+		}
+	})
+	slices.SortFunc(found, func(a, b Actor) int { return strings.Compare(a.Name(), b.Name()) })
+	vals := make([]starlark.Value, len(found))
+	for i, a := range found {
+		vals[i] = ScriptActor(sr.ctx, a)
+	}
+	return starlark.NewList(vals)
+}
+
+// --- Objects ---
+
+// starObjects is a set of objects, such as an inventory or a room's floor.
+type starObjects struct {
+	find func(func(*ObjectInstance) bool) []*ObjectInstance // nil for none
+}
+
+var objectsMethods = methods{
+	// has(id) reports whether any object has the definition id.
+	"has": method("has", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var id string
+		if err := starlark.UnpackPositionalArgs("has", args, kwargs, 1, &id); err != nil {
+			return nil, err
+		}
+		return starlark.Bool(len(recv.(*starObjects).byDef(id)) > 0), nil
+	}),
+	// count(id) counts the objects with the definition id.
+	"count": method("count", func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var id string
+		if err := starlark.UnpackPositionalArgs("count", args, kwargs, 1, &id); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(len(recv.(*starObjects).byDef(id))), nil
+	}),
+}
+
+func (so *starObjects) String() string        { return "<objects>" }
+func (so *starObjects) Type() string          { return "objects" }
+func (so *starObjects) Freeze()               {}
+func (so *starObjects) Truth() starlark.Bool  { return len(so.all()) > 0 }
+func (so *starObjects) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: objects") }
+func (so *starObjects) AttrNames() []string   { return objectsMethods.names("items") }
+
+func (so *starObjects) Attr(name string) (starlark.Value, error) {
+	if name == "items" {
+		all := so.all()
+		slices.SortFunc(all, func(a, b *ObjectInstance) int { return strings.Compare(a.ShortDesc(), b.ShortDesc()) })
+		vals := make([]starlark.Value, len(all))
+		for i, oi := range all {
+			vals[i] = ScriptObject(oi)
+		}
+		return starlark.NewList(vals), nil
+	}
+	return objectsMethods.attr(so, name), nil
+}
+
+func (so *starObjects) all() []*ObjectInstance {
+	if so.find == nil {
+		return nil
+	}
+	return so.find(func(*ObjectInstance) bool { return true })
+}
+
+func (so *starObjects) byDef(id string) []*ObjectInstance {
+	if so.find == nil {
+		return nil
+	}
+	return so.find(func(oi *ObjectInstance) bool { return oi.Object.Id() == id })
+}
+
+// starObject is an object instance as Starlark sees it.
+type starObject struct {
+	oi *ObjectInstance
+}
+
+// ScriptObject wraps an object for Starlark code, or returns None for nil.
+func ScriptObject(oi *ObjectInstance) starlark.Value {
+	if oi == nil {
+		return starlark.None
+	}
+	return &starObject{oi: oi}
+}
+
+func (so *starObject) String() string        { return fmt.Sprintf("<object %s>", so.oi.Object.Id()) }
+func (so *starObject) Type() string          { return "object" }
+func (so *starObject) Freeze()               {}
+func (so *starObject) Truth() starlark.Bool  { return starlark.True }
+func (so *starObject) Hash() (uint32, error) { return hashString(so.oi.InstanceId), nil }
+func (so *starObject) AttrNames() []string   { return []string{"id", "name"} }
+
+func (so *starObject) CompareSameType(op syntax.Token, y starlark.Value, _ int) (bool, error) {
+	return compareIds(op, so.oi.InstanceId, y.(*starObject).oi.InstanceId)
+}
+
+func (so *starObject) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "id":
+		return starlark.String(so.oi.Object.Id()), nil
+	case "name":
+		return starlark.String(so.oi.ShortDesc()), nil
+	}
+	return nil, nil
+}
+
+// --- Variables ---
+
+// starVars exposes a trigger owner's script variables, shared with step
+// scripts' .Vars. Variables hold text; ints and bools are stored as their
+// text, so code reads numbers back with int().
+type starVars struct {
+	state *triggerState
+}
+
+func (sv *starVars) String() string        { return "<vars>" }
+func (sv *starVars) Type() string          { return "vars" }
+func (sv *starVars) Freeze()               {}
+func (sv *starVars) Truth() starlark.Bool  { return starlark.True }
+func (sv *starVars) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: vars") }
+func (sv *starVars) AttrNames() []string   { return []string{"get"} }
+
+// Get returns the variable named k. Unset variables are not found, so
+// vars["x"] fails for them and code uses vars.get("x", default).
+func (sv *starVars) Get(k starlark.Value) (starlark.Value, bool, error) {
+	name, ok := starlark.AsString(k)
+	if !ok {
+		return nil, false, fmt.Errorf("vars: key must be a string, not %s", k.Type())
+	}
+	sv.state.mu.Lock()
+	defer sv.state.mu.Unlock()
+	v, found := sv.state.vars[name]
+	return starlark.String(v), found, nil
+}
+
+func (sv *starVars) SetKey(k, v starlark.Value) error {
+	name, ok := starlark.AsString(k)
+	if !ok {
+		return fmt.Errorf("vars: key must be a string, not %s", k.Type())
+	}
+	var text string
+	switch v := v.(type) {
+	case starlark.String:
+		text = string(v)
+	case starlark.Int, starlark.Bool:
+		text = v.String()
+	default:
+		return fmt.Errorf("vars: can't store a %s", v.Type())
+	}
+	sv.state.setVar(name, text)
+	return nil
+}
+
+func (sv *starVars) Attr(name string) (starlark.Value, error) {
+	if name != "get" {
+		return nil, nil
+	}
+	return starlark.NewBuiltin("get", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key starlark.Value
+		var def starlark.Value = starlark.None
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &key, &def); err != nil {
+			return nil, err
+		}
+		v, found, err := sv.Get(key)
+		if err != nil || !found {
+			return def, err
+		}
+		return v, nil
+	}), nil
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func TestScriptRun_codeGlobals(t *testing.T) {
+	r := &scriptRun{ctx: context.Background(), owner: roomOwner(newTestRoom("hall"))}
+
+	got := slices.Sorted(maps.Keys(r.codeGlobals()))
+	want := slices.Sorted(slices.Values(assets.TriggerCodeGlobals))
+	if !slices.Equal(got, want) {
+		t.Errorf("codeGlobals() = %v, want assets.TriggerCodeGlobals %v", got, want)
+	}
+}
+
+func TestFireSpeech_code(t *testing.T) {
+	tests := map[string]struct {
+		code       string
+		pattern    string
+		carrying   string
+		said       string
+		wantMsgs   []string
+		wantDo     []string
+		wantForced []string
+	}{
+		"actor and self": {
+			code:     `actor.send("%s nods to %s." % (self.name, actor.name))`,
+			wantMsgs: []string{"the guard nods to Bob."},
+		},
+		"loops and math": {
+			code:     "total = 0\nfor n in range(1, 5):\n    total += n\nroom.echo(str(total))\n",
+			wantMsgs: []string{"10"},
+		},
+		"match and text": {
+			code:     `actor.send(match[1] + " / " + text)`,
+			pattern:  `password is (\w+)`,
+			said:     "the password is swordfish",
+			wantMsgs: []string{"swordfish / the password is swordfish"},
+		},
+		"inventory queries": {
+			code:     `actor.send("pass" if actor.inventory.has("pass") else "halt")`,
+			carrying: "pass",
+			wantMsgs: []string{"pass"},
+		},
+		"room queries": {
+			code:     `room.echo(", ".join([a.name for a in room.actors]) + " in " + room.name)`,
+			wantMsgs: []string{"Bob, the guard in Hall"},
+		},
+		"resources": {
+			code:     "cur, mx = self.resource(\"hp\")\nself.adjust(\"hp\", -3)\nactor.send(\"%d/%d -> %d\" % (cur, mx, self.resource(\"hp\")[0]))\n",
+			wantMsgs: []string{"10/10 -> 7"},
+		},
+		"commands": {
+			code:       "self.command(\"bow\")\nactor.command(\"kneel\")\n",
+			wantDo:     []string{"bow"},
+			wantForced: []string{"kneel"},
+		},
+		"a runtime error stops the code": {
+			code:     "actor.send(\"one\")\nactor.send(1 + \"x\")\nactor.send(\"two\")\n",
+			wantMsgs: []string{"one"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(nil, []assets.Trigger{{Type: assets.TriggerSpeech, Pattern: tc.pattern, Code: tc.code}})
+			if tc.carrying != "" {
+				s.player.inventory.AddObj(newTestObj(tc.carrying))
+			}
+			said := tc.said
+			if said == "" {
+				said = "hello"
+			}
+
+			FireSpeech(context.Background(), s.player, s.room, said)
+
+			if got := s.drain(); !slices.Equal(got, tc.wantMsgs) {
+				t.Errorf("messages = %q, want %q", got, tc.wantMsgs)
+			}
+			if !slices.Equal(s.guardCmds.commands, tc.wantDo) {
+				t.Errorf("guard commands = %v, want %v", s.guardCmds.commands, tc.wantDo)
+			}
+			if !slices.Equal(s.playerCmds.commands, tc.wantForced) {
+				t.Errorf("player commands = %v, want %v", s.playerCmds.commands, tc.wantForced)
+			}
+		})
+	}
+}
+
+func TestFireSpeech_codeVars(t *testing.T) {
+	// Code and step scripts share the owner's variables.
+	s := newScriptScene(nil, []assets.Trigger{
+		{Type: assets.TriggerSpeech, Code: `vars["count"] = int(vars.get("count", "0")) + 1`},
+		{Type: assets.TriggerSpeech, Script: []assets.ScriptStep{{Send: "{{ .Vars.count }}"}}},
+	})
+
+	for range 3 {
+		FireSpeech(context.Background(), s.player, s.room, "hi")
+	}
+
+	if got, want := s.drain(), []string{"1", "2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
+
+func TestInterceptCommand_code(t *testing.T) {
+	tests := map[string]struct {
+		code string
+		want bool
+	}{
+		"swallowed": {code: `actor.send("Nothing happens.")`, want: true},
+		"allowed":   {code: "allow()", want: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene([]assets.Trigger{{Type: assets.TriggerCommand, Pattern: "pull", Code: tc.code}}, nil)
+
+			if got := InterceptCommand(context.Background(), s.player, "pull"); got != tc.want {
+				t.Errorf("InterceptCommand() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
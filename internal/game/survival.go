@@ -0,0 +1,63 @@
+package game
+
+import "github.com/pixil98/go-mud/internal/assets"
+
+// SurvivalInterval is the number of world ticks between hunger and thirst drains.
+const SurvivalInterval = 30
+
+// SipQuench is the thirst restored by a single sip of any liquid.
+const SipQuench = 4
+
+// survivalResources maps each survival resource to the grant that suspends it.
+var survivalResources = map[string]string{
+	assets.ResourceHunger: assets.PerkGrantNoHunger,
+	assets.ResourceThirst: assets.PerkGrantNoThirst,
+}
+
+// survivalWarnings are queued to the actor when a survival resource runs out.
+var survivalWarnings = map[string]string{
+	assets.ResourceHunger: "You are hungry.",
+	assets.ResourceThirst: "You are thirsty.",
+}
+
+// survivalActive reports whether the named survival resource applies to this
+// actor: it must have a max from perks and must not be suspended by a grant.
+// Caller must hold the owning type's lock.
+func (a *ActorInstance) survivalActive(name string) bool {
+	if _, ok := a.resources[name]; !ok || a.resourceMax(name) <= 0 {
+		return false
+	}
+	return !a.HasGrant(survivalResources[name], "")
+}
+
+// depletedSurvival returns how many active survival resources are at zero.
+// Caller must hold the owning type's lock.
+func (a *ActorInstance) depletedSurvival() int {
+	n := 0
+	for name := range survivalResources {
+		if a.survivalActive(name) && a.resources[name] == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// drainTick lowers each active survival resource by its drain perk and
+// returns the warnings for any that are now empty.
+// Caller must hold the owning type's write lock.
+func (a *ActorInstance) drainTick() []string {
+	var msgs []string
+	for name := range survivalResources {
+		if !a.survivalActive(name) {
+			continue
+		}
+		drain := a.ModifierValue(assets.BuildKey(assets.ResourcePrefix, name, assets.ResourceAspectDrain))
+		if drain > 0 {
+			a.adjustResource(name, -drain, false)
+		}
+		if a.resources[name] == 0 {
+			msgs = append(msgs, survivalWarnings[name])
+		}
+	}
+	return msgs
+}
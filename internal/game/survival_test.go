@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+)
+
+func survivalPerks(extra ...assets.Perk) []assets.Perk {
+	key := func(name, aspect string) string { return assets.BuildKey(assets.ResourcePrefix, name, aspect) }
+	perks := []assets.Perk{
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceHp, assets.ResourceAspectMax), Value: 20},
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceHp, assets.ResourceAspectRegen), Value: 4},
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceHunger, assets.ResourceAspectMax), Value: 10},
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceHunger, assets.ResourceAspectDrain), Value: 2},
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceThirst, assets.ResourceAspectMax), Value: 10},
+		{Type: assets.PerkTypeModifier, Key: key(assets.ResourceThirst, assets.ResourceAspectDrain), Value: 3},
+	}
+	return append(perks, extra...)
+}
+
+func TestActorInstance_drainTick(t *testing.T) {
+	tests := map[string]struct {
+		perks      []assets.Perk
+		hunger     int
+		thirst     int
+		wantHunger int
+		wantThirst int
+		wantMsgs   int
+	}{
+		"both drain by their perk": {
+			perks: survivalPerks(), hunger: 10, thirst: 10,
+			wantHunger: 8, wantThirst: 7,
+		},
+		"drain clamps at zero and warns": {
+			perks: survivalPerks(), hunger: 1, thirst: 5,
+			wantHunger: 0, wantThirst: 2, wantMsgs: 1,
+		},
+		"nohunger grant suspends hunger": {
+			perks:  survivalPerks(assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantNoHunger}),
+			hunger: 0, thirst: 10,
+			wantHunger: 0, wantThirst: 7,
+		},
+		"no survival perks means nothing drains": {
+			perks: nil,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &ActorInstance{PerkCache: *NewPerkCache(tc.perks, nil)}
+			a.initResources()
+			a.setResourceCurrent(assets.ResourceHunger, tc.hunger)
+			a.setResourceCurrent(assets.ResourceThirst, tc.thirst)
+			a.mu.Lock()
+			msgs := a.drainTick()
+			a.mu.Unlock()
+			if hunger, _ := a.Resource(assets.ResourceHunger); hunger != tc.wantHunger {
+				t.Errorf("hunger = %d, want %d", hunger, tc.wantHunger)
+			}
+			if thirst, _ := a.Resource(assets.ResourceThirst); thirst != tc.wantThirst {
+				t.Errorf("thirst = %d, want %d", thirst, tc.wantThirst)
+			}
+			if len(msgs) != tc.wantMsgs {
+				t.Errorf("warnings = %v, want %d", msgs, tc.wantMsgs)
+			}
+		})
+	}
+}
+
+func TestActorInstance_regenTickSurvivalPenalty(t *testing.T) {
+	tests := map[string]struct {
+		perks  []assets.Perk
+		hunger int
+		thirst int
+		wantHP int
+	}{
+		"fed and watered regen fully":  {perks: survivalPerks(), hunger: 5, thirst: 5, wantHP: 4},
+		"starving halves regen":        {perks: survivalPerks(), hunger: 0, thirst: 5, wantHP: 2},
+		"starving and parched quarter": {perks: survivalPerks(), hunger: 0, thirst: 0, wantHP: 1},
+		"opted out hunger has no penalty": {
+			perks:  survivalPerks(assets.Perk{Type: assets.PerkTypeGrant, Key: assets.PerkGrantNoHunger}),
+			hunger: 0, thirst: 5, wantHP: 4,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &ActorInstance{PerkCache: *NewPerkCache(tc.perks, nil)}
+			a.initResources()
+			a.setResourceCurrent(assets.ResourceHp, 0)
+			a.setResourceCurrent(assets.ResourceHunger, tc.hunger)
+			a.setResourceCurrent(assets.ResourceThirst, tc.thirst)
+			a.mu.Lock()
+			a.regenTick()
+			a.mu.Unlock()
+			if hp, _ := a.Resource(assets.ResourceHp); hp != tc.wantHP {
+				t.Errorf("HP = %d, want %d", hp, tc.wantHP)
+			}
+		})
+	}
+}
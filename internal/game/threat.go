@@ -86,8 +86,9 @@ func (t *ThreatTable) clear() {
 }
 
 // resolveTarget picks the best target for this actor. If preferredId is
-// non-empty and present in the table, it wins. Otherwise the highest-threat
-// entry is returned. Returns nil if the table is empty.
+// non-empty and present in the table, it wins. Otherwise the entry with the
+// highest threat, discounted for enemies in the back rank, is returned.
+// Returns nil if the table is empty.
 func (t *ThreatTable) resolveTarget(preferredId string) Actor {
 	if preferredId != "" {
 		if e, ok := t.entries[preferredId]; ok {
@@ -96,7 +97,7 @@ func (t *ThreatTable) resolveTarget(preferredId string) Actor {
 	}
 	var best *threatEntry
 	for _, e := range t.entries {
-		if best == nil || e.threat > best.threat {
+		if best == nil || rankedThreat(e) > rankedThreat(best) {
 			best = e
 		}
 	}
@@ -196,6 +196,30 @@ func TestThreatTable_ResolveTarget(t *testing.T) {
 			preferred: "gone",
 			wantId:    "b",
 		},
+		"back rank counts half its threat": {
+			setup: func() ThreatTable {
+				var tt ThreatTable
+				tt.ensureEntry("a", newTestMI("a", "A"))
+				tt.ensureEntry("b", newTestMI("b", "B"))
+				tt.entries["a"].threat = 10
+				tt.entries["b"].threat = 15
+				tt.entries["b"].actor.(*MobileInstance).SetBackRank(true)
+				return tt
+			},
+			wantId: "a",
+		},
+		"threat overrides back rank": {
+			setup: func() ThreatTable {
+				var tt ThreatTable
+				tt.ensureEntry("a", newTestMI("a", "A"))
+				tt.ensureEntry("b", newTestMI("b", "B"))
+				tt.entries["a"].threat = 10
+				tt.entries["b"].threat = 25
+				tt.entries["b"].actor.(*MobileInstance).SetBackRank(true)
+				return tt
+			},
+			wantId: "b",
+		},
 	}
 
 	for name, tc := range tests {
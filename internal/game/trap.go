@@ -0,0 +1,14 @@
+package game
+
+import "github.com/pixil98/go-mud/internal/assets"
+
+// TrapState is the runtime state of the trap on an exit or container closure.
+type TrapState struct {
+	Armed bool // true until the trap is sprung or disarmed
+	Found bool // true once a search has revealed the trap
+}
+
+// Reset arms the trap if the closure defines one and forgets any search.
+func (ts *TrapState) Reset(c *assets.Closure) {
+	*ts = TrapState{Armed: c != nil && c.Trap != nil}
+}
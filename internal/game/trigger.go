@@ -0,0 +1,489 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"text/template"
+
+	"go.starlark.net/starlark"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/scripting"
+)
+
+// maxScriptDepth caps how many triggers and script effects deep one event
+// can reach, so scripts that set each other off can't loop forever.
+const maxScriptDepth = 4
+
+type scriptDepthKey struct{}
+
+// EnterScript returns ctx one script deeper, for running a script that ctx
+// set off. It reports false once scripts are maxScriptDepth deep, and the
+// script must not run.
+func EnterScript(ctx context.Context) (context.Context, bool) {
+	depth, _ := ctx.Value(scriptDepthKey{}).(int)
+	if depth >= maxScriptDepth {
+		return ctx, false
+	}
+	return context.WithValue(ctx, scriptDepthKey{}, depth+1), true
+}
+
+// scriptFuncs implements assets.ScriptFuncs for script templates.
+var scriptFuncs = template.FuncMap{
+	"carries": scriptCarries,
+	"random":  scriptRandom,
+}
+
+// scriptTemplates caches parsed script templates by their text.
+var scriptTemplates sync.Map
+
+// scriptCarries reports whether actor has an object with the given
+// definition id in its inventory or equipment.
+func scriptCarries(actor Actor, objId string) bool {
+	if actor == nil {
+		return false
+	}
+	if actor.Inventory() != nil && actor.Inventory().FindObjByDef(objId) != nil {
+		return true
+	}
+	if actor.Equipment() == nil {
+		return false
+	}
+	return len(actor.Equipment().FindObjs(func(oi *ObjectInstance) bool { return oi.Object.Id() == objId })) > 0
+}
+
+// scriptRandom returns a number from 1 to n.
+func scriptRandom(n int) int {
+	if n < 1 {
+		return 0
+	}
+	return rand.IntN(n) + 1
+}
+
+// triggerState is the runtime state of the triggers on one mob, room or
+// object. The zero value is ready to use.
+type triggerState struct {
+	mu       sync.Mutex
+	vars     map[string]string
+	hpFired  map[int]bool // hp triggers fired since HP last rose above them
+	fighting bool         // whether the mob was in combat last tick
+}
+
+// snapshotVars returns a copy of the script variables.
+func (ts *triggerState) snapshotVars() map[string]string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return maps.Clone(ts.vars)
+}
+
+func (ts *triggerState) setVar(name, value string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.vars == nil {
+		ts.vars = make(map[string]string)
+	}
+	ts.vars[name] = value
+}
+
+// startFight records whether the mob is fighting, reporting true when it has
+// just been drawn in.
+func (ts *triggerState) startFight(fighting bool) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	started := fighting && !ts.fighting
+	ts.fighting = fighting
+	return started
+}
+
+// crossHP reports whether hp trigger i should fire at the given HP percent,
+// firing once each time HP falls to threshold and rearming above it.
+func (ts *triggerState) crossHP(i, percent, threshold int) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if percent > threshold {
+		delete(ts.hpFired, i)
+		return false
+	}
+	if ts.hpFired[i] {
+		return false
+	}
+	if ts.hpFired == nil {
+		ts.hpFired = make(map[int]bool)
+	}
+	ts.hpFired[i] = true
+	return true
+}
+
+// scriptOwner is the mob, room or object a trigger is attached to.
+type scriptOwner struct {
+	name     string
+	mob      *MobileInstance // nil unless a mob owns the trigger
+	room     *RoomInstance   // where echoes go
+	state    *triggerState
+	randIntN func(int) int
+}
+
+func mobOwner(mi *MobileInstance) scriptOwner {
+	return scriptOwner{name: mi.Name(), mob: mi, room: mi.Room(), state: &mi.triggers, randIntN: mi.randIntN}
+}
+
+func roomOwner(ri *RoomInstance) scriptOwner {
+	return scriptOwner{name: ri.Room.Get().Name, room: ri, state: &ri.triggers, randIntN: rand.IntN}
+}
+
+func objOwner(oi *ObjectInstance, room *RoomInstance) scriptOwner {
+	return scriptOwner{name: oi.ShortDesc(), room: room, state: &oi.triggers, randIntN: rand.IntN}
+}
+
+// scriptData is what script templates see.
+type scriptData struct {
+	Self   string            // the owner's name
+	Actor  Actor             // who set the trigger off; nil for random triggers
+	Object *ObjectInstance   // the item given, for receive triggers
+	Text   string            // what was said, or the command's arguments
+	Match  []string          // the pattern's submatches, whole match first
+	Vars   map[string]string // the owner's script variables
+}
+
+// scriptRun is one firing of a trigger's script.
+type scriptRun struct {
+	ctx   context.Context
+	owner scriptOwner
+	data  scriptData
+	allow bool
+}
+
+// fireTrigger runs t's script for owner if its chance comes up. It reports
+// whether the script ran and whether it let an intercepted command through.
+func fireTrigger(ctx context.Context, owner scriptOwner, t *assets.Trigger, data scriptData) (fired, allow bool) {
+	if t.Chance > 0 && owner.randIntN(100) >= t.Chance {
+		return false, false
+	}
+	ctx, ok := EnterScript(ctx)
+	if !ok {
+		slog.Warn("script depth limit reached", "owner", owner.name, "trigger", t.Type)
+		return false, false
+	}
+
+	data.Self = owner.name
+	data.Vars = owner.state.snapshotVars()
+	if data.Vars == nil {
+		data.Vars = make(map[string]string)
+	}
+	run := &scriptRun{
+		ctx:   ctx,
+		owner: owner,
+		data:  data,
+	}
+	if prog := t.Program(); prog != nil {
+		run.runCode(prog)
+	} else {
+		run.exec(t.Script)
+	}
+	return true, run.allow
+}
+
+// runCode runs trigger code with the TriggerCodeGlobals bound to the run.
+func (r *scriptRun) runCode(prog *scripting.Program) {
+	if err := prog.Run(r.ctx, r.codeGlobals()); err != nil {
+		slog.Warn("trigger code failed", "owner", r.owner.name, "error", err)
+	}
+}
+
+// codeGlobals returns the values of assets.TriggerCodeGlobals for the run.
+func (r *scriptRun) codeGlobals() starlark.StringDict {
+	match := make(starlark.Tuple, len(r.data.Match))
+	for i, m := range r.data.Match {
+		match[i] = starlark.String(m)
+	}
+	var self, room starlark.Value = starlark.None, starlark.None
+	if r.owner.mob != nil {
+		self = ScriptActor(r.ctx, r.owner.mob)
+	}
+	if r.owner.room != nil {
+		room = ScriptRoom(r.ctx, r.owner.room)
+	}
+	return starlark.StringDict{
+		"actor":  ScriptActor(r.ctx, r.data.Actor),
+		"self":   self,
+		"room":   room,
+		"object": ScriptObject(r.data.Object),
+		"text":   starlark.String(r.data.Text),
+		"match":  match,
+		"vars":   &starVars{state: r.owner.state},
+		"random": ScriptRandom,
+		"allow": starlark.NewBuiltin("allow", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+				return nil, err
+			}
+			r.allow = true
+			return starlark.None, nil
+		}),
+	}
+}
+
+// fireAll fires every trigger of the given type, with data from match when it
+// accepts the trigger.
+func fireAll(ctx context.Context, owner scriptOwner, ts []assets.Trigger, typ assets.TriggerType, match func(*assets.Trigger) (scriptData, bool)) {
+	for i := range ts {
+		if ts[i].Type != typ {
+			continue
+		}
+		if data, ok := match(&ts[i]); ok {
+			fireTrigger(ctx, owner, &ts[i], data)
+		}
+	}
+}
+
+// exec runs steps in order. Returns false once a stop step ends the script.
+func (r *scriptRun) exec(steps []assets.ScriptStep) bool {
+	for _, step := range steps {
+		switch {
+		case step.Stop:
+			return false
+		case step.Allow:
+			r.allow = true
+		case step.If != "":
+			cond, ok := r.render(assets.ScriptCondition(step.If))
+			if !ok {
+				continue
+			}
+			branch := step.Else
+			if cond == "true" {
+				branch = step.Then
+			}
+			if !r.exec(branch) {
+				return false
+			}
+		case step.Set != "":
+			if value, ok := r.render(step.Value); ok {
+				r.owner.state.setVar(step.Set, value)
+				r.data.Vars[step.Set] = value
+			}
+		case step.Do != "":
+			if r.owner.mob != nil {
+				r.command(r.owner.mob, step.Do)
+			}
+		case step.Force != "":
+			if r.data.Actor != nil {
+				r.command(r.data.Actor, step.Force)
+			}
+		case step.Echo != "":
+			if text, ok := r.render(step.Echo); ok && r.owner.room != nil {
+				r.owner.room.Publish([]byte(text), nil)
+			}
+		case step.Send != "":
+			if text, ok := r.render(step.Send); ok && r.data.Actor != nil {
+				r.data.Actor.Publish([]byte(text), nil)
+			}
+		}
+	}
+	return true
+}
+
+// command renders line and runs it as actor.
+func (r *scriptRun) command(actor Actor, line string) {
+	if text, ok := r.render(line); ok {
+		runCommand(r.ctx, actor, text)
+	}
+}
+
+// render executes a script template against the run's data. Failures are
+// logged and reported as not ok.
+func (r *scriptRun) render(text string) (string, bool) {
+	out, err := renderScript(text, r.data)
+	if err != nil {
+		slog.Warn("script template failed", "owner", r.owner.name, "template", text, "error", err)
+		return "", false
+	}
+	return out, true
+}
+
+// renderScript executes a script template against data.
+func renderScript(text string, data scriptData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := scriptTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func scriptTemplate(text string) (*template.Template, error) {
+	if tmpl, ok := scriptTemplates.Load(text); ok {
+		return tmpl.(*template.Template), nil
+	}
+	tmpl, err := assets.ParseScriptTemplate(text, scriptFuncs)
+	if err != nil {
+		return nil, err
+	}
+	scriptTemplates.Store(text, tmpl)
+	return tmpl, nil
+}
+
+// --- Events ---
+
+// FireArrival runs the room's enter triggers for an actor that just walked
+// in, and when the actor is a player, the greet triggers of the mobs there.
+// A player's visit also counts toward their quests.
+func FireArrival(ctx context.Context, actor Actor, room *RoomInstance) {
+	if room == nil {
+		return
+	}
+	if ci, ok := actor.(*CharacterInstance); ok {
+		ci.advanceQuests(questEvent{kind: assets.QuestObjectiveVisit, id: room.Room.Id()}, ci.publishText)
+	}
+	fireAll(ctx, roomOwner(room), room.Room.Get().Triggers, assets.TriggerEnter, func(*assets.Trigger) (scriptData, bool) {
+		return scriptData{Actor: actor}, true
+	})
+	if !actor.IsCharacter() {
+		return
+	}
+	for _, mi := range scriptMobs(room, nil) {
+		if mi.IsInCombat() {
+			continue
+		}
+		fireAll(ctx, mobOwner(mi), mi.Mobile.Get().Triggers, assets.TriggerGreet, func(*assets.Trigger) (scriptData, bool) {
+			return scriptData{Actor: actor}, true
+		})
+	}
+}
+
+// FireSpeech runs the speech triggers of the room and its mobs for something
+// a player said aloud there.
+func FireSpeech(ctx context.Context, speaker Actor, room *RoomInstance, text string) {
+	if room == nil || !speaker.IsCharacter() {
+		return
+	}
+	match := func(t *assets.Trigger) (scriptData, bool) {
+		m, ok := t.Match(text)
+		return scriptData{Actor: speaker, Text: text, Match: m}, ok
+	}
+	fireAll(ctx, roomOwner(room), room.Room.Get().Triggers, assets.TriggerSpeech, match)
+	for _, mi := range scriptMobs(room, nil) {
+		fireAll(ctx, mobOwner(mi), mi.Mobile.Get().Triggers, assets.TriggerSpeech, match)
+	}
+}
+
+// FireReceive runs the receive triggers of a mob a player just gave oi to.
+func FireReceive(ctx context.Context, giver Actor, mi *MobileInstance, oi *ObjectInstance) {
+	if !giver.IsCharacter() || !mi.IsAlive() {
+		return
+	}
+	fireAll(ctx, mobOwner(mi), mi.Mobile.Get().Triggers, assets.TriggerReceive, func(t *assets.Trigger) (scriptData, bool) {
+		ok := t.Object.Id() == "" || t.Object.Id() == oi.Object.Id()
+		return scriptData{Actor: giver, Object: oi}, ok
+	})
+}
+
+// InterceptCommand offers a player's command to the command triggers around
+// them: items they carry, items on the floor, mobs in the room, then the room
+// itself. The first trigger that fires decides, and the command is swallowed
+// unless its script allows it. Returns true if the command was swallowed.
+func InterceptCommand(ctx context.Context, actor Actor, cmd string, args ...string) bool {
+	room := actor.Room()
+	if room == nil || !actor.IsCharacter() {
+		return false
+	}
+	text := strings.Join(args, " ")
+	offer := func(owner scriptOwner, ts []assets.Trigger) (handled, swallowed bool) {
+		for i := range ts {
+			if ts[i].Type != assets.TriggerCommand {
+				continue
+			}
+			m, ok := ts[i].Match(cmd)
+			if !ok {
+				continue
+			}
+			if fired, allow := fireTrigger(ctx, owner, &ts[i], scriptData{Actor: actor, Text: text, Match: m}); fired {
+				return true, !allow
+			}
+		}
+		return false, false
+	}
+
+	var objs []*ObjectInstance
+	all := func(*ObjectInstance) bool { return true }
+	objs = append(objs, actor.Inventory().FindObjs(all)...)
+	objs = append(objs, actor.Equipment().FindObjs(all)...)
+	objs = append(objs, room.FindObjs(all)...)
+	for _, oi := range objs {
+		if handled, swallowed := offer(objOwner(oi, room), oi.Object.Get().Triggers); handled {
+			return swallowed
+		}
+	}
+	for _, mi := range scriptMobs(room, actor) {
+		if handled, swallowed := offer(mobOwner(mi), mi.Mobile.Get().Triggers); handled {
+			return swallowed
+		}
+	}
+	handled, swallowed := offer(roomOwner(room), room.Room.Get().Triggers)
+	return handled && swallowed
+}
+
+// scriptMobs returns the living mobs in room that have triggers, other than
+// skip.
+func scriptMobs(room *RoomInstance, skip Actor) []*MobileInstance {
+	return room.FindMobs(func(mi *MobileInstance) bool {
+		return Actor(mi) != skip && mi.IsAlive() && len(mi.Mobile.Get().Triggers) > 0
+	})
+}
+
+// triggerTick runs the mob's fight, hp and random triggers for this tick.
+func (mi *MobileInstance) triggerTick(ctx context.Context) {
+	ts := mi.Mobile.Get().Triggers
+	if len(ts) == 0 || !mi.IsAlive() {
+		return
+	}
+	owner := mobOwner(mi)
+	fighting := mi.IsInCombat()
+	if mi.triggers.startFight(fighting) {
+		fireAll(ctx, owner, ts, assets.TriggerFight, func(*assets.Trigger) (scriptData, bool) {
+			return scriptData{Actor: mi.CombatTarget()}, true
+		})
+	}
+
+	cur, maxHP := mi.Resource(assets.ResourceHp)
+	if maxHP > 0 {
+		percent := cur * 100 / maxHP
+		for i := range ts {
+			if ts[i].Type == assets.TriggerHP && mi.triggers.crossHP(i, percent, ts[i].Percent) {
+				fireTrigger(ctx, owner, &ts[i], scriptData{Actor: mi.CombatTarget()})
+			}
+		}
+	}
+
+	if !fighting && owner.room != nil && owner.room.PlayerCount() > 0 {
+		fireAll(ctx, owner, ts, assets.TriggerRandom, func(*assets.Trigger) (scriptData, bool) {
+			return scriptData{}, true
+		})
+	}
+}
+
+// fireDeath runs the mob's death triggers, with killer as the actor.
+func (mi *MobileInstance) fireDeath(ctx context.Context, killer Actor) {
+	fireAll(ctx, mobOwner(mi), mi.Mobile.Get().Triggers, assets.TriggerDeath, func(*assets.Trigger) (scriptData, bool) {
+		return scriptData{Actor: killer}, true
+	})
+}
+
+// triggerTick runs the room's random triggers while players are in it.
+func (ri *RoomInstance) triggerTick(ctx context.Context) {
+	ts := ri.Room.Get().Triggers
+	if len(ts) == 0 || ri.PlayerCount() == 0 {
+		return
+	}
+	fireAll(ctx, roomOwner(ri), ts, assets.TriggerRandom, func(*assets.Trigger) (scriptData, bool) {
+		return scriptData{}, true
+	})
+}
@@ -0,0 +1,377 @@
+package game
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// scriptScene is a room holding a player and a guard for trigger tests.
+type scriptScene struct {
+	room       *RoomInstance
+	player     *CharacterInstance
+	guard      *MobileInstance
+	msgs       chan []byte
+	playerCmds *fakeCommander
+	guardCmds  *fakeCommander
+}
+
+// newScriptScene builds a room with the given triggers and puts a player
+// named Bob and a guard with guardTriggers in it.
+func newScriptScene(roomTriggers, guardTriggers []assets.Trigger) *scriptScene {
+	for _, ts := range [][]assets.Trigger{roomTriggers, guardTriggers} {
+		for i := range ts {
+			_ = ts[i].Compile()
+		}
+	}
+	ri, _ := NewRoomInstance(storage.NewResolvedSmartIdentifier("hall", &assets.Room{Name: "Hall", Triggers: roomTriggers}))
+	s := &scriptScene{
+		room:       ri,
+		msgs:       make(chan []byte, 16),
+		playerCmds: &fakeCommander{},
+		guardCmds:  &fakeCommander{},
+	}
+
+	s.player = newTestCI("bob", "Bob")
+	s.player.msgs = s.msgs
+	s.player.inventory = NewInventory()
+	s.player.equipment = NewEquipment()
+	s.player.commander = s.playerCmds
+	s.player.room = ri
+	ri.AddPlayer(s.player.Id(), s.player)
+
+	s.guard = newEnemyMI("guard")
+	s.guard.Mobile.Get().ShortDesc = "the guard"
+	s.guard.Mobile.Get().Triggers = guardTriggers
+	s.guard.commander = s.guardCmds
+	ri.AddMob(s.guard)
+	return s
+}
+
+// drain returns the messages sent to the player so far.
+func (s *scriptScene) drain() []string {
+	var out []string
+	for {
+		select {
+		case m := <-s.msgs:
+			out = append(out, string(m))
+		default:
+			return out
+		}
+	}
+}
+
+func speech(pattern string, script ...assets.ScriptStep) assets.Trigger {
+	return assets.Trigger{Type: assets.TriggerSpeech, Pattern: pattern, Script: script}
+}
+
+func TestFireSpeech(t *testing.T) {
+	tests := map[string]struct {
+		guard      []assets.Trigger
+		room       []assets.Trigger
+		said       string
+		carrying   string
+		roll       int
+		wantMsgs   []string
+		wantDo     []string
+		wantForced []string
+	}{
+		"send names the speaker": {
+			guard:    []assets.Trigger{speech("hello", assets.ScriptStep{Send: "Well met, {{ .Actor.Name }}."})},
+			said:     "Hello there",
+			wantMsgs: []string{"Well met, Bob."},
+		},
+		"echo reaches the room": {
+			guard:    []assets.Trigger{speech("", assets.ScriptStep{Echo: "{{ .Self }} nods."})},
+			said:     "anything",
+			wantMsgs: []string{"the guard nods."},
+		},
+		"room triggers hear speech too": {
+			room:     []assets.Trigger{speech("echo", assets.ScriptStep{Echo: "{{ .Text }}... {{ .Text }}..."})},
+			said:     "echo",
+			wantMsgs: []string{"echo... echo..."},
+		},
+		"pattern submatches": {
+			guard:    []assets.Trigger{speech(`password is (\w+)`, assets.ScriptStep{Send: "{{ index .Match 1 }}? Pass."})},
+			said:     "the password is swordfish",
+			wantMsgs: []string{"swordfish? Pass."},
+		},
+		"pattern not matched": {
+			guard: []assets.Trigger{speech("goodbye", assets.ScriptStep{Send: "Farewell."})},
+			said:  "hello",
+		},
+		"if takes then": {
+			guard: []assets.Trigger{speech("", assets.ScriptStep{
+				If:   `carries .Actor "pass"`,
+				Then: []assets.ScriptStep{{Send: "Go ahead."}},
+				Else: []assets.ScriptStep{{Send: "Halt!"}},
+			})},
+			said:     "let me by",
+			carrying: "pass",
+			wantMsgs: []string{"Go ahead."},
+		},
+		"if takes else": {
+			guard: []assets.Trigger{speech("", assets.ScriptStep{
+				If:   `carries .Actor "pass"`,
+				Then: []assets.ScriptStep{{Send: "Go ahead."}},
+				Else: []assets.ScriptStep{{Send: "Halt!"}},
+			})},
+			said:     "let me by",
+			wantMsgs: []string{"Halt!"},
+		},
+		"set is readable later in the script": {
+			guard: []assets.Trigger{speech("",
+				assets.ScriptStep{Set: "last", Value: "{{ .Actor.Name }}"},
+				assets.ScriptStep{Send: "Noted, {{ .Vars.last }}."},
+			)},
+			said:     "hi",
+			wantMsgs: []string{"Noted, Bob."},
+		},
+		"stop ends the script": {
+			guard: []assets.Trigger{speech("",
+				assets.ScriptStep{If: "true", Then: []assets.ScriptStep{{Send: "one"}, {Stop: true}}},
+				assets.ScriptStep{Send: "two"},
+			)},
+			said:     "hi",
+			wantMsgs: []string{"one"},
+		},
+		"chance missed": {
+			guard: []assets.Trigger{{Type: assets.TriggerSpeech, Chance: 50, Script: []assets.ScriptStep{{Send: "Hm?"}}}},
+			said:  "hi",
+			roll:  50,
+		},
+		"do and force run commands": {
+			guard: []assets.Trigger{speech("",
+				assets.ScriptStep{Do: "bow {{ .Actor.Name }}"},
+				assets.ScriptStep{Force: "kneel"},
+			)},
+			said:       "hi",
+			wantDo:     []string{"bow"},
+			wantForced: []string{"kneel"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(tc.room, tc.guard)
+			s.guard.randIntN = func(int) int { return tc.roll }
+			if tc.carrying != "" {
+				s.player.inventory.AddObj(newTestObj(tc.carrying))
+			}
+
+			FireSpeech(context.Background(), s.player, s.room, tc.said)
+
+			if got := s.drain(); !slices.Equal(got, tc.wantMsgs) {
+				t.Errorf("messages = %q, want %q", got, tc.wantMsgs)
+			}
+			if !slices.Equal(s.guardCmds.commands, tc.wantDo) {
+				t.Errorf("guard commands = %v, want %v", s.guardCmds.commands, tc.wantDo)
+			}
+			if !slices.Equal(s.playerCmds.commands, tc.wantForced) {
+				t.Errorf("player commands = %v, want %v", s.playerCmds.commands, tc.wantForced)
+			}
+		})
+	}
+}
+
+func TestFireSpeech_varsPersist(t *testing.T) {
+	s := newScriptScene(nil, []assets.Trigger{speech("",
+		assets.ScriptStep{
+			If:   `eq .Vars.met "yes"`,
+			Then: []assets.ScriptStep{{Send: "You again."}},
+			Else: []assets.ScriptStep{{Send: "A stranger."}, {Set: "met", Value: "yes"}},
+		},
+	)})
+
+	FireSpeech(context.Background(), s.player, s.room, "hi")
+	FireSpeech(context.Background(), s.player, s.room, "hi")
+
+	if got, want := s.drain(), []string{"A stranger.", "You again."}; !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
+
+func TestFireSpeech_mobSpeakerIgnored(t *testing.T) {
+	s := newScriptScene([]assets.Trigger{speech("", assets.ScriptStep{Echo: "heard"})}, nil)
+
+	FireSpeech(context.Background(), s.guard, s.room, "hi")
+
+	if got := s.drain(); len(got) != 0 {
+		t.Errorf("messages = %q, want none", got)
+	}
+}
+
+func TestFireReceive(t *testing.T) {
+	thanks := []assets.ScriptStep{{Send: "Thanks for {{ .Object.ShortDesc }}."}}
+	tests := map[string]struct {
+		wants    string
+		given    string
+		wantMsgs []string
+	}{
+		"any item":   {given: "apple", wantMsgs: []string{"Thanks for apple."}},
+		"wanted":     {wants: "letter", given: "letter", wantMsgs: []string{"Thanks for letter."}},
+		"not wanted": {wants: "letter", given: "apple"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			trig := assets.Trigger{Type: assets.TriggerReceive, Script: thanks}
+			if tc.wants != "" {
+				trig.Object = storage.NewSmartIdentifier[*assets.Object](tc.wants)
+			}
+			s := newScriptScene(nil, []assets.Trigger{trig})
+
+			FireReceive(context.Background(), s.player, s.guard, newTestObj(tc.given))
+
+			if got := s.drain(); !slices.Equal(got, tc.wantMsgs) {
+				t.Errorf("messages = %q, want %q", got, tc.wantMsgs)
+			}
+		})
+	}
+}
+
+func TestFireArrival(t *testing.T) {
+	s := newScriptScene(
+		[]assets.Trigger{{Type: assets.TriggerEnter, Script: []assets.ScriptStep{{Send: "The floor creaks."}}}},
+		[]assets.Trigger{{Type: assets.TriggerGreet, Script: []assets.ScriptStep{{Send: "Welcome, {{ .Actor.Name }}."}}}},
+	)
+
+	FireArrival(context.Background(), s.player, s.room)
+
+	if got, want := s.drain(), []string{"The floor creaks.", "Welcome, Bob."}; !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
+
+func TestInterceptCommand(t *testing.T) {
+	pull := func(script ...assets.ScriptStep) []assets.Trigger {
+		return []assets.Trigger{{Type: assets.TriggerCommand, Pattern: "pull|yank", Script: script}}
+	}
+	tests := map[string]struct {
+		room     []assets.Trigger
+		guard    []assets.Trigger
+		carried  []assets.Trigger
+		cmd      string
+		args     []string
+		want     bool
+		wantMsgs []string
+	}{
+		"room swallows the command": {
+			room:     pull(assets.ScriptStep{Send: "You {{ index .Match 0 }} the {{ .Text }}."}),
+			cmd:      "YANK",
+			args:     []string{"chain"},
+			want:     true,
+			wantMsgs: []string{"You YANK the chain."},
+		},
+		"whole word only": {
+			room: pull(assets.ScriptStep{Send: "Click."}),
+			cmd:  "pullover",
+		},
+		"allow lets the command through": {
+			room:     pull(assets.ScriptStep{Send: "Click."}, assets.ScriptStep{Allow: true}),
+			cmd:      "pull",
+			wantMsgs: []string{"Click."},
+		},
+		"carried items go first": {
+			room:     pull(assets.ScriptStep{Send: "room"}),
+			carried:  pull(assets.ScriptStep{Send: "item"}),
+			cmd:      "pull",
+			want:     true,
+			wantMsgs: []string{"item"},
+		},
+		"mobs before the room": {
+			room:     pull(assets.ScriptStep{Send: "room"}),
+			guard:    pull(assets.ScriptStep{Send: "Hands off."}),
+			cmd:      "pull",
+			want:     true,
+			wantMsgs: []string{"Hands off."},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newScriptScene(tc.room, tc.guard)
+			if tc.carried != nil {
+				s.player.inventory.AddObj(&ObjectInstance{
+					InstanceId: "rope",
+					Object:     storage.NewResolvedSmartIdentifier("rope", &assets.Object{ShortDesc: "a rope", Triggers: tc.carried}),
+				})
+			}
+
+			if got := InterceptCommand(context.Background(), s.player, tc.cmd, tc.args...); got != tc.want {
+				t.Errorf("InterceptCommand() = %v, want %v", got, tc.want)
+			}
+			if got := s.drain(); !slices.Equal(got, tc.wantMsgs) {
+				t.Errorf("messages = %q, want %q", got, tc.wantMsgs)
+			}
+		})
+	}
+}
+
+func TestInterceptCommand_mobsPassThrough(t *testing.T) {
+	s := newScriptScene([]assets.Trigger{{Type: assets.TriggerCommand, Pattern: "pull", Script: []assets.ScriptStep{{Echo: "Click."}}}}, nil)
+
+	if InterceptCommand(context.Background(), s.guard, "pull") {
+		t.Error("InterceptCommand() swallowed a mob's command")
+	}
+}
+
+func TestFireTrigger_depthLimit(t *testing.T) {
+	s := newScriptScene(nil, nil)
+	trig := &assets.Trigger{Type: assets.TriggerSpeech, Script: []assets.ScriptStep{{Echo: "again"}}}
+	ctx := context.WithValue(context.Background(), scriptDepthKey{}, maxScriptDepth)
+
+	if fired, _ := fireTrigger(ctx, roomOwner(s.room), trig, scriptData{}); fired {
+		t.Error("fireTrigger() ran past the depth limit")
+	}
+}
+
+func TestMobileInstance_triggerTick(t *testing.T) {
+	echo := func(text string) []assets.ScriptStep { return []assets.ScriptStep{{Echo: text}} }
+	s := newScriptScene(nil, []assets.Trigger{
+		{Type: assets.TriggerFight, Script: echo("fight")},
+		{Type: assets.TriggerHP, Percent: 50, Script: echo("hurt")},
+		{Type: assets.TriggerRandom, Chance: 100, Script: echo("idle")},
+	})
+	s.guard.randIntN = zeroRand
+
+	steps := []struct {
+		hp       int
+		fighting bool
+		want     []string
+	}{
+		{hp: 10, want: []string{"idle"}},
+		{hp: 10, fighting: true, want: []string{"fight"}},
+		{hp: 5, fighting: true, want: []string{"hurt"}},
+		{hp: 3, fighting: true},
+		{hp: 8, fighting: true},
+		{hp: 4, want: []string{"hurt", "idle"}},
+		{hp: 4, fighting: true, want: []string{"fight"}},
+	}
+	for i, step := range steps {
+		s.guard.SetResource(assets.ResourceHp, step.hp)
+		if step.fighting {
+			s.guard.EnsureThreat(s.player.Id(), s.player)
+		} else {
+			s.guard.ClearThreatTable()
+		}
+
+		s.guard.triggerTick(context.Background())
+
+		if got := s.drain(); !slices.Equal(got, step.want) {
+			t.Errorf("step %d: messages = %q, want %q", i, got, step.want)
+		}
+	}
+}
+
+func TestProcessDeath_deathTrigger(t *testing.T) {
+	s := newScriptScene(nil, []assets.Trigger{{Type: assets.TriggerDeath, Script: []assets.ScriptStep{
+		{Send: "{{ .Self }} curses you, {{ .Actor.Name }}."},
+	}}})
+
+	processDeath(context.Background(), s.guard, s.player, s.room)
+
+	if got, want := s.drain(), []string{"the guard curses you, Bob."}; !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
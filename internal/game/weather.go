@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// Sky is the visible state of a zone's weather.
+type Sky int
+
+const (
+	SkyCloudless Sky = iota
+	SkyCloudy
+	SkyRaining
+	SkyLightning
+)
+
+// Pressure bounds and the month at which the seasons turn toward winter.
+const (
+	minPressure   = 960
+	maxPressure   = 1040
+	maxChange     = 12
+	autumnMonth   = 9
+	startPressure = 1000
+)
+
+// Weather is a zone's barometric weather, evolved once per game hour using
+// CircleMUD's pressure model: pressure drifts toward a seasonal norm and the
+// sky clears or clouds over as it crosses thresholds.
+//
+// Weather is safe for concurrent use.
+type Weather struct {
+	mu       sync.RWMutex
+	pressure int
+	change   int
+	sky      Sky
+
+	randIntN func(int) int // source of randomness; defaults to rand.IntN
+}
+
+// NewWeather creates clear weather at average pressure.
+func NewWeather() *Weather {
+	return &Weather{pressure: startPressure, randIntN: rand.IntN}
+}
+
+// Sky returns the current sky.
+func (w *Weather) Sky() Sky {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sky
+}
+
+// Improving reports whether pressure is steady or rising.
+func (w *Weather) Improving() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.change >= 0
+}
+
+// dice rolls n dice with the given number of sides.
+func (w *Weather) dice(n, sides int) int {
+	total := 0
+	for range n {
+		total += w.randIntN(sides) + 1
+	}
+	return total
+}
+
+// Tick evolves the weather by one game hour in the given month and returns
+// the message announcing a change of sky, or "" if the sky held.
+func (w *Weather) Tick(month int) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	norm := 1015
+	if month >= autumnMonth {
+		norm = 985
+	}
+	diff := 2
+	if w.pressure > norm {
+		diff = -2
+	}
+	w.change += w.dice(1, 4)*diff + w.dice(2, 6) - w.dice(2, 6)
+	w.change = min(max(w.change, -maxChange), maxChange)
+	w.pressure = min(max(w.pressure+w.change, minPressure), maxPressure)
+
+	chance := w.dice(1, 4) == 1
+	switch w.sky {
+	case SkyCloudless:
+		if w.pressure < 990 || (w.pressure < 1010 && chance) {
+			w.sky = SkyCloudy
+			return "The sky starts to get cloudy."
+		}
+	case SkyCloudy:
+		if w.pressure < 970 || (w.pressure < 990 && chance) {
+			w.sky = SkyRaining
+			return "It starts to rain."
+		}
+		if w.pressure > 1030 && chance {
+			w.sky = SkyCloudless
+			return "The clouds disappear."
+		}
+	case SkyRaining:
+		if w.pressure < 970 && chance {
+			w.sky = SkyLightning
+			return "Lightning starts to show in the sky."
+		}
+		if w.pressure > 1030 || (w.pressure > 1010 && chance) {
+			w.sky = SkyCloudy
+			return "The rain stops."
+		}
+	case SkyLightning:
+		if w.pressure > 1010 || (w.pressure > 990 && chance) {
+			w.sky = SkyRaining
+			return "The lightning stops."
+		}
+	}
+	return ""
+}
+
+// String describes the weather as an outdoor observer feels it.
+func (w *Weather) String() string {
+	look := [...]string{"cloudless", "cloudy", "rainy", "lit by flashes of lightning"}[w.Sky()]
+	feel := "your foot tells you bad weather is due"
+	if w.Improving() {
+		feel = "you feel a warm wind from the south"
+	}
+	return "The sky is " + look + " and " + feel + "."
+}
@@ -0,0 +1,47 @@
+package game
+
+import "testing"
+
+func TestWeather_Tick(t *testing.T) {
+	tests := map[string]struct {
+		sky      Sky
+		pressure int
+		roll     int // every die lands on roll+1
+		wantSky  Sky
+		wantMsg  string
+	}{
+		"low pressure clouds over": {
+			sky: SkyCloudless, pressure: 975, roll: 3,
+			wantSky: SkyCloudy, wantMsg: "The sky starts to get cloudy.",
+		},
+		"clouds break to rain": {
+			sky: SkyCloudy, pressure: 960, roll: 3,
+			wantSky: SkyRaining, wantMsg: "It starts to rain.",
+		},
+		"high pressure stops the rain": {
+			sky: SkyRaining, pressure: 1040, roll: 3,
+			wantSky: SkyCloudy, wantMsg: "The rain stops.",
+		},
+		"steady sky holds": {
+			sky: SkyCloudless, pressure: 1020, roll: 3,
+			wantSky: SkyCloudless,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := NewWeather()
+			w.sky, w.pressure = tc.sky, tc.pressure
+			w.randIntN = func(int) int { return tc.roll }
+
+			if got := w.Tick(0); got != tc.wantMsg {
+				t.Errorf("Tick() = %q, want %q", got, tc.wantMsg)
+			}
+			if w.Sky() != tc.wantSky {
+				t.Errorf("sky = %d, want %d", w.Sky(), tc.wantSky)
+			}
+			if w.pressure < minPressure || w.pressure > maxPressure {
+				t.Errorf("pressure %d out of bounds", w.pressure)
+			}
+		})
+	}
+}
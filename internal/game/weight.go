@@ -0,0 +1,139 @@
+package game
+
+import "github.com/pixil98/go-mud/internal/assets"
+
+// CarryPerSTR is the weight a character can carry per point of STR, before
+// any core.carry.max bonus.
+const CarryPerSTR = 15
+
+// encumberedPct is the share of the carry limit above which a character is
+// encumbered.
+const encumberedPct = 60
+
+// encumbranceSource is the perk source a character carries while
+// encumbered: clumsier attacks and an easier target.
+var encumbranceSource = NewPerkCache([]assets.Perk{
+	{Type: assets.PerkTypeModifier, Key: assets.BuildKey(assets.CombatAttackPrefix, assets.ModSuffixFlat), Value: -2},
+	{Type: assets.PerkTypeModifier, Key: assets.BuildKey(assets.CombatACPrefix, assets.ModSuffixFlat), Value: -2},
+}, nil)
+
+// TotalWeight returns the object's weight plus everything inside it.
+func (oi *ObjectInstance) TotalWeight() int {
+	w := oi.Object.Get().Weight
+	if oi.Contents != nil {
+		w += oi.Contents.Weight()
+	}
+	return w
+}
+
+// CanHold reports whether a container has room for w more weight.
+// Containers without a capacity hold anything.
+func (oi *ObjectInstance) CanHold(w int) bool {
+	capacity := oi.Object.Get().Capacity
+	if oi.Contents == nil {
+		return false
+	}
+	return capacity == 0 || oi.Contents.Weight()+w <= capacity
+}
+
+// Weight returns the total weight of every item, including container contents.
+func (inv *Inventory) Weight() int {
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	w := 0
+	for _, oi := range inv.objs {
+		w += oi.TotalWeight()
+	}
+	return w
+}
+
+// Weight returns the total weight of every equipped item.
+func (eq *Equipment) Weight() int {
+	eq.mu.RLock()
+	defer eq.mu.RUnlock()
+	w := 0
+	for _, slot := range eq.objs {
+		if slot.Obj != nil {
+			w += slot.Obj.TotalWeight()
+		}
+	}
+	return w
+}
+
+// CarriedWeight returns the weight of everything the character carries or wears.
+func (ci *CharacterInstance) CarriedWeight() int {
+	w := 0
+	if ci.inventory != nil {
+		w += ci.inventory.Weight()
+	}
+	if ci.equipment != nil {
+		w += ci.equipment.Weight()
+	}
+	return w
+}
+
+// Carries reports whether the character carries or wears oi, or carries
+// it inside a container.
+func (ci *CharacterInstance) Carries(oi *ObjectInstance) bool {
+	return (ci.inventory != nil && ci.inventory.holds(oi)) ||
+		(ci.equipment != nil && ci.equipment.holds(oi))
+}
+
+// holds reports whether oi is in the inventory or inside a container in it.
+func (inv *Inventory) holds(oi *ObjectInstance) bool {
+	return len(inv.FindObjs(func(o *ObjectInstance) bool {
+		return o == oi || (o.Contents != nil && o.Contents.holds(oi))
+	})) > 0
+}
+
+// holds reports whether oi is equipped or inside an equipped container.
+func (eq *Equipment) holds(oi *ObjectInstance) bool {
+	return len(eq.FindObjs(func(o *ObjectInstance) bool {
+		return o == oi || (o.Contents != nil && o.Contents.holds(oi))
+	})) > 0
+}
+
+// CarryLimit returns the most weight the character can carry: STR times
+// CarryPerSTR plus any core.carry.max modifier.
+func (ci *CharacterInstance) CarryLimit() int {
+	str := int(ci.EffectiveStats()[assets.StatSTR])
+	return max(0, str*CarryPerSTR+ci.ModifierValue(assets.PerkKeyCarryMax))
+}
+
+// CanCarry reports whether the character can take on w more weight.
+func (ci *CharacterInstance) CanCarry(w int) bool {
+	return ci.CarriedWeight()+w <= ci.CarryLimit()
+}
+
+// IsEncumbered reports whether the character is carrying more than
+// encumberedPct of their carry limit.
+func (ci *CharacterInstance) IsEncumbered() bool {
+	return ci.CarriedWeight()*100 > ci.CarryLimit()*encumberedPct
+}
+
+// refreshEncumbrance adds or removes the encumbrance penalty to match the
+// character's load. The load is read and the penalty changed under ci.mu, so
+// of two overlapping refreshes the last one always leaves the flag and the
+// penalty in step. Caller must not hold ci.mu.
+func (ci *CharacterInstance) refreshEncumbrance() {
+	ci.mu.Lock()
+	enc := ci.IsEncumbered()
+	changed := ci.encumbered != enc
+	ci.encumbered = enc
+	if changed {
+		if enc {
+			ci.AddSource("encumbrance", encumbranceSource)
+		} else {
+			ci.RemoveSource("encumbrance")
+		}
+	}
+	ci.mu.Unlock()
+	if !changed {
+		return
+	}
+	if enc {
+		ci.QueueTickMsg("You are weighed down by your load.")
+	} else {
+		ci.QueueTickMsg("You are no longer encumbered.")
+	}
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/pixil98/go-mud/internal/assets"
+	"github.com/pixil98/go-mud/internal/storage"
+)
+
+// newTestWeighted creates an object with the given weight and, when
+// capacity is non-zero, a container with that capacity.
+func newTestWeighted(id string, weight, capacity int) *ObjectInstance {
+	def := &assets.Object{Aliases: []string{id}, ShortDesc: id, Weight: weight, Capacity: capacity}
+	if capacity > 0 {
+		def.Flags = []string{"container"}
+	}
+	oi, _ := NewObjectInstance(storage.NewResolvedSmartIdentifier(id, def))
+	return oi
+}
+
+func TestObjectInstance_Weight(t *testing.T) {
+	tests := map[string]struct {
+		contents  []int
+		add       int
+		wantTotal int
+		wantHold  bool
+	}{
+		"empty container": {
+			add: 10, wantTotal: 2, wantHold: true,
+		},
+		"contents count toward total": {
+			contents: []int{3, 4}, add: 3, wantTotal: 9, wantHold: true,
+		},
+		"full container refuses more": {
+			contents: []int{6, 4}, add: 1, wantTotal: 12, wantHold: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bag := newTestWeighted("bag", 2, 10)
+			for i, w := range tc.contents {
+				bag.Contents.AddObj(newTestWeighted(string(rune('a'+i)), w, 0))
+			}
+			if got := bag.TotalWeight(); got != tc.wantTotal {
+				t.Errorf("TotalWeight() = %d, want %d", got, tc.wantTotal)
+			}
+			if got := bag.CanHold(tc.add); got != tc.wantHold {
+				t.Errorf("CanHold(%d) = %v, want %v", tc.add, got, tc.wantHold)
+			}
+		})
+	}
+}
+
+func TestCharacterInstance_Encumbrance(t *testing.T) {
+	attackKey := assets.BuildKey(assets.CombatAttackPrefix, assets.ModSuffixFlat)
+	tests := map[string]struct {
+		carryMax     int
+		weights      []int
+		wantLimit    int
+		wantCanCarry bool
+		wantEnc      bool
+	}{
+		"light load": {
+			weights: []int{10}, wantLimit: 150, wantCanCarry: true,
+		},
+		"heavy load is encumbered": {
+			weights: []int{60, 40}, wantLimit: 150, wantCanCarry: true, wantEnc: true,
+		},
+		"carry bonus raises the limit": {
+			carryMax: 100, weights: []int{100}, wantLimit: 250, wantCanCarry: true,
+		},
+		"at the limit": {
+			weights: []int{150}, wantLimit: 150, wantCanCarry: false, wantEnc: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ci := newTestCI("c1", "Alice")
+			ci.Character.Get().BaseStats = map[assets.StatKey]int{assets.StatSTR: 10}
+			ci.PerkCache = *NewPerkCache([]assets.Perk{
+				{Type: assets.PerkTypeModifier, Key: assets.PerkKeyCarryMax, Value: tc.carryMax},
+			}, nil)
+			ci.inventory = NewInventory()
+			for i, w := range tc.weights {
+				ci.inventory.AddObj(newTestWeighted(string(rune('a'+i)), w, 0))
+			}
+
+			if got := ci.CarryLimit(); got != tc.wantLimit {
+				t.Errorf("CarryLimit() = %d, want %d", got, tc.wantLimit)
+			}
+			if got := ci.CanCarry(1); got != tc.wantCanCarry {
+				t.Errorf("CanCarry(1) = %v, want %v", got, tc.wantCanCarry)
+			}
+
+			ci.refreshEncumbrance()
+			if got := ci.ModifierValue(attackKey) < 0; got != tc.wantEnc {
+				t.Errorf("encumbrance penalty applied = %v, want %v", got, tc.wantEnc)
+			}
+
+			ci.inventory.Drain()
+			ci.refreshEncumbrance()
+			if ci.ModifierValue(attackKey) != 0 {
+				t.Error("encumbrance penalty remains after dropping everything")
+			}
+		})
+	}
+}
@@ -17,7 +17,10 @@ type WorldState struct {
 
 	zones            map[string]*ZoneInstance
 	perks            *PerkCache
+	sky              *PerkCache // perks for outdoor rooms only, e.g. night darkness
+	calendar         *Calendar
 	commanderFactory CommanderFactory
+	objectHooks      []ObjectHook
 }
 
 // SetCommanderFactory sets the factory used to create per-actor Commanders
@@ -58,9 +61,11 @@ func NewWorldState(zones storage.Storer[*assets.Zone], rooms storage.Storer[*ass
 	worldPerks := NewPerkCache(nil, nil)
 
 	w := &WorldState{
-		players: make(map[string]*CharacterInstance),
-		zones:   make(map[string]*ZoneInstance),
-		perks:   worldPerks,
+		players:  make(map[string]*CharacterInstance),
+		zones:    make(map[string]*ZoneInstance),
+		perks:    worldPerks,
+		sky:      NewPerkCache(nil, nil),
+		calendar: NewCalendar(DefaultHoursPerTick),
 	}
 
 	// Build zone instances
@@ -82,6 +87,9 @@ func NewWorldState(zones storage.Storer[*assets.Zone], rooms storage.Storer[*ass
 				return nil, err
 			}
 			zi.AddRoom(ri)
+			if !ri.IsIndoors() {
+				ri.Perks.AddSource("sky", w.sky)
+			}
 		}
 	}
 
@@ -103,6 +111,59 @@ func NewWorldState(zones storage.Storer[*assets.Zone], rooms storage.Storer[*ass
 	return w, nil
 }
 
+// Calendar returns the world clock.
+func (w *WorldState) Calendar() *Calendar {
+	return w.calendar
+}
+
+// SetCalendar replaces the world clock, e.g. to apply a configured speed,
+// and brings the sky in line with the new time.
+func (w *WorldState) SetCalendar(c *Calendar) {
+	w.calendar = c
+	w.updateSky()
+}
+
+// updateSky darkens outdoor rooms until dawn if it is night.
+func (w *WorldState) updateSky() {
+	if w.calendar.TimeOfDay() != assets.TimeNight {
+		return
+	}
+	w.sky.AddTimedPerks("night", nightPerks, w.calendar.TicksUntilDawn())
+}
+
+// nightPerks are granted to every outdoor room between nightfall and dawn.
+var nightPerks = []assets.Perk{{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagDark)}}
+
+// sunMessages announce the start of each phase of the day outdoors.
+var sunMessages = map[assets.TimeOfDay]string{
+	assets.TimeDawn:  "The sun rises in the east.",
+	assets.TimeDay:   "The day has begun.",
+	assets.TimeDusk:  "The sun slowly disappears in the west.",
+	assets.TimeNight: "The night has begun.",
+}
+
+// tickCalendar advances the clock and, on each new game hour, announces
+// sunrise and sunset and evolves every zone's weather.
+func (w *WorldState) tickCalendar() {
+	before := w.calendar.TimeOfDay()
+	if w.calendar.Advance() == 0 {
+		return
+	}
+	now := w.calendar.TimeOfDay()
+	if now != before {
+		w.updateSky()
+	}
+	month := w.calendar.Month()
+	for _, zi := range w.zones {
+		if now != before {
+			zi.PublishOutdoors([]byte(sunMessages[now]))
+		}
+		if msg := zi.weather.Tick(month); msg != "" {
+			zi.PublishOutdoors([]byte(msg))
+		}
+	}
+}
+
 // Perks returns the world-level perk cache.
 func (w *WorldState) Perks() *PerkCache {
 	return w.perks
@@ -119,6 +180,16 @@ func (w *WorldState) GetZone(zoneId string) *ZoneInstance {
 	return w.zones[zoneId]
 }
 
+// GetRoom returns the room with the given ID in the given zone.
+// Returns nil if either is not found.
+func (w *WorldState) GetRoom(zoneId, roomId string) *RoomInstance {
+	zi := w.zones[zoneId]
+	if zi == nil {
+		return nil
+	}
+	return zi.GetRoom(roomId)
+}
+
 // GetPlayer returns the player state. Returns nil if player not found.
 func (w *WorldState) GetPlayer(charId string) *CharacterInstance {
 	w.mu.RLock()
@@ -224,7 +295,9 @@ func (w *WorldState) Tick(ctx context.Context) error {
 		}
 	}
 
+	w.tickCalendar()
 	w.perks.Tick()
+	w.sky.Tick()
 	players := w.snapshotPlayers()
 	for _, ci := range players {
 		ci.Tick(ctx)
@@ -232,6 +305,7 @@ func (w *WorldState) Tick(ctx context.Context) error {
 	for _, zi := range w.zones {
 		zi.Tick()
 	}
+	w.tickRoomTriggers(ctx)
 	w.tickMobs(ctx)
 
 	for _, ci := range players {
@@ -254,6 +328,15 @@ func (w *WorldState) snapshotPlayers() []*CharacterInstance {
 	return players
 }
 
+// tickRoomTriggers runs the random triggers of rooms with players in them.
+func (w *WorldState) tickRoomTriggers(ctx context.Context) {
+	for _, zi := range w.zones {
+		zi.ForEachRoom(func(_ string, ri *RoomInstance) {
+			ri.triggerTick(ctx)
+		})
+	}
+}
+
 // tickMobs snapshots all mobs in the world and ticks each one once.
 // A world-level snapshot prevents double-ticking when mobs wander across
 // rooms or zones during their tick.
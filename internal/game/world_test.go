@@ -114,6 +114,27 @@ func TestWorldState_GetZone(t *testing.T) {
 	}
 }
 
+func TestWorldState_GetRoom(t *testing.T) {
+	tests := map[string]struct {
+		zoneId  string
+		roomId  string
+		wantNil bool
+	}{
+		"existing room returns instance": {zoneId: "z1", roomId: "r1"},
+		"missing room returns nil":       {zoneId: "z1", roomId: "nope", wantNil: true},
+		"missing zone returns nil":       {zoneId: "nope", roomId: "r1", wantNil: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, _, _ := newTestWorld()
+			got := w.GetRoom(tc.zoneId, tc.roomId)
+			if tc.wantNil != (got == nil) {
+				t.Errorf("GetRoom(%q, %q) = %v, wantNil %v", tc.zoneId, tc.roomId, got, tc.wantNil)
+			}
+		})
+	}
+}
+
 func TestWorldState_GetPlayer(t *testing.T) {
 	tests := map[string]struct {
 		addPlayer bool
@@ -356,3 +377,77 @@ func TestWorldState_Tick(t *testing.T) {
 		})
 	}
 }
+
+func TestWorldState_tickCalendar(t *testing.T) {
+	tests := map[string]struct {
+		hour, minute   int
+		wantDark       bool
+		wantOutdoorMsg string
+	}{
+		"nightfall darkens the sky": {
+			hour: 21, minute: 59, wantDark: true, wantOutdoorMsg: "The night has begun.",
+		},
+		"sunrise": {
+			hour: 4, minute: 59, wantDark: false, wantOutdoorMsg: "The sun rises in the east.",
+		},
+		"quiet hour": {
+			hour: 13, minute: 30, wantDark: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			zone := &assets.Zone{ResetMode: assets.ZoneResetNever}
+			zoneRef := storage.NewResolvedSmartIdentifier("z1", zone)
+			w, err := NewWorldState(
+				newFakeStore[*assets.Zone](map[string]*assets.Zone{"z1": zone}),
+				newFakeStore[*assets.Room](map[string]*assets.Room{
+					"field": {Name: "Field", Zone: zoneRef},
+					"hut": {Name: "Hut", Zone: zoneRef, Perks: []assets.Perk{
+						{Type: assets.PerkTypeGrant, Key: string(assets.RoomFlagIndoors)},
+					}},
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewWorldState: %v", err)
+			}
+			w.calendar = calendarAt(0, tc.hour, tc.minute, 1)
+			zi := w.GetZone("z1")
+			zi.weather.randIntN = func(int) int { return 3 } // hold a clear sky
+			field, hut := zi.GetRoom("field"), zi.GetRoom("hut")
+
+			outside := make(chan []byte, 10)
+			inside := make(chan []byte, 10)
+			for _, p := range []struct {
+				room *RoomInstance
+				msgs chan []byte
+			}{{field, outside}, {hut, inside}} {
+				ci, err := NewCharacterInstance(storage.NewResolvedSmartIdentifier(p.room.Room.Id(), &assets.Character{Name: "P"}), p.msgs, p.room)
+				if err != nil {
+					t.Fatalf("NewCharacterInstance: %v", err)
+				}
+				p.room.AddPlayer(p.room.Room.Id(), ci)
+			}
+
+			w.tickCalendar()
+
+			if got := field.Perks.HasGrant(string(assets.RoomFlagDark), ""); got != tc.wantDark {
+				t.Errorf("outdoor room dark = %v, want %v", got, tc.wantDark)
+			}
+			if hut.Perks.HasGrant(string(assets.RoomFlagDark), "") {
+				t.Error("indoor room went dark")
+			}
+			var got string
+			select {
+			case msg := <-outside:
+				got = string(msg)
+			default:
+			}
+			if got != tc.wantOutdoorMsg {
+				t.Errorf("outdoor message = %q, want %q", got, tc.wantOutdoorMsg)
+			}
+			if len(inside) != 0 {
+				t.Errorf("indoor player got %d messages, want none", len(inside))
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"time"
 
 	"github.com/pixil98/go-mud/internal/assets"
@@ -13,13 +14,15 @@ import (
 type ZoneInstance struct {
 	Zone storage.SmartIdentifier[*assets.Zone]
 
-	world *WorldState
-	clock func() time.Time // source of current time for reset scheduling; defaults to time.Now
+	world    *WorldState
+	clock    func() time.Time // source of current time for reset scheduling; defaults to time.Now
+	randIntN func(int) int    // source of randomness for spawn chances; defaults to rand.IntN
 
 	nextReset        time.Time     // when zone should next reset (runtime only)
 	lifespanDuration time.Duration // parsed lifespan
 
-	rooms map[string]*RoomInstance
+	rooms   map[string]*RoomInstance
+	weather *Weather
 
 	Perks *PerkCache
 }
@@ -36,11 +39,13 @@ func NewZoneInstance(zone storage.SmartIdentifier[*assets.Zone], world *WorldSta
 		return nil, fmt.Errorf("unable to create instance from unresolved zone %q", zone.Id())
 	}
 	zi := &ZoneInstance{
-		Zone:  zone,
-		world: world,
-		clock: time.Now,
-		rooms: make(map[string]*RoomInstance),
-		Perks: NewPerkCache(def.Perks, nil),
+		Zone:     zone,
+		world:    world,
+		clock:    time.Now,
+		randIntN: rand.IntN,
+		rooms:    make(map[string]*RoomInstance),
+		weather:  NewWeather(),
+		Perks:    NewPerkCache(def.Perks, nil),
 	}
 	if def.Lifespan != "" {
 		d, err := time.ParseDuration(def.Lifespan)
@@ -77,8 +82,10 @@ func (z *ZoneInstance) Reset(force bool, cf CommanderFactory) error {
 		}
 	}
 
+	strays := z.collectStrays()
+	pass := &resetPass{cf: cf, census: newSpawnCensus(z.censusZones(), z), randIntN: z.randIntN}
 	for _, ri := range z.rooms {
-		err := ri.Reset(cf)
+		err := ri.reset(pass, strays[ri])
 		if err != nil {
 			return fmt.Errorf("resetting zone %q: %w", z.Zone.Id(), err)
 		}
@@ -93,6 +100,61 @@ func (z *ZoneInstance) Reset(force bool, cf CommanderFactory) error {
 	return nil
 }
 
+// censusZones returns the zones counted toward spawn max limits: the whole
+// world, or just this zone when it isn't part of one.
+func (z *ZoneInstance) censusZones() []*ZoneInstance {
+	if z.world == nil {
+		return []*ZoneInstance{z}
+	}
+	zones := make([]*ZoneInstance, 0, len(z.world.zones))
+	for _, zi := range z.world.zones {
+		zones = append(zones, zi)
+	}
+	return zones
+}
+
+// collectStrays takes the zone's mobs that have wandered into other zones
+// out of the rooms they stand in, so a reset doesn't leave them there beside
+// fresh copies. Under the recall policy they are returned keyed by home room
+// to take up their spawn slots again; otherwise they are simply gone and
+// fresh copies spawn in their place. Strays in a fight or following a player
+// are left be; they keep their spawn slots and still count toward the
+// zone's spawn limits, so no copy spawns while they're away.
+func (z *ZoneInstance) collectStrays() map[*RoomInstance][]*MobileInstance {
+	if z.world == nil {
+		return nil
+	}
+	var strays []*MobileInstance
+	for _, other := range z.world.zones {
+		if other == z {
+			continue
+		}
+		other.ForEachRoom(func(_ string, ri *RoomInstance) {
+			ri.ForEachMob(func(mi *MobileInstance) {
+				if mi.homeZone() != z || mi.IsInCombat() {
+					return
+				}
+				if f := mi.Following(); f != nil && f.IsCharacter() {
+					return
+				}
+				strays = append(strays, mi)
+			})
+		})
+	}
+
+	recall := z.Zone.Get().Strays == assets.ZoneStraysRecall
+	recalled := make(map[*RoomInstance][]*MobileInstance)
+	for _, mi := range strays {
+		from := mi.Room()
+		from.RemoveMob(mi.Id())
+		from.RefreshLight()
+		if recall {
+			recalled[mi.home] = append(recalled[mi.home], mi)
+		}
+	}
+	return recalled
+}
+
 // Tick advances one game tick for the zone.
 func (z *ZoneInstance) Tick() {
 	z.Perks.Tick()
@@ -116,6 +178,20 @@ func (z *ZoneInstance) Publish(data []byte, exclude []string) {
 	}
 }
 
+// PublishOutdoors delivers data to every player in the zone's outdoor rooms.
+func (z *ZoneInstance) PublishOutdoors(data []byte) {
+	for _, ri := range z.rooms {
+		if !ri.IsIndoors() {
+			ri.Publish(data, nil)
+		}
+	}
+}
+
+// Weather returns the zone's weather.
+func (z *ZoneInstance) Weather() *Weather {
+	return z.weather
+}
+
 // ForEachRoom calls fn for each room in the zone.
 func (z *ZoneInstance) ForEachRoom(fn func(roomId string, room *RoomInstance)) {
 	for id, ri := range z.rooms {
@@ -293,7 +293,7 @@ func TestZoneInstance_Reset(t *testing.T) {
 		force     bool
 		pastReset bool // leave nextReset at zero (before any "now") to trigger reset
 		addPlayer bool
-		wantReset bool // if true, expect the pre-placed object to be drained from the room
+		wantReset bool // if true, expect the pre-placed mob to be cleared from the room
 	}{
 		"never mode skips without force":            {mode: assets.ZoneResetNever, wantReset: false},
 		"lifespan mode with future nextReset skips": {mode: assets.ZoneResetLifespan, pastReset: false, wantReset: false},
@@ -318,7 +318,7 @@ func TestZoneInstance_Reset(t *testing.T) {
 
 			ri := newTestRoom("r")
 			zi.AddRoom(ri)
-			ri.AddObj(newTestObj("sword")) // sentinel: drained on reset, kept if skipped
+			ri.AddMob(newTestMI("rat", "a rat")) // sentinel: no spawn owns it, so reset clears it
 
 			if tc.addPlayer {
 				ri.AddPlayer("p", newTestCI("p", "player"))
@@ -328,12 +328,110 @@ func TestZoneInstance_Reset(t *testing.T) {
 				t.Fatalf("Reset: %v", err)
 			}
 
-			count := len(ri.FindObjs(func(*ObjectInstance) bool { return true }))
+			count := len(ri.FindMobs(func(*MobileInstance) bool { return true }))
 			if tc.wantReset && count != 0 {
-				t.Errorf("expected room objects drained after reset, got %d", count)
+				t.Errorf("expected room mobs cleared after reset, got %d", count)
 			}
 			if !tc.wantReset && count != 1 {
-				t.Errorf("expected 1 object (reset skipped), got %d", count)
+				t.Errorf("expected 1 mob (reset skipped), got %d", count)
+			}
+		})
+	}
+}
+
+func TestZoneInstance_ResetStrays(t *testing.T) {
+	tests := map[string]struct {
+		strays    string
+		wander    bool   // walk the wolf out of the forest before the reset
+		fighting  bool   // put the wolf in a fight first
+		zoneMax   bool   // the den has a second wolf spawn, limited to one per zone
+		resetZone string // zone to reset; defaults to the forest
+		wantDen   int
+		wantField int
+		wantSame  bool // the wolf in the den is the one that wandered off
+	}{
+		"wolf at home is kept": {
+			wantDen:  1,
+			wantSame: true,
+		},
+		"stray despawns": {
+			wander:  true,
+			wantDen: 1,
+		},
+		"stray is recalled instead of replaced": {
+			strays:   assets.ZoneStraysRecall,
+			wander:   true,
+			wantDen:  1,
+			wantSame: true,
+		},
+		"fighting stray is left be and not replaced": {
+			wander:    true,
+			fighting:  true,
+			wantField: 1,
+		},
+		"fighting stray counts toward the zone max": {
+			wander:    true,
+			fighting:  true,
+			zoneMax:   true,
+			wantField: 1,
+		},
+		"other zone's reset keeps the stray": {
+			wander:    true,
+			resetZone: "plains",
+			wantField: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wolf := storage.NewResolvedSmartIdentifier("wolf", &assets.Mobile{Aliases: []string{"wolf"}, ShortDesc: "a wolf"})
+			forest := &assets.Zone{ResetMode: assets.ZoneResetNever, Strays: tc.strays}
+			plains := &assets.Zone{ResetMode: assets.ZoneResetNever}
+			spawns := []assets.MobSpawn{{Mobile: wolf}}
+			if tc.zoneMax {
+				spawns = append(spawns, assets.MobSpawn{Mobile: wolf, SpawnRule: assets.SpawnRule{Max: 1, MaxScope: assets.SpawnScopeZone}})
+			}
+			w, err := NewWorldState(
+				newFakeStore(map[string]*assets.Zone{"forest": forest, "plains": plains}),
+				newFakeStore(map[string]*assets.Room{
+					"den":   {Name: "Den", Zone: storage.NewResolvedSmartIdentifier("forest", forest), MobSpawns: spawns},
+					"field": {Name: "Field", Zone: storage.NewResolvedSmartIdentifier("plains", plains)},
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewWorldState: %v", err)
+			}
+			if err := w.ResetAll(); err != nil {
+				t.Fatalf("ResetAll: %v", err)
+			}
+			den, field := w.GetRoom("forest", "den"), w.GetRoom("plains", "field")
+			mobs := func(ri *RoomInstance) []*MobileInstance {
+				return ri.FindMobs(func(*MobileInstance) bool { return true })
+			}
+			first := mobs(den)[0]
+			if tc.wander {
+				first.Move(den, field)
+			}
+			if tc.fighting {
+				foe := newEnemyMI("foe")
+				first.EnsureThreat(foe.Id(), foe)
+			}
+
+			zone := tc.resetZone
+			if zone == "" {
+				zone = "forest"
+			}
+			if err := w.GetZone(zone).Reset(true, nil); err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+
+			if got := len(mobs(den)); got != tc.wantDen {
+				t.Errorf("wolves in den = %d, want %d", got, tc.wantDen)
+			}
+			if got := len(mobs(field)); got != tc.wantField {
+				t.Errorf("wolves in field = %d, want %d", got, tc.wantField)
+			}
+			if tc.wantDen > 0 && (mobs(den)[0] == first) != tc.wantSame {
+				t.Errorf("den wolf is the wanderer = %v, want %v", mobs(den)[0] == first, tc.wantSame)
 			}
 		})
 	}
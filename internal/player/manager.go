@@ -144,7 +144,7 @@ func (m *PlayerManager) newPlayer(conn io.ReadWriter) (*Player, error) {
 	}
 
 	// Resolve foreign keys on the character
-	if err := char.Resolve(m.dict.Pronouns, m.dict.Races, m.dict.Objects); err != nil {
+	if err := char.Resolve(m.dict.Pronouns, m.dict.Races, m.dict.Objects, m.dict.Quests); err != nil {
 		return nil, fmt.Errorf("resolving character references: %w", err)
 	}
 
@@ -255,6 +255,11 @@ func (m *PlayerManager) initCharacter(rw io.ReadWriter, char *assets.Character)
 		char.Level = 1
 	}
 
+	// Characters without a bind point recall to where new players start.
+	if char.BindRoom == "" {
+		char.BindZone, char.BindRoom = m.defaultZone, m.defaultRoom
+	}
+
 	return nil
 }
 
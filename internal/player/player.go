@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -105,7 +106,13 @@ func (p *Player) Play(ctx context.Context) error {
 					return fmt.Errorf("player state not found for %s", p.charId)
 				}
 
-				err = p.cmdHandler.Exec(ctx, ps, parts[0], parts[1:]...)
+				cmd, args := parts[0], parts[1:]
+				// Mid-conversation, a bare number picks a reply.
+				if _, numErr := strconv.Atoi(cmd); numErr == nil && len(args) == 0 && ps.Conversing() {
+					cmd, args = "reply", parts
+				}
+
+				err = p.cmdHandler.Exec(ctx, ps, cmd, args...)
 				if err != nil {
 					var userErr *commands.UserError
 					if errors.As(err, &userErr) {
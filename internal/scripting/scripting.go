@@ -0,0 +1,82 @@
+// Package scripting compiles and runs the Starlark code builders attach to
+// triggers and ability effects. It knows nothing of the game: callers supply
+// the names a program may use when compiling and their values when running.
+package scripting
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// MaxSteps caps the Starlark steps one run may take, so a runaway loop fails
+// instead of stalling the world tick.
+const MaxSteps = 100_000
+
+// fileOptions enables the language features builders expect: top-level
+// loops and ifs, while loops, sets and reassigning globals. Recursion stays
+// off; MaxSteps bounds loops but not the Go stack.
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+}
+
+// Program is compiled Starlark code, safe to run any number of times.
+type Program struct {
+	name string
+	prog *starlark.Program
+}
+
+// Compile parses and resolves src. Besides Starlark's builtins, the code may
+// only refer to the names in predeclared; anything else is a compile error,
+// so typos surface when assets load rather than when the code first runs.
+func Compile(name, src string, predeclared []string) (*Program, error) {
+	_, prog, err := starlark.SourceProgramOptions(fileOptions, name, src, func(n string) bool {
+		return slices.Contains(predeclared, n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if prog.NumLoads() > 0 {
+		return nil, errors.New("load is not supported")
+	}
+	return &Program{name: name, prog: prog}, nil
+}
+
+// Run executes the program with the given values for its predeclared names.
+// The run stops with an error once it exceeds MaxSteps or ctx is done.
+func (p *Program) Run(ctx context.Context, predeclared starlark.StringDict) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	thread := &starlark.Thread{
+		Name: p.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			slog.Debug("script print", "script", p.name, "msg", msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(MaxSteps)
+	stop := context.AfterFunc(ctx, func() { thread.Cancel(context.Cause(ctx).Error()) })
+	defer stop()
+
+	_, err := p.prog.Init(thread, predeclared)
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return &runError{evalErr}
+	}
+	return err
+}
+
+// runError reports a failed run with its Starlark backtrace. It unwraps to
+// the error a builtin returned, if that is what stopped the run.
+type runError struct {
+	*starlark.EvalError
+}
+
+func (e *runError) Error() string { return e.Backtrace() }
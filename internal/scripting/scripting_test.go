@@ -0,0 +1,92 @@
+package scripting
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.starlark.net/starlark"
+)
+
+func TestCompile(t *testing.T) {
+	tests := map[string]struct {
+		src    string
+		expErr bool
+	}{
+		"predeclared names":   {src: "out.append(x * 2)"},
+		"top-level control":   {src: "for i in range(3):\n    if i > 1:\n        out.append(i)\n"},
+		"while loops":         {src: "n = 0\nwhile n < 3:\n    n += 1\n"},
+		"unknown name":        {src: "out.append(y)", expErr: true},
+		"syntax error":        {src: "out.append(", expErr: true},
+		"load is unsupported": {src: `load("x.star", "y")`, expErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile("test", tc.src, []string{"out", "x"})
+			if (err != nil) != tc.expErr {
+				t.Errorf("Compile() error = %v, expErr %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestProgram_Run(t *testing.T) {
+	stop := errors.New("stop")
+	halt := starlark.NewBuiltin("halt", func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+		return nil, stop
+	})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		src     string
+		ctx     context.Context
+		want    int
+		expErr  bool
+		wantErr error
+	}{
+		"runs to completion": {
+			src:  "for i in range(4):\n    out.append(i)\n",
+			want: 4,
+		},
+		"runaway loop hits the step limit": {
+			src:    "while True:\n    pass\n",
+			expErr: true,
+		},
+		"cancelled context": {
+			src:    "out.append(1)",
+			ctx:    cancelled,
+			expErr: true,
+		},
+		"builtin error unwraps": {
+			src:     "halt()",
+			expErr:  true,
+			wantErr: stop,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			prog, err := Compile("test", tc.src, []string{"out", "halt"})
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			out := starlark.NewList(nil)
+
+			err = prog.Run(ctx, starlark.StringDict{"out": out, "halt": halt})
+
+			if (err != nil) != tc.expErr {
+				t.Fatalf("Run() error = %v, expErr %v", err, tc.expErr)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tc.wantErr)
+			}
+			if !tc.expErr && out.Len() != tc.want {
+				t.Errorf("out has %d items, want %d", out.Len(), tc.want)
+			}
+		})
+	}
+}
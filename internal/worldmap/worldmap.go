@@ -0,0 +1,236 @@
+// Package worldmap lays rooms out on a compass grid and renders them as an
+// ASCII map. It knows nothing about live game state: callers describe the
+// world through a Graph, so the same layout serves the in-game map command
+// and offline tools working from room assets.
+package worldmap
+
+import (
+	"sort"
+	"strings"
+)
+
+// Exit is one way out of a room as the map sees it.
+type Exit struct {
+	Direction string
+	To        string // destination room id; "" if the exit leads nowhere
+	Closed    bool   // a closed door blocks the exit
+}
+
+// Graph supplies the rooms to lay out.
+type Graph interface {
+	// Exits returns the exits of the room the map should show.
+	Exits(roomId string) []Exit
+	// Zone returns the id of the zone the room belongs to.
+	Zone(roomId string) string
+}
+
+// offsets maps each planar direction to its grid step. Up and down have no
+// offset and are drawn as markers on the room instead.
+var offsets = map[string][2]int{
+	"north":     {0, -1},
+	"south":     {0, 1},
+	"east":      {1, 0},
+	"west":      {-1, 0},
+	"northeast": {1, -1},
+	"northwest": {-1, -1},
+	"southeast": {1, 1},
+	"southwest": {-1, 1},
+}
+
+// Cell is a room placed on the grid.
+type Cell struct {
+	Room  string
+	Zone  string
+	X, Y  int
+	Up    bool
+	Down  bool
+	Exits []Exit
+}
+
+// Grid is the result of a layout: rooms keyed by position, with the room the
+// layout started from at the origin.
+type Grid struct {
+	Start string
+	cells map[[2]int]*Cell
+	rooms map[string]*Cell
+}
+
+// Layout walks the graph breadth-first from start, placing each room one
+// grid step from the room it was reached from in the exit's direction.
+// Rooms further than radius steps away, rooms for which include returns
+// false, and rooms whose position is already taken are left off the grid.
+// A nil include admits every room.
+func Layout(g Graph, start string, radius int, include func(roomId string) bool) *Grid {
+	grid := &Grid{
+		Start: start,
+		cells: make(map[[2]int]*Cell),
+		rooms: make(map[string]*Cell),
+	}
+	grid.place(g, start, 0, 0)
+
+	type step struct {
+		room  string
+		depth int
+	}
+	queue := []step{{start, 0}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.depth >= radius {
+			continue
+		}
+		from := grid.rooms[cur.room]
+		for _, ex := range from.Exits {
+			off, planar := offsets[ex.Direction]
+			if !planar || ex.To == "" {
+				continue
+			}
+			if _, seen := grid.rooms[ex.To]; seen {
+				continue
+			}
+			if include != nil && !include(ex.To) {
+				continue
+			}
+			x, y := from.X+off[0], from.Y+off[1]
+			if _, taken := grid.cells[[2]int{x, y}]; taken {
+				continue
+			}
+			grid.place(g, ex.To, x, y)
+			queue = append(queue, step{ex.To, cur.depth + 1})
+		}
+	}
+	return grid
+}
+
+// place puts a room on the grid at (x, y).
+func (gr *Grid) place(g Graph, room string, x, y int) {
+	exits := g.Exits(room)
+	sort.Slice(exits, func(i, j int) bool { return exits[i].Direction < exits[j].Direction })
+	c := &Cell{Room: room, Zone: g.Zone(room), X: x, Y: y, Exits: exits}
+	for _, ex := range exits {
+		switch ex.Direction {
+		case "up":
+			c.Up = true
+		case "down":
+			c.Down = true
+		}
+	}
+	gr.cells[[2]int{x, y}] = c
+	gr.rooms[room] = c
+}
+
+// Cell returns the placed cell for a room, or nil if the room is not on the grid.
+func (gr *Grid) Cell(roomId string) *Cell {
+	return gr.rooms[roomId]
+}
+
+// Len returns the number of rooms on the grid.
+func (gr *Grid) Len() int {
+	return len(gr.rooms)
+}
+
+// RenderOptions controls how rooms are drawn.
+type RenderOptions struct {
+	// Here is the room marked as the viewer's position, if any.
+	Here string
+	// Dim reports rooms to draw without detail (e.g. too dark to make out).
+	Dim func(roomId string) bool
+}
+
+// Map symbols. Rooms are three characters wide: brackets around a marker,
+// with braces instead of brackets for rooms in another zone than the start.
+const (
+	symbolHere     = '*'
+	symbolUp       = '^'
+	symbolDown     = 'v'
+	symbolUpDown   = '%'
+	symbolDim      = '?'
+	symbolClosed   = '+'
+	symbolCrossing = 'X'
+)
+
+// Legend explains the map symbols.
+const Legend = "[*] you  [^] up  [v] down  [%] up and down  [?] too dark to see\n" +
+	"{ } another zone  - | / \\ exits  + closed door"
+
+// Render draws the grid. Each room takes three columns and one row, with a
+// column and a row between neighbours for the connecting exits; exits to
+// rooms off the grid are drawn as stubs.
+func (gr *Grid) Render(opts RenderOptions) string {
+	if len(gr.rooms) == 0 {
+		return ""
+	}
+	minX, minY, maxX, maxY := 0, 0, 0, 0
+	for pos := range gr.cells {
+		minX, maxX = min(minX, pos[0]), max(maxX, pos[0])
+		minY, maxY = min(minY, pos[1]), max(maxY, pos[1])
+	}
+
+	// One spare column and row around the edge leaves room for stubs.
+	width := (maxX-minX+1)*4 + 1
+	height := (maxY-minY+1)*2 + 1
+	canvas := make([][]rune, height)
+	for i := range canvas {
+		canvas[i] = []rune(strings.Repeat(" ", width))
+	}
+	col := func(x int) int { return (x-minX)*4 + 1 }
+	row := func(y int) int { return (y-minY)*2 + 1 }
+
+	startZone := gr.rooms[gr.Start].Zone
+	for _, c := range gr.cells {
+		r, cx := row(c.Y), col(c.X)
+		open, shut := '[', ']'
+		if c.Zone != startZone {
+			open, shut = '{', '}'
+		}
+		canvas[r][cx], canvas[r][cx+2] = open, shut
+		canvas[r][cx+1] = gr.marker(c, opts)
+
+		for _, ex := range c.Exits {
+			off, planar := offsets[ex.Direction]
+			if !planar {
+				continue
+			}
+			// Connectors sit halfway between this room's centre and its neighbour's.
+			cr := r + off[1]
+			cc := cx + 1 + off[0]*2
+			link := map[[2]int]rune{
+				{0, -1}: '|', {0, 1}: '|', {1, 0}: '-', {-1, 0}: '-',
+				{1, -1}: '/', {-1, 1}: '/', {1, 1}: '\\', {-1, -1}: '\\',
+			}[off]
+			if ex.Closed {
+				link = symbolClosed
+			}
+			switch existing := canvas[cr][cc]; {
+			case existing == ' ' || existing == link:
+				canvas[cr][cc] = link
+			case (existing == '/' && link == '\\') || (existing == '\\' && link == '/'):
+				canvas[cr][cc] = symbolCrossing
+			}
+		}
+	}
+
+	lines := make([]string, height)
+	for i, line := range canvas {
+		lines[i] = strings.TrimRight(string(line), " ")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// marker returns the character drawn inside a room's brackets.
+func (gr *Grid) marker(c *Cell, opts RenderOptions) rune {
+	switch {
+	case c.Room == opts.Here:
+		return symbolHere
+	case opts.Dim != nil && opts.Dim(c.Room):
+		return symbolDim
+	case c.Up && c.Down:
+		return symbolUpDown
+	case c.Up:
+		return symbolUp
+	case c.Down:
+		return symbolDown
+	default:
+		return ' '
+	}
+}
@@ -0,0 +1,206 @@
+package worldmap
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeGraph is an in-memory Graph: room -> exits, with every room in zone
+// "a" unless listed in zones.
+type fakeGraph struct {
+	exits map[string][]Exit
+	zones map[string]string
+}
+
+func (g fakeGraph) Exits(roomId string) []Exit {
+	return append([]Exit(nil), g.exits[roomId]...)
+}
+
+func (g fakeGraph) Zone(roomId string) string {
+	if z, ok := g.zones[roomId]; ok {
+		return z
+	}
+	return "a"
+}
+
+// crossroads is a plus-shaped area: a centre with a room in each cardinal
+// direction, and a second room further north.
+var crossroads = fakeGraph{
+	exits: map[string][]Exit{
+		"centre": {
+			{Direction: "north", To: "n1"},
+			{Direction: "south", To: "s1"},
+			{Direction: "east", To: "e1"},
+			{Direction: "west", To: "w1"},
+		},
+		"n1": {{Direction: "south", To: "centre"}, {Direction: "north", To: "n2"}},
+		"n2": {{Direction: "south", To: "n1"}},
+		"s1": {{Direction: "north", To: "centre"}},
+		"e1": {{Direction: "west", To: "centre"}},
+		"w1": {{Direction: "east", To: "centre"}},
+	},
+}
+
+func TestLayout(t *testing.T) {
+	tests := map[string]struct {
+		graph      fakeGraph
+		radius     int
+		include    func(string) bool
+		wantPos    map[string][2]int
+		wantAbsent []string
+	}{
+		"rooms placed by direction": {
+			graph:  crossroads,
+			radius: 2,
+			wantPos: map[string][2]int{
+				"centre": {0, 0}, "n1": {0, -1}, "n2": {0, -2},
+				"s1": {0, 1}, "e1": {1, 0}, "w1": {-1, 0},
+			},
+		},
+		"radius limits depth": {
+			graph:      crossroads,
+			radius:     1,
+			wantPos:    map[string][2]int{"n1": {0, -1}},
+			wantAbsent: []string{"n2"},
+		},
+		"excluded rooms and what lies beyond them are left off": {
+			graph:      crossroads,
+			radius:     3,
+			include:    func(id string) bool { return id != "n1" },
+			wantPos:    map[string][2]int{"e1": {1, 0}},
+			wantAbsent: []string{"n1", "n2"},
+		},
+		"diagonals and vertical exits": {
+			graph: fakeGraph{exits: map[string][]Exit{
+				"a": {{Direction: "northeast", To: "b"}, {Direction: "up", To: "c"}},
+				"b": {{Direction: "southwest", To: "a"}},
+			}},
+			radius:     2,
+			wantPos:    map[string][2]int{"a": {0, 0}, "b": {1, -1}},
+			wantAbsent: []string{"c"},
+		},
+		"second room for a taken position is dropped": {
+			graph: fakeGraph{exits: map[string][]Exit{
+				"a": {{Direction: "east", To: "b"}, {Direction: "north", To: "c"}},
+				"b": {{Direction: "northwest", To: "d"}},
+			}},
+			radius:     2,
+			wantPos:    map[string][2]int{"c": {0, -1}},
+			wantAbsent: []string{"d"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := "centre"
+			if _, ok := tc.graph.exits[start]; !ok {
+				start = "a"
+			}
+			grid := Layout(tc.graph, start, tc.radius, tc.include)
+			for id, want := range tc.wantPos {
+				c := grid.Cell(id)
+				if c == nil {
+					t.Errorf("room %q not placed", id)
+					continue
+				}
+				if got := [2]int{c.X, c.Y}; got != want {
+					t.Errorf("room %q at %v, want %v", id, got, want)
+				}
+			}
+			for _, id := range tc.wantAbsent {
+				if grid.Cell(id) != nil {
+					t.Errorf("room %q placed, want absent", id)
+				}
+			}
+		})
+	}
+}
+
+func TestGrid_Render(t *testing.T) {
+	tests := map[string]struct {
+		graph  fakeGraph
+		start  string
+		radius int
+		opts   RenderOptions
+		want   string
+	}{
+		"crossroads with viewer at the centre": {
+			graph:  crossroads,
+			start:  "centre",
+			radius: 2,
+			opts:   RenderOptions{Here: "centre"},
+			want: strings.Join([]string{
+				"     [ ]",
+				"      |",
+				"     [ ]",
+				"      |",
+				" [ ]-[*]-[ ]",
+				"      |",
+				"     [ ]",
+			}, "\n"),
+		},
+		"closed door, other zone and stub": {
+			graph: fakeGraph{
+				exits: map[string][]Exit{
+					"a": {{Direction: "east", To: "b", Closed: true}, {Direction: "south", To: "c"}},
+					"b": {{Direction: "west", To: "a", Closed: true}},
+					"c": {{Direction: "north", To: "a"}},
+				},
+				zones: map[string]string{"b": "b"},
+			},
+			start:  "a",
+			radius: 1,
+			opts:   RenderOptions{Here: "a"},
+			want: strings.Join([]string{
+				" [*]+{ }",
+				"  |",
+				" [ ]",
+			}, "\n"),
+		},
+		"vertical markers and dimmed rooms": {
+			graph: fakeGraph{exits: map[string][]Exit{
+				"a": {{Direction: "east", To: "b"}, {Direction: "up", To: "x"}, {Direction: "down", To: "y"}},
+				"b": {{Direction: "west", To: "a"}, {Direction: "east", To: "c"}, {Direction: "down", To: "y"}},
+				"c": {{Direction: "west", To: "b"}, {Direction: "up", To: "x"}},
+			}},
+			start:  "a",
+			radius: 2,
+			opts:   RenderOptions{Dim: func(id string) bool { return id == "c" }},
+			want:   " [%]-[v]-[?]",
+		},
+		"exits off the grid drawn as stubs": {
+			graph: fakeGraph{exits: map[string][]Exit{
+				"a": {{Direction: "north", To: "b"}, {Direction: "southwest", To: "c"}},
+			}},
+			start:  "a",
+			radius: 0,
+			want: strings.Join([]string{
+				"  |",
+				" [ ]",
+				"/",
+			}, "\n"),
+		},
+		"crossing diagonals": {
+			graph: fakeGraph{exits: map[string][]Exit{
+				"a": {{Direction: "east", To: "b"}, {Direction: "southeast", To: "d"}, {Direction: "south", To: "c"}},
+				"b": {{Direction: "west", To: "a"}, {Direction: "southwest", To: "c"}},
+				"c": {{Direction: "north", To: "a"}, {Direction: "northeast", To: "b"}},
+				"d": {{Direction: "northwest", To: "a"}},
+			}},
+			start:  "a",
+			radius: 1,
+			want: strings.Join([]string{
+				" [ ]-[ ]",
+				"  | X",
+				" [ ] [ ]",
+			}, "\n"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Layout(tc.graph, tc.start, tc.radius, nil).Render(tc.opts)
+			if got != tc.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
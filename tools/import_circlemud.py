@@ -48,6 +48,7 @@ ROOM_FLAGS = {
     "DARK": {"key": "room_dark"},
     "DEATH": {"key": "room_death"},
     "NOMOB": {"key": "room_nomob"},
+    "INDOORS": {"key": "room_indoors"},
     "PEACEFUL": {"key": "room_peaceful"},
     "NOMAGIC": {"key": "room_nomagic"},
     "TUNNEL": {"key": "room_single_occupant"},
@@ -64,6 +65,13 @@ SECTOR_TYPES = {
     9: "FLYING",
 }
 
+# CircleMUD sector name -> go-mud room sector.
+SECTOR_NAMES = {
+    "INSIDE": "inside", "CITY": "city", "FIELD": "field", "FOREST": "forest",
+    "HILLS": "hills", "MOUNTAIN": "mountain", "WATER_SWIM": "water_swim",
+    "WATER_NOSWIM": "water_noswim", "UNDERWATER": "underwater", "FLYING": "air",
+}
+
 # ---------------------------------------------------------------------------
 # Mobile constants
 # ---------------------------------------------------------------------------
@@ -157,6 +165,17 @@ OBJ_TYPE_MAP = {
     21: "PEN", 22: "BOAT", 23: "FOUNTAIN",
 }
 
+# Game ticks per CircleMUD hour, used to convert light burn times.
+TICKS_PER_MUD_HOUR = 30
+
+# Liquid type (DRINKCON/FOUNTAIN value 2) -> liquid name.
+LIQUID_TYPES = {
+    0: "water", 1: "beer", 2: "wine", 3: "ale", 4: "dark ale", 5: "whisky",
+    6: "lemonade", 7: "firebreather", 8: "local speciality", 9: "slime mold juice",
+    10: "milk", 11: "tea", 12: "coffee", 13: "blood", 14: "salt water",
+    15: "clear water",
+}
+
 OBJ_EFFECT_BITS = {
     0: "GLOW",
     1: "HUM",
@@ -871,8 +890,10 @@ def convert_room(parsed_room, zone_slug, v2z, zones, known_obj_vnums):
         "name": parsed_room["name"],
         "description": collapse_newlines(parsed_room["description"]),
         "zone_id": zone_slug,
-        "exits": exits,
     }
+    if sector in SECTOR_NAMES:
+        room["sector"] = SECTOR_NAMES[sector]
+    room["exits"] = exits
 
     if perks:
         room["perks"] = perks
@@ -880,8 +901,6 @@ def convert_room(parsed_room, zone_slug, v2z, zones, known_obj_vnums):
         room["extra_descs"] = extra_descs
 
     unused = {}
-    if sector:
-        unused["sector_type"] = sector
     if skipped_flags:
         unused["flags"] = skipped_flags
 
@@ -1044,6 +1063,10 @@ def convert_object(parsed_obj, zone_slug, v2z, zones, known_obj_vnums):
 
     # Type-specific handling
     unused = {}
+    obj_food = None
+    obj_drink = None
+    obj_light = None
+    obj_capacity = 0
 
     if type_name == "WEAPON":
         dice = values[1]
@@ -1060,17 +1083,19 @@ def convert_object(parsed_obj, zone_slug, v2z, zones, known_obj_vnums):
             perks.append({"type": "modifier", "key": "core.combat.ac.flat", "value": ac})
 
     elif type_name == "LIGHT":
+        # Burn time is in MUD hours; -1 means the light never burns out and
+        # 0 means it is already spent.
         burn_time = values[2]
-        if burn_time != 0:
-            perks.append({"type": "grant", "key": "ignore_restriction", "arg": "room_dark"})
-        if burn_time > 0:
-            unused["burn_time_hours"] = burn_time
+        # CircleMUD moves held lights into the dedicated light position.
+        slots = ["light" if s == "hold" else s for s in slots]
+        if burn_time < 0:
+            obj_light = {}
+        elif burn_time > 0:
+            obj_light = {"burn": burn_time * TICKS_PER_MUD_HOUR}
 
     elif type_name == "CONTAINER":
         flags.append("container")
-        capacity = values[0]
-        if capacity > 0:
-            unused["capacity"] = capacity
+        obj_capacity = max(0, values[0])
         cflag = values[1]
         if cflag & CONTAINER_CLOSEABLE or cflag & CONTAINER_CLOSED or cflag & CONTAINER_LOCKED:
             closure = {"closed": bool(cflag & CONTAINER_CLOSED)}
@@ -1092,6 +1117,27 @@ def convert_object(parsed_obj, zone_slug, v2z, zones, known_obj_vnums):
                         unused["keyless_lock_pickproof"] = True
             unused["closure"] = closure
 
+    elif type_name == "FOOD":
+        obj_food = {"fill": max(1, values[0])}
+        if values[3] != 0:
+            unused["poisoned"] = True
+
+    elif type_name in ("DRINKCON", "FOUNTAIN"):
+        liquid = LIQUID_TYPES.get(values[2], "water")
+        if type_name == "FOUNTAIN":
+            obj_drink = {"liquid": liquid, "infinite": True}
+            if "immobile" not in flags:
+                flags.append("immobile")
+        else:
+            capacity = max(1, values[0])
+            sips = min(max(0, values[1]), capacity)
+            obj_drink = {"capacity": capacity}
+            if sips > 0:
+                obj_drink["liquid"] = liquid
+                obj_drink["sips"] = sips
+        if values[3] != 0:
+            unused["poisoned"] = True
+
     # Affect fields -> perks
     for aff in parsed_obj["affects"]:
         loc_name = AFFECT_LOCATION_MAP.get(aff["location"], "")
@@ -1139,8 +1185,18 @@ def convert_object(parsed_obj, zone_slug, v2z, zones, known_obj_vnums):
         obj["flags"] = flags
     if slots:
         obj["wear_slots"] = slots
+    if parsed_obj["weight"] > 0:
+        obj["weight"] = parsed_obj["weight"]
+    if obj_capacity:
+        obj["capacity"] = obj_capacity
     if perks:
         obj["perks"] = perks
+    if obj_food:
+        obj["food"] = obj_food
+    if obj_drink:
+        obj["drink"] = obj_drink
+    if obj_light is not None:
+        obj["light"] = obj_light
     if extra_descs:
         obj["extra_descs"] = extra_descs
 
@@ -1149,12 +1205,13 @@ def convert_object(parsed_obj, zone_slug, v2z, zones, known_obj_vnums):
         unused["cost"] = parsed_obj["cost"]
     if parsed_obj["rent"] != 0:
         unused["rent"] = parsed_obj["rent"]
-    if parsed_obj["weight"] != 0:
+    if parsed_obj["weight"] < 0:
         unused["weight"] = parsed_obj["weight"]
     if skipped_effects:
         unused["effects"] = skipped_effects
     if type_name not in ("WEAPON", "ARMOR", "LIGHT", "CONTAINER", "KEY",
-                          "TREASURE", "OTHER", "TRASH"):
+                          "TREASURE", "OTHER", "TRASH", "FOOD", "DRINKCON",
+                          "FOUNTAIN"):
         unused["type"] = type_name
         unused["values"] = values
 